@@ -20,65 +20,399 @@ package main
 
 import (
 	"errors"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/dreadl0ck/readline"
 )
 
-// ErrInvalidAlias means there is a name conflict with an existing command
-var ErrInvalidAlias = errors.New("invalid alias")
+var (
+	// ErrInvalidAlias means there is a name conflict with an existing command
+	ErrInvalidAlias = errors.New("invalid alias")
+
+	// ErrUnknownAlias means there is no alias with the name
+	ErrUnknownAlias = errors.New("unknown alias")
+
+	// ErrTooManyAliasArgs means more arguments were supplied than the alias has placeholders
+	ErrTooManyAliasArgs = errors.New("too many arguments for alias")
+
+	// placeholders in alias commands:
+	// $1, $2 ... positional arguments
+	// $@ all positional arguments
+	// ${1:-default} positional argument with a default value
+	// ${name:-default} named argument with a default value, set with name=value
+	aliasPlaceholder = regexp.MustCompile(`\$\{([1-9][0-9]*|[A-Za-z_][A-Za-z0-9_]*):-([^}]*)\}|\$\{([1-9][0-9]*)\}|\$([1-9][0-9]*|@)`)
+)
 
 func printAliasCommandErr() {
 	l.Println(ErrInvalidUsage)
-	l.Println("usage: alias [remove <name>] [set <name> <command>]")
+	l.Println("usage: alias [remove <name>] [[set] <name> <command>]")
 }
 
 // check if an alias name conflicts with builtin user defined command names
 func validateAlias(name string) error {
 
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return errors.New(ErrInvalidAlias.Error() + ": invalid name: " + name)
+	}
+
 	// check for conflict with builtin
-	for _, b := range builtins {
-		if b == name {
-			Log.Error("alias ", name, " conflicts with builtin: ", b)
-			return ErrInvalidAlias
-		}
+	if _, ok := builtins[name]; ok {
+		return errors.New(ErrInvalidAlias.Error() + ": conflicts with builtin: " + name)
 	}
 
 	// check for conflict with user command
-	if command, ok := cmdMap.items[name]; ok {
-		Log.Error("alias ", name, " conflicts with command: ", command.path)
-		return ErrInvalidAlias
+	cmdMap.Lock()
+	command, ok := cmdMap.items[name]
+	cmdMap.Unlock()
+	if ok {
+		return errors.New(ErrInvalidAlias.Error() + ": conflicts with command: " + command.path)
+	}
+
+	return nil
+}
+
+// a placeholder found in an alias command
+type placeholder struct {
+
+	// full placeholder string, for example: ${2:-latest}
+	match string
+
+	// index of positional placeholders, starting at 1
+	// 0 for named placeholders and $@
+	index int
+
+	// name of named placeholders
+	name string
+
+	// $@
+	all bool
+
+	defaultValue string
+	hasDefault   bool
+}
+
+// parse all placeholders from an alias command
+func parsePlaceholders(command string) (res []*placeholder) {
+
+	for _, m := range aliasPlaceholder.FindAllStringSubmatch(command, -1) {
+
+		p := &placeholder{match: m[0]}
+
+		switch {
+		case m[1] != "":
+			p.hasDefault = true
+			p.defaultValue = m[2]
+			if i, err := strconv.Atoi(m[1]); err == nil {
+				p.index = i
+			} else {
+				p.name = m[1]
+			}
+		case m[3] != "":
+			p.index, _ = strconv.Atoi(m[3])
+		case m[4] == "@":
+			p.all = true
+		default:
+			p.index, _ = strconv.Atoi(m[4])
+		}
+
+		res = append(res, p)
+	}
+
+	return
+}
+
+// expand the placeholders of an alias command with the supplied arguments
+// arguments in the name=value format set the named placeholders, all others are positional
+// if the alias has no placeholders, the arguments are appended to the command
+func expandAlias(command string, args []string) (string, error) {
+
+	placeholders := parsePlaceholders(command)
+	if len(placeholders) == 0 {
+		return strings.TrimSpace(command + " " + strings.Join(args, " ")), nil
+	}
+
+	var (
+		named      = make(map[string]string, 0)
+		positional []string
+		used       = make(map[int]bool, 0)
+		usesAll    bool
+	)
+
+	for _, p := range placeholders {
+		if p.name != "" {
+			named[p.name] = p.defaultValue
+		}
+	}
+
+	for _, arg := range args {
+		if i := strings.Index(arg, "="); i > 0 {
+			if _, ok := named[arg[:i]]; ok {
+				named[arg[:i]] = arg[i+1:]
+				continue
+			}
+		}
+		positional = append(positional, arg)
+	}
+
+	var err error
+	expanded := aliasPlaceholder.ReplaceAllStringFunc(command, func(match string) string {
+
+		p := parsePlaceholders(match)[0]
+
+		switch {
+		case p.all:
+			usesAll = true
+			return strings.Join(positional, " ")
+		case p.name != "":
+			return named[p.name]
+		case p.index <= len(positional) && p.index > 0:
+			used[p.index] = true
+			return positional[p.index-1]
+		case p.hasDefault:
+			return p.defaultValue
+		default:
+			if err == nil {
+				err = errors.New("missing argument $" + strconv.Itoa(p.index))
+			}
+			return match
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if !usesAll && len(used) < len(positional) {
+		return "", errors.New(ErrTooManyAliasArgs.Error() + ": " + strings.Join(positional, " "))
+	}
+
+	return expanded, nil
+}
+
+// expand the alias at the beginning of a line
+// if the first field is not an alias, the line is returned unchanged
+func expandAliasLine(line string) (string, error) {
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return line, nil
+	}
+
+	projectData.Lock()
+	command, ok := projectData.fields.Aliases[fields[0]]
+	projectData.Unlock()
+
+	if !ok {
+		return line, nil
+	}
+
+	return expandAlias(command, fields[1:])
+}
+
+// expand the aliases at the beginning of each command of a chain
+// aliases can expand to a chain themselves, the returned fields contain a single command each
+func expandChainAliases(line string) ([]string, error) {
+
+	var expanded []string
+	for _, field := range strings.Split(line, commandChainSeparator) {
+		e, err := expandAliasLine(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, e)
+	}
+
+	return strings.Split(strings.Join(expanded, " "+commandChainSeparator+" "), commandChainSeparator), nil
+}
+
+// validate the ZEUS commands of an alias against their declared arguments
+func validateAliasCommand(command string) error {
+
+	var (
+		placeholders = parsePlaceholders(command)
+		stages       = splitPipeline(command)
+	)
+
+	for i, raw := range stages {
+
+		stage, err := parsePipelineStage(raw)
+		if err != nil {
+			return err
+		}
+
+		parts := strings.Split(stage.line, commandChainSeparator)
+		for k, part := range parts {
+
+			fields := strings.Fields(part)
+			if len(fields) == 0 {
+				return errors.New("empty command in alias")
+			}
+
+			cmd, err := cmdMap.getCommand(fields[0])
+			if err != nil {
+				// not a ZEUS command, will be passed to the shell
+				continue
+			}
+
+			// arguments are appended to the last command if there are no placeholders
+			appendsArgs := len(placeholders) == 0 && i == len(stages)-1 && k == len(parts)-1
+
+			err = validateCommandArgs(cmd, fields[1:], appendsArgs)
+			if err != nil {
+				return errors.New(cmd.name + ": " + err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate the arguments for a command in an alias or a dependency
+// values containing placeholders are checked when the alias is invoked
+func validateCommandArgs(cmd *command, args []string, appendsArgs bool) error {
+
+	labels := make(map[string]bool, 0)
+
+	for _, arg := range args {
+
+		if arg == "$@" {
+			appendsArgs = true
+			continue
+		}
+
+		slice := strings.SplitN(arg, "=", 2)
+		if len(slice) != 2 {
+			return errors.New("invalid argument: " + arg)
+		}
+
+		cmdArg, ok := cmd.args[slice[0]]
+		if !ok {
+			return errors.New(ErrInvalidArgumentLabel.Error() + ": " + slice[0])
+		}
+
+		if labels[slice[0]] {
+			return errors.New("argument label appeared more than once: " + slice[0])
+		}
+		labels[slice[0]] = true
+
+		// check the default value of placeholders
+		value := slice[1]
+		if placeholders := parsePlaceholders(value); len(placeholders) > 0 {
+			if len(placeholders) == 1 && placeholders[0].match == value && placeholders[0].hasDefault && placeholders[0].defaultValue != "" {
+				value = placeholders[0].defaultValue
+			} else {
+				continue
+			}
+		}
+
+		if err := validArgType(value, cmdArg.argType); err != nil {
+			return errors.New(ErrInvalidArgumentType.Error() + ": " + err.Error() + ", label=" + slice[0] + ", value=" + value)
+		}
+	}
+
+	// missing arguments can still be supplied when invoking the alias
+	if appendsArgs {
+		return nil
+	}
+
+	for name, arg := range cmd.args {
+		if !arg.optional && !labels[name] {
+			return errors.New("missing argument: " + name + ":" + strings.Title(arg.argType.String()))
+		}
 	}
 
 	return nil
 }
 
 // add an alias to project data and shell completer
-func addAlias(name, command string) {
+func addAlias(name, command string) error {
 
 	err := validateAlias(name)
 	if err != nil {
-		Log.WithError(err).Error("failed to validate alias: ", name)
-		return
+		return err
+	}
+
+	if strings.TrimSpace(command) == "" {
+		return errors.New("no command for alias: " + name)
+	}
+
+	err = validateAliasCommand(command)
+	if err != nil {
+		return errors.New("invalid command for alias " + name + ": " + err.Error())
 	}
 
 	// add to project data
 	projectData.Lock()
+	_, exists := projectData.fields.Aliases[name]
 	projectData.fields.Aliases[name] = command
 	projectData.Unlock()
 
 	projectData.update()
 
 	// add to completer
-	completer.Lock()
-	completer.Children = append(completer.Children, readline.PcItem(name))
-	completer.Unlock()
+	if !exists {
+		completer.Lock()
+		completer.Children = append(completer.Children, newAliasCompleter(name))
+		completer.Unlock()
+	}
+
+	return nil
 }
 
-func deleteAlias(name string) {
+func deleteAlias(name string) error {
 	projectData.Lock()
+	_, ok := projectData.fields.Aliases[name]
 	delete(projectData.fields.Aliases, name)
 	projectData.Unlock()
+
+	if !ok {
+		return errors.New(ErrUnknownAlias.Error() + ": " + name)
+	}
+
+	projectData.update()
+
+	// remove from completer
+	completer.Lock()
+	for i, c := range completer.Children {
+		if strings.TrimSpace(string(c.GetName())) == name {
+			completer.Children = append(completer.Children[:i], completer.Children[i+1:]...)
+			break
+		}
+	}
+	completer.Unlock()
+
+	return nil
+}
+
+// create the completer for an alias
+// completes the named placeholders of the alias
+func newAliasCompleter(name string) *readline.PrefixCompleter {
+	return readline.PcItem(name,
+		readline.PcItemDynamic(func(path string) (res []string) {
+
+			projectData.Lock()
+			command := projectData.fields.Aliases[name]
+			projectData.Unlock()
+
+			for _, p := range parsePlaceholders(command) {
+				if p.name != "" && !strings.Contains(path, " "+p.name+"=") {
+					res = append(res, p.name+"=")
+				}
+			}
+			return
+		}),
+	)
+}
+
+// complete alias names
+func aliasCompleter(path string) (res []string) {
+	projectData.Lock()
+	defer projectData.Unlock()
+	for name := range projectData.fields.Aliases {
+		res = append(res, name)
+	}
+	return
 }
 
 // print alias names to stdout
@@ -113,16 +447,29 @@ func handleAliasCommand(args []string) {
 		return
 	}
 
+	var err error
 	switch args[1] {
 	case "set":
-		if len(args) < 3 {
+		if len(args) < 4 {
 			printAliasCommandErr()
 			return
 		}
-		addAlias(args[2], strings.Join(args[3:], " "))
+		err = addAlias(args[2], trimQuotes(strings.Join(args[3:], " ")))
 	case "remove":
-		deleteAlias(args[2])
+		err = deleteAlias(args[2])
 	default:
-		printAliasCommandErr()
+		// alias <name> <command>
+		err = addAlias(args[1], trimQuotes(strings.Join(args[2:], " ")))
+	}
+	if err != nil {
+		Log.WithError(err).Error("alias failed")
+	}
+}
+
+// remove quotes around an alias command
+func trimQuotes(command string) string {
+	if len(command) > 1 && (command[0] == '"' || command[0] == '\'') && command[len(command)-1] == command[0] {
+		return command[1 : len(command)-1]
 	}
+	return command
 }
@@ -0,0 +1,586 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRunFinished means the run can not be cancelled because it already finished
+	ErrRunFinished = errors.New("run already finished")
+
+	// runs started over the REST API
+	apiRuns = newRunStore()
+
+	// ANSI escape sequences, removed from error messages returned by the API
+	ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+)
+
+// number of runs kept in the run store, the oldest finished runs are removed first
+const maxAPIRuns = 100
+
+// apiCommand is the metadata of a command returned by the REST API
+type apiCommand struct {
+	Name         string      `json:"name"`
+	Description  string      `json:"description,omitempty"`
+	Help         string      `json:"help,omitempty"`
+	Language     string      `json:"language"`
+	Async        bool        `json:"async,omitempty"`
+	BuildNumber  bool        `json:"buildNumber,omitempty"`
+	Dependencies []string    `json:"dependencies,omitempty"`
+	Outputs      []string    `json:"outputs,omitempty"`
+	Arguments    *jsonSchema `json:"arguments"`
+	State        string      `json:"state,omitempty"`
+}
+
+// JSON schema types for the argument types
+var argumentSchemaTypes = map[reflect.Kind]string{
+	reflect.String:  "string",
+	reflect.Int:     "integer",
+	reflect.Bool:    "boolean",
+	reflect.Float64: "number",
+}
+
+// JSON schema for the arguments of a command
+func (c *command) argumentSchema() *jsonSchema {
+
+	s := &jsonSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema, 0),
+		AdditionalProperties: false,
+	}
+
+	for name, arg := range c.args {
+
+		p := &jsonSchema{
+			Type: argumentSchemaTypes[arg.argType],
+		}
+		if arg.optional {
+			if arg.defaultValue != "" {
+				p.Default = defaultArgumentValue(arg)
+			}
+		} else {
+			s.Required = append(s.Required, name)
+		}
+
+		s.Properties[name] = p
+	}
+	sort.Strings(s.Required)
+
+	return s
+}
+
+// typed default value of an argument
+// falls back to the string if it does not match the type
+func defaultArgumentValue(arg *commandArg) interface{} {
+
+	v := strings.TrimSpace(arg.defaultValue)
+
+	switch arg.argType {
+	case reflect.Int:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	case reflect.Float64:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+
+	return v
+}
+
+// metadata for all commands, sorted by name
+func apiCommands() []*apiCommand {
+
+	states := lastCommandStates()
+
+	cmdMap.Lock()
+	defer cmdMap.Unlock()
+
+	var list []*apiCommand
+	for _, c := range cmdMap.items {
+		list = append(list, &apiCommand{
+			Name:         c.name,
+			Description:  c.description,
+			Help:         c.help,
+			Language:     c.language,
+			Async:        c.async,
+			BuildNumber:  c.buildNumber,
+			Dependencies: c.dependencies,
+			Outputs:      c.outputs,
+			Arguments:    c.argumentSchema(),
+			State:        states[c.name],
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
+
+// runOutput collects the output of a run
+// it is written by the processes and read by the API concurrently
+type runOutput struct {
+	buf bytes.Buffer
+	sync.Mutex
+}
+
+func (o *runOutput) Write(p []byte) (int, error) {
+	o.Lock()
+	defer o.Unlock()
+	return o.buf.Write(p)
+}
+
+func (o *runOutput) String() string {
+	o.Lock()
+	defer o.Unlock()
+	return o.buf.String()
+}
+
+// apiRun is a command chain started over the REST API
+type apiRun struct {
+	id    string
+	chain string
+	state string
+	err   error
+	start time.Time
+	end   time.Time
+
+	// execution context, used for cancellation
+	ctx *execContext
+
+	output *runOutput
+
+	// closed when the run has finished
+	done chan struct{}
+
+	sync.Mutex
+}
+
+// apiRunStatus is the state of a run returned by the REST API
+type apiRunStatus struct {
+	ID       string     `json:"id"`
+	Chain    string     `json:"chain"`
+	State    string     `json:"state"`
+	Error    string     `json:"error,omitempty"`
+	Start    time.Time  `json:"start"`
+	End      *time.Time `json:"end,omitempty"`
+	Duration string     `json:"duration"`
+	Output   string     `json:"output,omitempty"`
+}
+
+// status of the run, the output is included on request
+func (r *apiRun) status(withOutput bool) *apiRunStatus {
+
+	r.Lock()
+	defer r.Unlock()
+
+	s := &apiRunStatus{
+		ID:    r.id,
+		Chain: r.chain,
+		State: r.state,
+		Start: r.start,
+	}
+
+	if r.err != nil {
+		s.Error = ansiEscape.ReplaceAllString(r.err.Error(), "")
+	}
+
+	if r.end.IsZero() {
+		s.Duration = time.Since(r.start).String()
+	} else {
+		end := r.end
+		s.End = &end
+		s.Duration = r.end.Sub(r.start).String()
+	}
+
+	if withOutput {
+		s.Output = r.output.String()
+	}
+
+	return s
+}
+
+// finish the run
+func (r *apiRun) finish(err error) {
+
+	r.Lock()
+	r.end = time.Now()
+	r.err = err
+	r.state = finalRunState(r.ctx, err)
+	r.Unlock()
+
+	close(r.done)
+}
+
+// cancel the run, all processes of the run are killed
+func (r *apiRun) cancel() error {
+
+	select {
+	case <-r.done:
+		return ErrRunFinished
+	default:
+	}
+
+	r.ctx.cancel()
+	return nil
+}
+
+// runStore contains the runs started over the REST API
+type runStore struct {
+	items map[string]*apiRun
+	sync.Mutex
+}
+
+func newRunStore() *runStore {
+	return &runStore{
+		items: make(map[string]*apiRun, 0),
+	}
+}
+
+// add a run and remove the oldest finished runs if the store is full
+func (s *runStore) add(r *apiRun) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.items[r.id] = r
+
+	if len(s.items) <= maxAPIRuns {
+		return
+	}
+
+	var finished []*apiRun
+	for _, run := range s.items {
+		select {
+		case <-run.done:
+			finished = append(finished, run)
+		default:
+		}
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].start.Before(finished[j].start)
+	})
+
+	for i := 0; i < len(finished) && len(s.items) > maxAPIRuns; i++ {
+		delete(s.items, finished[i].id)
+	}
+}
+
+// get a run by its ID
+func (s *runStore) get(id string) (*apiRun, bool) {
+	s.Lock()
+	defer s.Unlock()
+	r, ok := s.items[id]
+	return r, ok
+}
+
+// all runs, oldest first
+func (s *runStore) list() []*apiRun {
+
+	s.Lock()
+	var list []*apiRun
+	for _, r := range s.items {
+		list = append(list, r)
+	}
+	s.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].start.Before(list[j].start)
+	})
+
+	return list
+}
+
+// apiRunRequest is the body of a POST request to /api/run
+// chain is a command or command chain as typed in the shell, including arguments
+// args contains additional arguments, mapped by command name and argument label
+type apiRunRequest struct {
+	Chain string                            `json:"chain"`
+	Args  map[string]map[string]interface{} `json:"args"`
+}
+
+// build the fields of the command chain from a run request
+func (req *apiRunRequest) fields() ([]string, error) {
+
+	if strings.TrimSpace(req.Chain) == "" {
+		return nil, errors.New("missing chain")
+	}
+
+	var (
+		fields = strings.Split(req.Chain, commandChainSeparator)
+		used   = make(map[string]bool, 0)
+	)
+
+	for i, f := range fields {
+
+		name := strings.Fields(f)
+		if len(name) == 0 {
+			return nil, errors.New("invalid command chain: empty command")
+		}
+
+		c, err := cmdMap.getCommand(name[0])
+		if err != nil {
+			return nil, err
+		}
+
+		args, ok := req.Args[c.name]
+		if !ok {
+			continue
+		}
+		used[c.name] = true
+
+		var labels []string
+		for label := range args {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		for _, label := range labels {
+
+			var value string
+			switch v := args[label].(type) {
+			case string:
+				value = v
+			case float64:
+				value = strconv.FormatFloat(v, 'f', -1, 64)
+			case bool:
+				value = strconv.FormatBool(v)
+			default:
+				return nil, errors.New("invalid value for argument " + label + " of command " + c.name)
+			}
+
+			if value == "" || strings.ContainsAny(value, " \t\n=") {
+				return nil, errors.New("invalid value for argument " + label + " of command " + c.name + ": values must not be empty or contain whitespace or '='")
+			}
+
+			fields[i] += " " + label + "=" + value
+		}
+	}
+
+	for name := range req.Args {
+		if !used[name] {
+			return nil, errors.New("arguments for command " + name + " that is not part of the chain")
+		}
+	}
+
+	// check the arguments before anything is started
+	for _, f := range fields {
+		args := strings.Fields(f)
+		c, _ := cmdMap.getCommand(args[0])
+		_, err := c.parseArguments(args[1:])
+		if err != nil {
+			return nil, errors.New(c.name + ": " + err.Error())
+		}
+	}
+
+	return fields, nil
+}
+
+// start a command chain in the background and capture its output
+func startAPIRun(fields []string) (*apiRun, error) {
+
+	cmdChain, ok := validCommandChain(fields)
+	if !ok {
+		return nil, errors.New("invalid command chain")
+	}
+
+	var (
+		output = &runOutput{}
+		line   []string
+	)
+
+	for _, f := range fields {
+		line = append(line, strings.TrimSpace(f))
+	}
+
+	r := &apiRun{
+		id:     randomString(),
+		chain:  strings.Join(line, " "+commandChainSeparator+" "),
+		state:  stateRunning,
+		start:  time.Now(),
+		output: output,
+		done:   make(chan struct{}),
+	}
+
+	// the output is streamed to the web interface with the ID of the run
+	ctx, stream := newOutputContext(output).startStream(r.id, r.chain)
+	r.ctx = ctx
+
+	apiRuns.add(r)
+
+	go func() {
+		err := cmdChain.exec(ctx, fields)
+		stream.finish(finalRunState(ctx, err))
+		r.finish(err)
+	}()
+
+	return r, nil
+}
+
+// apiProcess is a spawned process returned by the REST API
+type apiProcess struct {
+	ID      string `json:"id"`
+	Command string `json:"command"`
+	PID     int    `json:"pid"`
+}
+
+// all spawned processes, sorted by PID
+func apiProcesses() []*apiProcess {
+
+	processMapMutex.Lock()
+	var list []*apiProcess
+	for id, p := range processMap {
+		list = append(list, &apiProcess{
+			ID:      string(id),
+			Command: p.Name,
+			PID:     p.PID,
+		})
+	}
+	processMapMutex.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].PID < list[j].PID
+	})
+
+	return list
+}
+
+/*
+ *	Handlers
+ */
+
+// write a value as JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(b, '\n'))
+}
+
+// write an error as JSON response
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{
+		"error": ansiEscape.ReplaceAllString(err.Error(), ""),
+	})
+}
+
+// list all commands with their argument schemas
+var apiCommandsHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, apiCommands())
+})
+
+// start a command chain, responds with the run status including its ID
+var apiRunHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+	var req apiRunRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, errors.New("invalid request body: "+err.Error()))
+		return
+	}
+
+	fields, err := req.fields()
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	run, err := startAPIRun(fields)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/runs/"+run.id)
+	writeJSON(w, http.StatusAccepted, run.status(false))
+})
+
+// list all runs started over the API, without their output
+var apiRunsHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+	list := make([]*apiRunStatus, 0)
+	for _, run := range apiRuns.list() {
+		list = append(list, run.status(false))
+	}
+
+	writeJSON(w, http.StatusOK, list)
+})
+
+// get the status and output of a run, or cancel it
+var apiRunStatusHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/runs/")
+
+	run, ok := apiRuns.get(id)
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, errors.New("unknown run: "+id))
+		return
+	}
+
+	if r.Method == "DELETE" {
+		err := run.cancel()
+		if err != nil {
+			writeJSONError(w, http.StatusConflict, err)
+			return
+		}
+
+		// wait for the processes to exit, so the response contains the final state
+		select {
+		case <-run.done:
+		case <-time.After(5 * time.Second):
+		}
+	}
+
+	writeJSON(w, http.StatusOK, run.status(true))
+})
+
+// list the spawned processes
+var apiProcsHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	list := apiProcesses()
+	if list == nil {
+		list = make([]*apiProcess, 0)
+	}
+	writeJSON(w, http.StatusOK, list)
+})
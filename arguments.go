@@ -62,6 +62,33 @@ type commandArg struct {
 // and return the validatedArgs as map
 func validateArgs(args []string) (map[string]*commandArg, error) {
 
+	validatedArgs, err := parseArgumentDeclarations(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// check for name conflicts with globals
+	var conflict string
+	g.Lock()
+	for argumentName := range validatedArgs {
+		if _, ok := g.Vars[argumentName]; ok {
+			conflict = argumentName
+		}
+	}
+	g.Unlock()
+
+	if conflict != "" {
+		listGlobals()
+		return nil, errors.New("argument name " + conflict + " conflicts with a global variable")
+	}
+
+	return validatedArgs, nil
+}
+
+// parse the argument declarations of a command
+// does not check for conflicts with globals
+func parseArgumentDeclarations(args []string) (map[string]*commandArg, error) {
+
 	// init map
 	validatedArgs := make(map[string]*commandArg, 0)
 
@@ -89,17 +116,6 @@ func validateArgs(args []string) (map[string]*commandArg, error) {
 			// argument name may contain leading whitespace - trim it
 			var argumentName = strings.TrimSpace(slice[0])
 
-			// check for name conflicts with globals
-			g.Lock()
-			for name := range g.Vars {
-				if argumentName == name {
-					g.Unlock()
-					listGlobals()
-					return nil, errors.New("argument name " + argumentName + " conflicts with a global variable")
-				}
-			}
-			g.Unlock()
-
 			// check for duplicate argument names
 			if a, ok := validatedArgs[argumentName]; ok {
 				Log.Error("argument label ", a.name, " was used twice")
@@ -44,16 +44,18 @@ func handleAuthorCommand(args []string) {
 			printAuthorUsageErr()
 			return
 		}
-		projectData.Lock()
-		projectData.fields.Author = strings.Join(args[2:], " ")
-		projectData.Unlock()
-		projectData.update()
+		setAuthor(strings.Join(args[2:], " "))
 	case "remove":
-		projectData.Lock()
-		projectData.fields.Author = ""
-		projectData.Unlock()
-		projectData.update()
+		setAuthor("")
 	default:
 		printAuthorUsageErr()
 	}
 }
+
+// set the project author, an empty name removes the author
+func setAuthor(name string) {
+	projectData.Lock()
+	projectData.fields.Author = strings.TrimSpace(name)
+	projectData.Unlock()
+	projectData.update()
+}
@@ -0,0 +1,520 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// ErrNoGoToolchain means the go command is required to build the binary
+var ErrNoGoToolchain = errors.New("the go toolchain is required to build a binary")
+
+// target for the generate builtin that produces a Go executable
+const generateBinary = "binary"
+
+// binarySpec describes the commands embedded into a generated binary
+// it is serialized as JSON into the source of the binary
+type binarySpec struct {
+	Name     string           `json:"name"`
+	Version  string           `json:"version"`
+	Chain    string           `json:"chain"`
+	Commands []*binaryCommand `json:"commands"`
+}
+
+type binaryCommand struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Help        string `json:"help"`
+
+	// interpreter and its flags, the path of the script is appended
+	Interpreter []string `json:"interpreter"`
+
+	FileExtension      string `json:"fileExtension"`
+	VariableKeyword    string `json:"variableKeyword"`
+	AssignmentOperator string `json:"assignmentOperator"`
+
+	// global variables and language specific global code
+	Globals string `json:"globals"`
+
+	Code         string              `json:"code"`
+	Flags        []*binaryFlag       `json:"flags"`
+	Dependencies []*binaryDependency `json:"dependencies"`
+}
+
+// a typed flag for a command argument
+type binaryFlag struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional"`
+	Default  string `json:"default"`
+}
+
+type binaryDependency struct {
+	Name string            `json:"name"`
+	Args map[string]string `json:"args"`
+}
+
+// generate a Go executable that embeds the commands of a command chain and their dependencies
+// every command is exposed as a subcommand with typed flags for its arguments
+// the source and the binary are written to zeus/generated/<name>
+func generateBinaryTarget(name string, chainArgs []string) error {
+
+	plan, err := newGeneratePlan(chainArgs)
+	if err != nil {
+		return err
+	}
+
+	spec := &binarySpec{
+		Name:    name,
+		Version: version,
+		Chain:   plan.chain,
+	}
+
+	for _, n := range plan.nodes {
+		c, err := n.binaryCommand()
+		if err != nil {
+			return err
+		}
+		spec.Commands = append(spec.Commands, c)
+	}
+
+	src, err := spec.source()
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(zeusDir, "generated", name)
+
+	err = os.MkdirAll(dir, 0744)
+	if err != nil {
+		return err
+	}
+
+	srcPath := filepath.Join(dir, "main.go")
+
+	err = ioutil.WriteFile(srcPath, src, 0644)
+	if err != nil {
+		return err
+	}
+
+	l.Println("generated " + srcPath)
+
+	if _, err := exec.LookPath("go"); err != nil {
+		return ErrNoGoToolchain
+	}
+
+	binPath := filepath.Join(dir, name)
+
+	// only the standard library is used, so the file can be built outside of a module
+	// without cgo the binary is linked statically
+	cmd := exec.Command("go", "build", "-o", name, "main.go")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.New("failed to build " + binPath + ": " + err.Error() + "\n" + string(out))
+	}
+
+	l.Println("generated " + binPath)
+	return nil
+}
+
+// assemble the embedded representation of a command
+func (n *generateNode) binaryCommand() (*binaryCommand, error) {
+
+	code, err := n.script()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &binaryCommand{
+		Name:               n.cmd.name,
+		Description:        n.cmd.description,
+		Help:               n.cmd.help,
+		Interpreter:        strings.Fields(n.lang.Interpreter + " " + n.lang.FlagStopOnError),
+		FileExtension:      n.lang.FileExtension,
+		VariableKeyword:    n.lang.VariableKeyword,
+		AssignmentOperator: n.lang.AssignmentOperator,
+		Globals:            generateGlobalCode(n.lang),
+		Code:               code,
+	}
+
+	// arguments supplied in the chain become the defaults of the flags
+	chainArgs, err := parseLabeledArgs(n.args)
+	if err != nil {
+		return nil, errors.New(n.cmd.name + ": " + err.Error())
+	}
+
+	for _, arg := range n.cmd.args {
+
+		f := &binaryFlag{
+			Name:     arg.name,
+			Type:     binaryFlagType(arg.argType),
+			Optional: arg.optional,
+			Default:  strings.TrimSpace(arg.defaultValue),
+		}
+
+		if v, ok := chainArgs[arg.name]; ok {
+			f.Default = v
+			f.Optional = true
+		}
+
+		c.Flags = append(c.Flags, f)
+	}
+
+	sort.Slice(c.Flags, func(i, j int) bool {
+		return c.Flags[i].Name < c.Flags[j].Name
+	})
+
+	for _, d := range n.cmd.dependencies {
+
+		fields := strings.Fields(d)
+		if len(fields) == 0 {
+			return nil, ErrEmptyDependency
+		}
+
+		args, err := parseLabeledArgs(fields[1:])
+		if err != nil {
+			return nil, errors.New(n.cmd.name + ": dependency " + fields[0] + ": " + err.Error())
+		}
+
+		c.Dependencies = append(c.Dependencies, &binaryDependency{
+			Name: fields[0],
+			Args: args,
+		})
+	}
+
+	return c, nil
+}
+
+// parse arguments in the label=value format
+func parseLabeledArgs(args []string) (map[string]string, error) {
+
+	m := make(map[string]string, len(args))
+	for _, a := range args {
+		parts := strings.SplitN(a, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.New("invalid argument: " + a)
+		}
+		m[parts[0]] = parts[1]
+	}
+
+	return m, nil
+}
+
+// Go flag type for an argument type
+func binaryFlagType(k reflect.Kind) string {
+	switch k {
+	case reflect.Int:
+		return "int"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Float64:
+		return "float"
+	default:
+		return "string"
+	}
+}
+
+// render the source code of the binary
+func (s *binarySpec) source() ([]byte, error) {
+
+	spec, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	err = binaryTemplate.Execute(&b, map[string]string{
+		"Version": version,
+		"Chain":   s.Chain,
+		"Spec":    strconv.Quote(string(spec)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// source of the generated binary, it only depends on the standard library
+var binaryTemplate = template.Must(template.New("binary").Parse(`// generated by ZEUS v{{.Version}}
+// commandChain: {{.Chain}}
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const spec = {{.Spec}}
+
+type tool struct {
+	Name     string     ` + "`json:\"name\"`" + `
+	Version  string     ` + "`json:\"version\"`" + `
+	Chain    string     ` + "`json:\"chain\"`" + `
+	Commands []*command ` + "`json:\"commands\"`" + `
+}
+
+type command struct {
+	Name               string        ` + "`json:\"name\"`" + `
+	Description        string        ` + "`json:\"description\"`" + `
+	Help               string        ` + "`json:\"help\"`" + `
+	Interpreter        []string      ` + "`json:\"interpreter\"`" + `
+	FileExtension      string        ` + "`json:\"fileExtension\"`" + `
+	VariableKeyword    string        ` + "`json:\"variableKeyword\"`" + `
+	AssignmentOperator string        ` + "`json:\"assignmentOperator\"`" + `
+	Globals            string        ` + "`json:\"globals\"`" + `
+	Code               string        ` + "`json:\"code\"`" + `
+	Flags              []*cmdFlag    ` + "`json:\"flags\"`" + `
+	Dependencies       []*dependency ` + "`json:\"dependencies\"`" + `
+}
+
+type cmdFlag struct {
+	Name     string ` + "`json:\"name\"`" + `
+	Type     string ` + "`json:\"type\"`" + `
+	Optional bool   ` + "`json:\"optional\"`" + `
+	Default  string ` + "`json:\"default\"`" + `
+}
+
+type dependency struct {
+	Name string            ` + "`json:\"name\"`" + `
+	Args map[string]string ` + "`json:\"args\"`" + `
+}
+
+func main() {
+
+	var t tool
+	if err := json.Unmarshal([]byte(spec), &t); err != nil {
+		fmt.Fprintln(os.Stderr, "invalid spec:", err)
+		os.Exit(1)
+	}
+
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		t.usage()
+		if len(os.Args) < 2 {
+			os.Exit(2)
+		}
+		return
+	}
+
+	c := t.command(os.Args[1])
+	if c == nil {
+		fmt.Fprintln(os.Stderr, "unknown command:", os.Args[1])
+		t.usage()
+		os.Exit(2)
+	}
+
+	values, err := c.parseFlags(os.Args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if err := t.run(c, values, make(map[string]bool)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			if code := exitErr.ExitCode(); code > 0 {
+				os.Exit(code)
+			}
+		}
+		os.Exit(1)
+	}
+}
+
+func (t *tool) usage() {
+	fmt.Fprintf(os.Stderr, "%s - generated by ZEUS v%s\n\n", t.Name, t.Version)
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags]\n\ncommands:\n", t.Name)
+	for _, c := range t.Commands {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", c.Name, c.Description)
+	}
+	fmt.Fprintf(os.Stderr, "\nrun '%s <command> --help' for the flags of a command\n", t.Name)
+}
+
+func (t *tool) command(name string) *command {
+	for _, c := range t.Commands {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// parse the flags of a command and return the values for all arguments
+func (c *command) parseFlags(args []string) (map[string]string, error) {
+
+	fs := flag.NewFlagSet(c.Name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags]\n\n", c.Name)
+		if c.Description != "" {
+			fmt.Fprintln(os.Stderr, c.Description)
+		}
+		if c.Help != "" {
+			fmt.Fprintln(os.Stderr, "\n"+strings.TrimSpace(c.Help))
+		}
+		if len(c.Flags) > 0 {
+			fmt.Fprintln(os.Stderr, "\nflags:")
+			fs.PrintDefaults()
+		}
+	}
+
+	for _, f := range c.Flags {
+		usage := "optional"
+		if !f.Optional {
+			usage = "required"
+		}
+		switch f.Type {
+		case "int":
+			v, _ := strconv.Atoi(f.Default)
+			fs.Int(f.Name, v, usage)
+		case "bool":
+			v, _ := strconv.ParseBool(f.Default)
+			fs.Bool(f.Name, v, usage)
+		case "float":
+			v, _ := strconv.ParseFloat(f.Default, 64)
+			fs.Float64(f.Name, v, usage)
+		default:
+			fs.String(f.Name, f.Default, usage)
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("%s: unexpected arguments: %s", c.Name, strings.Join(fs.Args(), " "))
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	values := make(map[string]string)
+	for _, f := range c.Flags {
+		if !f.Optional && !set[f.Name] {
+			return nil, fmt.Errorf("%s: missing flag: --%s (%s)", c.Name, f.Name, f.Type)
+		}
+		values[f.Name] = fs.Lookup(f.Name).Value.String()
+	}
+
+	return values, nil
+}
+
+// values for a dependency: the supplied arguments and the defaults of the flags
+func (c *command) dependencyValues(args map[string]string) (map[string]string, error) {
+
+	values := make(map[string]string)
+	for _, f := range c.Flags {
+		if v, ok := args[f.Name]; ok {
+			values[f.Name] = v
+			continue
+		}
+		if !f.Optional {
+			return nil, fmt.Errorf("%s: missing argument: %s", c.Name, f.Name)
+		}
+		values[f.Name] = f.Default
+		if values[f.Name] == "" && f.Type != "string" {
+			values[f.Name] = map[string]string{"int": "0", "bool": "false", "float": "0.0"}[f.Type]
+		}
+	}
+
+	return values, nil
+}
+
+// run the dependencies of a command and the command itself
+func (t *tool) run(c *command, values map[string]string, done map[string]bool) error {
+
+	for _, d := range c.Dependencies {
+
+		if done[d.Name] {
+			continue
+		}
+
+		dep := t.command(d.Name)
+		if dep == nil {
+			return fmt.Errorf("%s: unknown dependency: %s", c.Name, d.Name)
+		}
+
+		depValues, err := dep.dependencyValues(d.Args)
+		if err != nil {
+			return err
+		}
+
+		if err := t.run(dep, depValues, done); err != nil {
+			return err
+		}
+	}
+
+	done[c.Name] = true
+	return c.exec(values)
+}
+
+// write the script into a temporary file and pass it to the interpreter
+func (c *command) exec(values map[string]string) error {
+
+	var script strings.Builder
+	for _, f := range c.Flags {
+		v := values[f.Name]
+		if f.Type == "string" {
+			v = strconv.Quote(v)
+		}
+		script.WriteString(c.VariableKeyword + f.Name + c.AssignmentOperator + v + "\n")
+	}
+	script.WriteString(c.Globals + "\n" + c.Code + "\n")
+
+	f, err := ioutil.TempFile("", c.Name+"-*"+c.FileExtension)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(script.String()); err != nil {
+		f.Close()
+		return err
+	}
+	f.Close()
+
+	cmd := exec.Command(c.Interpreter[0], append(c.Interpreter[1:], f.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+`))
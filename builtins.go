@@ -48,6 +48,7 @@ const (
 	eventsCommand     = "events"
 	dataCommand       = "data"
 	makefileCommand   = "makefile"
+	importCommand     = "import"
 	authorCommand     = "author"
 	wikiCommand       = "wiki"
 	webCommand        = "web"
@@ -59,6 +60,18 @@ const (
 	procsCommand      = "procs"
 	editCommand       = "edit"
 	generateCommand   = "generate"
+	upCommand         = "up"
+	jobsCommand       = "jobs"
+	fgCommand         = "fg"
+	bgCommand         = "bg"
+	waitCommand       = "wait"
+	validateCommand   = "validate"
+	schemaCommand     = "schema"
+	graphCommand      = "graph"
+	historyCommand    = "history"
+	reportCommand     = "report"
+	daemonCommand     = "daemon"
+	dashboardCommand  = "dashboard"
 )
 
 // mapped builtin names to description
@@ -67,7 +80,7 @@ var builtins = map[string]string{
 	helpCommand:       "print the command overview or the manualtext for a specific command",
 	clearCommand:      "clear the terminal screen",
 	infoCommand:       "print project info (lines of code + latest git commits)",
-	formatCommand:     "run the formatter for all scripts",
+	formatCommand:     "run the formatter for all scripts, --check prints a diff",
 	globalsCommand:    "print the current globals",
 	configCommand:     "print or change the current config",
 	deadlineCommand:   "print or change the deadline",
@@ -78,6 +91,7 @@ var builtins = map[string]string{
 	aliasCommand:      "print, add or remove aliases",
 	colorsCommand:     "change the current ANSI color profile",
 	makefileCommand:   "show or migrate GNU Makefiles",
+	importCommand:     "show or import npm scripts, justfiles and Taskfiles",
 	authorCommand:     "print or change project author name",
 	keysCommand:       "manage keybindings",
 	builtinsCommand:   "print the builtins overview",
@@ -90,6 +104,18 @@ var builtins = map[string]string{
 	procsCommand:      "manage spawned processes",
 	editCommand:       "edit scripts",
 	generateCommand:   "generate a standalone version of the script",
+	upCommand:         "start a service group and interleave the output",
+	jobsCommand:       "list background jobs",
+	fgCommand:         "wait for a background job in the foreground",
+	bgCommand:         "continue a stopped job in the background",
+	waitCommand:       "wait for background jobs to finish",
+	validateCommand:   "check the zeus directory for problems without running anything",
+	schemaCommand:     "print or write the JSON schemas for the ZEUS files",
+	graphCommand:      "export the dependency graph as DOT, Mermaid or JSON",
+	historyCommand:    "list, filter and analyze previous runs",
+	reportCommand:     "generate a markdown or HTML project report",
+	daemonCommand:     "start, stop or query the daemon for the project",
+	dashboardCommand:  "show runs, processes, events and output in a terminal UI",
 }
 
 // executed when running the info command
@@ -346,20 +372,12 @@ func printTodoCount() {
 		return
 	}
 
-	contents, err := ioutil.ReadFile(conf.fields.TodoFilePath)
+	count, err := countTodos(conf.fields.TodoFilePath)
 	if err != nil {
 		l.Println(err)
 		return
 	}
 
-	var count int
-
-	for _, line := range strings.Split(string(contents), "\n") {
-		if strings.HasPrefix(line, "- ") {
-			count++
-		}
-	}
-
 	l.Println(cp.Text + pad("TODOs", 14) + cp.Prompt + strconv.Itoa(count))
 }
 
@@ -585,5 +603,5 @@ func handleEditCommand(args []string) {
 
 func printGenerateCommandUsageErr() {
 	l.Println(ErrInvalidUsage)
-	l.Println("usage: generate <outputName> <commandChain>")
+	l.Println("usage: generate [makefile | workflow | dockerfile | binary] <outputName> <commandChain>")
 }
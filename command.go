@@ -30,6 +30,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -98,13 +99,14 @@ type command struct {
 	exec string
 }
 
-// Run executes the command
-func (c *command) Run(args []string, async bool) error {
+// Run executes the command with the arguments from the supplied execution context
+// if async is set, the command will be detached in a screen session
+func (c *command) Run(ctx *execContext, async bool) error {
 
 	// spawn async commands in a new goroutine
 	if async {
 		go func() {
-			err := c.Run(args, false)
+			err := c.run(ctx, true)
 			if err != nil {
 				Log.WithError(err).Error("failed to run command: " + c.name)
 			}
@@ -114,14 +116,31 @@ func (c *command) Run(args []string, async bool) error {
 		return nil
 	}
 
+	return c.run(ctx, false)
+}
+
+// run the command, detach determines if its spawned in a screen session
+func (c *command) run(ctx *execContext, detach bool) (err error) {
+
 	var (
 		cLog         = Log.WithField("prefix", c.name)
 		start        = time.Now()
 		stdErrBuffer = &bytes.Buffer{}
+		st           = ctx.status
 	)
 
+	if ctx.cancelled() {
+		return ErrCancelled
+	}
+
+	ctx, inv := ctx.startCommand(c.name, ctx.dependency, detach)
+	defer func() {
+		inv.stderr = stdErrBuffer.String()
+		inv.finish(ctx, err)
+	}()
+
 	// handle dependencies
-	err := c.execDependencies()
+	err = c.execDependencies(ctx)
 	if err != nil {
 		return errors.New("dependency error: " + err.Error())
 	}
@@ -142,10 +161,11 @@ func (c *command) Run(args []string, async bool) error {
 
 			if !outputMissing {
 				// all output files / dirs exist, skip command
-				s.Lock()
-				s.currentCommand++
-				l.Println(printPrompt() + "[" + strconv.Itoa(s.currentCommand) + "/" + strconv.Itoa(s.numCommands) + "] skipping " + cp.Prompt + c.name + cp.Reset + " because all named outputs exist")
-				s.Unlock()
+				st.Lock()
+				st.currentCommand++
+				ctx.out.Println(printPrompt() + st.progress() + " skipping " + cp.Prompt + c.name + cp.Reset + " because all named outputs exist")
+				st.Unlock()
+				inv.skip("all named outputs exist")
 				return nil
 			}
 		}
@@ -153,34 +173,42 @@ func (c *command) Run(args []string, async bool) error {
 
 	cLog.WithFields(logrus.Fields{
 		"prefix": "exec",
-		"args":   args,
+		"args":   ctx.args,
 	}).Debug(cp.CmdName + c.name + cp.Reset)
 
-	s.Lock()
-	s.currentCommand++
-	s.Unlock()
+	st.Lock()
+	st.currentCommand++
+	st.Unlock()
+
+	inv.running()
 
 	// handle args
-	argBuffer, err := c.parseArguments(args)
+	argBuffer, err := c.parseArguments(ctx.args)
 	if err != nil {
 		return err
 	}
 
 	// init command
-	cmd, script, cleanupFunc, err := c.createCommand(argBuffer)
+	cmd, script, cleanupFunc, err := c.createCommand(argBuffer, detach)
 	if err != nil {
 		return err
 	}
 
-	// set host shell environment
-	cmd.Env = os.Environ()
+	// set environment of the invocation
+	cmd.Env = ctx.env
 
 	// don't wire terminalIO for async jobs
 	// they can be attached by using the procs builtin
-	if !c.async {
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = io.MultiWriter(os.Stderr, stdErrBuffer)
-		cmd.Stdin = os.Stdin
+	if !detach {
+		cmd.Stdout = ctx.stdout
+		cmd.Stderr = io.MultiWriter(ctx.stderr, stdErrBuffer)
+
+		// background invocations run in their own process group
+		// their stdin is not attached to the terminal
+		if ctx.background {
+			cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		}
+		cmd.Stdin = ctx.stdin
 	}
 
 	// incease build number if set
@@ -191,18 +219,22 @@ func (c *command) Run(args []string, async bool) error {
 		projectData.update()
 	}
 
-	s.Lock()
-	if c.async {
-		l.Println(printPrompt() + "[" + strconv.Itoa(s.currentCommand) + "/" + strconv.Itoa(s.numCommands) + "] detaching " + cp.Prompt + c.name + cp.Reset)
+	st.Lock()
+	if detach {
+		ctx.out.Println(printPrompt() + st.progress() + " detaching " + cp.Prompt + c.name + cp.Reset)
 	} else {
-		l.Println(printPrompt() + "[" + strconv.Itoa(s.currentCommand) + "/" + strconv.Itoa(s.numCommands) + "] executing " + cp.Prompt + c.name + cp.Reset)
+		ctx.out.Println(printPrompt() + st.progress() + " executing " + cp.Prompt + c.name + cp.Reset)
 	}
-	s.Unlock()
+	st.Unlock()
 
 	// lets go
 	err = cmd.Start()
 	if err != nil {
-		cLog.WithError(err).Fatal("failed to start command: " + c.name)
+		cLog.WithError(err).Error("failed to start command: " + c.name)
+		if cleanupFunc != nil {
+			cleanupFunc()
+		}
+		return err
 	}
 
 	// add to processMap
@@ -212,20 +244,42 @@ func (c *command) Run(args []string, async bool) error {
 	)
 	cLog.Debug("PID: ", pid)
 	addProcess(id, c.name, cmd.Process, pid)
+	st.addPID(pid)
 
 	// after command has finished running, remove from processMap
 	defer deleteProcessByPID(pid)
+	defer st.removePID(pid)
+
+	// kill the process when the invocation is cancelled
+	if !detach {
+		finished := make(chan struct{})
+		defer close(finished)
+		go func() {
+			select {
+			case <-ctx.done():
+				if ctx.background {
+					syscall.Kill(-pid, syscall.SIGKILL)
+				} else {
+					cmd.Process.Kill()
+				}
+			case <-finished:
+			}
+		}()
+	}
 
 	// wait for process
-	return c.waitForProcess(cmd, cleanupFunc, script, id, pid, start, stdErrBuffer)
+	return c.waitForProcess(ctx, cmd, cleanupFunc, script, id, pid, start, stdErrBuffer, detach)
 }
 
-func (c *command) waitForProcess(cmd *exec.Cmd, cleanupFunc func(), script string, id processID, pid int, start time.Time, stdErrBuffer *bytes.Buffer) error {
+func (c *command) waitForProcess(ctx *execContext, cmd *exec.Cmd, cleanupFunc func(), script string, id processID, pid int, start time.Time, stdErrBuffer *bytes.Buffer, detach bool) error {
 
 	cLog := Log.WithField("prefix", "waitForProcess")
 
 	// wait for command to finish execution
 	err := cmd.Wait()
+	if cmd.ProcessState != nil {
+		ctx.span.set("exitCode", cmd.ProcessState.ExitCode())
+	}
 	if err != nil {
 
 		// execute cleanupFunc if there is one
@@ -233,6 +287,11 @@ func (c *command) waitForProcess(cmd *exec.Cmd, cleanupFunc func(), script strin
 			cleanupFunc()
 		}
 
+		// the process has been killed, no need to dump the script
+		if ctx.cancelled() {
+			return ErrCancelled
+		}
+
 		// when there are no globals
 		// read the command script directly
 		// and print it with line numbers to stdout for easy debugging
@@ -272,7 +331,7 @@ func (c *command) waitForProcess(cmd *exec.Cmd, cleanupFunc func(), script strin
 		return err
 	}
 
-	if c.async {
+	if detach {
 
 		// add to process map PID +1
 		cLog.Debug("detached PID: ", pid+1)
@@ -300,14 +359,14 @@ func (c *command) waitForProcess(cmd *exec.Cmd, cleanupFunc func(), script strin
 			}
 		}()
 	} else {
-		s.Lock()
+		ctx.status.Lock()
 		// print stats
-		l.Println(
-			printPrompt()+"["+strconv.Itoa(s.currentCommand)+"/"+strconv.Itoa(s.numCommands)+"] finished "+cp.Prompt+c.name+cp.Text+" in"+cp.Prompt,
+		ctx.out.Println(
+			printPrompt()+ctx.status.progress()+" finished "+cp.Prompt+c.name+cp.Text+" in"+cp.Prompt,
 			time.Now().Sub(start),
 			cp.Reset,
 		)
-		s.Unlock()
+		ctx.status.Unlock()
 
 		// execute cleanupFunc if there is one
 		if cleanupFunc != nil {
@@ -320,7 +379,7 @@ func (c *command) waitForProcess(cmd *exec.Cmd, cleanupFunc func(), script strin
 
 // execute dependencies for the current command
 // if their named outputs do not exist
-func (c *command) execDependencies() error {
+func (c *command) execDependencies(ctx *execContext) error {
 
 	if len(c.dependencies) > 0 {
 
@@ -354,17 +413,24 @@ func (c *command) execDependencies() error {
 				// next iteration
 				if !outputMissing {
 
-					s.Lock()
-					s.currentCommand++
-					l.Println(printPrompt() + "[" + strconv.Itoa(s.currentCommand) + "/" + strconv.Itoa(s.numCommands) + "] skipping " + cp.Prompt + dep.name + cp.Reset)
-					s.Unlock()
+					ctx.status.Lock()
+					ctx.status.currentCommand++
+					ctx.out.Println(printPrompt() + ctx.status.progress() + " skipping " + cp.Prompt + dep.name + cp.Reset)
+					ctx.status.Unlock()
+
+					depCtx, inv := ctx.withArgs(fields[1:]).startCommand(dep.name, true, false)
+					inv.skip("all named outputs exist")
+					inv.finish(depCtx, nil)
 
 					continue
 				}
 			}
 
 			// execute dependency and pass args
-			err = dep.Run(fields[1:], dep.async)
+			depCtx := ctx.withArgs(fields[1:])
+			depCtx.dependency = true
+
+			err = dep.Run(depCtx, dep.async)
 			if err != nil {
 				Log.WithError(err).Error("failed to execute " + dep.name)
 				return err
@@ -390,7 +456,8 @@ func (c *command) getLanguage() (*Language, error) {
 
 // create an exec.Cmd instance ready for execution
 // for the given argument buffer
-func (c *command) createCommand(argBuffer string) (cmd *exec.Cmd, script string, cleanupFunc func(), err error) {
+// if detach is set, the command will be spawned in a screen session
+func (c *command) createCommand(argBuffer string, detach bool) (cmd *exec.Cmd, script string, cleanupFunc func(), err error) {
 
 	var (
 		shellCommand []string
@@ -398,7 +465,7 @@ func (c *command) createCommand(argBuffer string) (cmd *exec.Cmd, script string,
 		globalFuncs  string
 	)
 
-	if c.async {
+	if detach {
 		shellCommand = append(shellCommand, []string{"screen", "-L", "-S", c.name, "-dm"}...)
 	}
 
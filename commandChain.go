@@ -20,19 +20,64 @@ package main
 
 import (
 	"errors"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/mgutz/ansi"
 )
 
+// status keeps track of the progress of a command execution
+// every execContext has its own status instance
 type status struct {
 	recursionMap   map[string]int
 	numCommands    int
 	currentCommand int
+
+	// PIDs of the currently running processes
+	pids []int
+
 	sync.RWMutex
 }
 
+func newStatus() *status {
+	return &status{
+		recursionMap: make(map[string]int, 0),
+	}
+}
+
+// progress string for the current command, for example: [2/5]
+// status must be locked by the caller
+func (s *status) progress() string {
+	return "[" + strconv.Itoa(s.currentCommand) + "/" + strconv.Itoa(s.numCommands) + "]"
+}
+
+// add a PID to the running processes
+func (s *status) addPID(pid int) {
+	s.Lock()
+	s.pids = append(s.pids, pid)
+	s.Unlock()
+}
+
+// remove a PID from the running processes
+func (s *status) removePID(pid int) {
+	s.Lock()
+	defer s.Unlock()
+	for i, p := range s.pids {
+		if p == pid {
+			s.pids = append(s.pids[:i], s.pids[i+1:]...)
+			return
+		}
+	}
+}
+
+// get a copy of the currently running PIDs
+func (s *status) runningPIDs() []int {
+	s.Lock()
+	defer s.Unlock()
+	return append([]int{}, s.pids...)
+}
+
 func (s *status) reset() {
 	// reset counters
 	s.Lock()
@@ -83,30 +128,46 @@ func (cmdChain commandChain) String() (out string) {
 }
 
 // parse and execute a given commandChain string
-func (cmdChain commandChain) exec(cmds []string) {
+func (cmdChain commandChain) exec(ctx *execContext, cmds []string) (err error) {
 
-	defer s.reset()
+	defer ctx.status.reset()
+
+	var line []string
+	for _, c := range cmds {
+		line = append(line, strings.TrimSpace(c))
+	}
+	chain := strings.Join(line, " "+commandChainSeparator+" ")
+
+	ctx, inv := ctx.startInvocation(invocationChain, cmdChain.String(), chain, false)
+	defer func() {
+		inv.finish(ctx, err)
+	}()
 
 	// set numCommands counter
 	for _, c := range cmdChain {
-		count, err := getTotalDependencyCount(c)
+		count, err := getTotalDependencyCount(ctx.status, c)
 		if err != nil {
 			Log.WithError(err).Error("failed to get dependency count")
-			return
+			return err
 		}
-		s.Lock()
-		s.numCommands += count
-		s.Unlock()
+		ctx.status.Lock()
+		ctx.status.numCommands += count
+		ctx.status.Unlock()
 	}
 
 	// exec and pass args
 	for i, c := range cmdChain {
-		err := c.Run(strings.Fields(cmds[i])[1:], c.async)
+		if ctx.cancelled() {
+			return ErrCancelled
+		}
+		err := c.Run(ctx.withArgs(strings.Fields(cmds[i])[1:]), c.async)
 		if err != nil {
 			Log.WithError(err).Error("failed to execute " + c.name)
-			return
+			return err
 		}
 	}
+
+	return nil
 }
 
 // check if its a valid command chain
@@ -22,8 +22,6 @@ import (
 	"errors"
 	"io/ioutil"
 	"os"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -50,6 +48,9 @@ type CommandsFile struct {
 
 	// command data
 	Commands map[string]*commandData `yaml:"commands"`
+
+	// service groups: long running commands that are started together with the up builtin
+	Services map[string][]string `yaml:"services"`
 }
 
 func newCommandsFile() *CommandsFile {
@@ -57,6 +58,7 @@ func newCommandsFile() *CommandsFile {
 		Language: "bash",
 		Globals:  make(map[string]string, 0),
 		Commands: make(map[string]*commandData, 0),
+		Services: make(map[string][]string, 0),
 	}
 }
 
@@ -122,6 +124,13 @@ func parseCommandsFile(path string) error {
 		}
 	}
 
+	// initialize service groups
+	setServiceGroups(commandsFile.Services)
+	err = validateServiceGroups()
+	if err != nil {
+		return err
+	}
+
 	cmdMap.Lock()
 	defer cmdMap.Unlock()
 
@@ -136,115 +145,10 @@ func parseCommandsFile(path string) error {
 // look for invalid fields in commandsFile
 func validateCommandsFile(c []byte) error {
 
-	var (
-		fields = []string{
-			"description",
-			"help",
-			"language",
-			"arguments",
-			"dependencies",
-			"outputs",
-			"buildNumber",
-			"async",
-			"exec",
-			"globals",
-			"path",
-			"commands",
-		}
-		parsedFields                 []string
-		foundField                   bool
-		globalsStarted               bool
-		commandsStarted              bool
-		globalNames                  []string
-		commandNames                 []string
-		offsetCommandNamesAndGlobals int
-	)
-
-	// iterate over contents line by line
-	for i, line := range strings.Split(string(c), "\n") {
-
-		// determine current section
-		if strings.Contains(line, "globals:") {
-			globalsStarted = true
-			continue
-		} else if strings.Contains(line, "commands:") {
-			commandsStarted = true
-			globalsStarted = false
-			continue
-		}
-
-		if offsetCommandNamesAndGlobals == 0 {
-			if globalsStarted || commandsStarted {
-				offsetCommandNamesAndGlobals = countLeadingSpace(line)
-			}
-		}
-
-		// validate names of commands and custom global variable names
-		leadingSpace := countLeadingSpace(line)
-		if leadingSpace == offsetCommandNamesAndGlobals {
-
-			// catch duplicate global variable names
-			if globalsStarted {
-				field := extractYAMLField(line)
-				if field != "" {
-					for _, name := range globalNames {
-						if field == name {
-							printCodeSnippet(string(c), commandsFilePath, i)
-							return errors.New("line " + strconv.Itoa(i) + ": duplicate global name detected: " + name)
-						}
-					}
-					globalNames = append(globalNames, field)
-				}
-			}
-
-			// catch duplicate command names
-			if commandsStarted {
-				field := extractYAMLField(line)
-				if field != "" {
-
-					for _, name := range commandNames {
-						if field == name {
-							printCodeSnippet(string(c), commandsFilePath, i)
-							return errors.New("line " + strconv.Itoa(i) + ": duplicate command name detected: " + name)
-						}
-					}
-					commandNames = append(commandNames, field)
-					// next command started - reset parsedFields
-					parsedFields = []string{}
-				}
-			}
-			continue
-
-		} else if leadingSpace > offsetCommandNamesAndGlobals*2 {
-			// ignore everything that contains a colon inside the 'exec' field
-			continue
-		}
-
-		// check for unknown and duplicate fields + invalid combinations
-		field := extractYAMLField(line)
-		if field != "" {
-
-			// check for unknown fields
-			for _, item := range fields {
-				if field == strings.TrimSpace(string(item)) {
-					foundField = true
-				}
-			}
-			if !foundField {
-				printCodeSnippet(string(c), commandsFilePath, i)
-				return errors.New("line " + strconv.Itoa(i) + ": unknown field: " + field)
-			}
-			foundField = false
-
-			// check duplicate fields
-			for _, f := range parsedFields {
-				if f == field {
-					printCodeSnippet(string(c), commandsFilePath, i)
-					return errors.New("line " + strconv.Itoa(i) + ": duplicate field: " + field)
-				}
-			}
-			parsedFields = append(parsedFields, field)
-		}
+	errs := schemas["commands"].check(string(c))
+	if len(errs) > 0 {
+		printCodeSnippet(string(c), commandsFilePath, errs[0].line-1)
+		return errs[0]
 	}
 
 	return nil
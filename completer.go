@@ -59,35 +59,21 @@ func newAtomicCompleter() *atomicCompleter {
 }
 
 // assemble and return all items for config item completion
-// also used for validating the config YAML for unknown fields
-// if there's a key in the config that is not in here there will be a warning
-func configItems() []readline.PrefixCompleterInterface {
-	return []readline.PrefixCompleterInterface{
-		readline.PcItem("makefileOverview", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("autoFormat", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("fixParseErrors", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("colors", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("passCommandsToShell", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("eebInterface", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("interactive", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("debug", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("recursionDepth"),
-		readline.PcItem("projectNamePrompt", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("colorProfile"),
-		readline.PcItem("historyFile", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("historyLimit"),
-		readline.PcItem("exitOnInterrupt", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("disableTimestamps", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("printBuiltins", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("dumpScriptOnError", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("stopOnError", readline.PcItem("true"), readline.PcItem("false")),
-		readline.PcItem("portWebPanel"),
-		readline.PcItem("portGlueServer"),
-		readline.PcItem("dateFormat"),
-		readline.PcItem("todoFilePath"),
-		readline.PcItem("editor"),
-		readline.PcItem("codeSnippetScope"),
+// the items are generated from the config schema
+// fields that can not be set from the shell are skipped
+func configItems() (items []readline.PrefixCompleterInterface) {
+
+	s := schemas["config"]
+	for _, name := range s.properties() {
+		switch s.Properties[name].Type {
+		case "boolean":
+			items = append(items, readline.PcItem(name, readline.PcItem("true"), readline.PcItem("false")))
+		case "integer", "string":
+			items = append(items, readline.PcItem(name))
+		}
 	}
+
+	return
 }
 
 // assemble and return all items for keycomb item completion
@@ -128,7 +114,9 @@ func newCompleter() *readline.PrefixCompleter {
 		),
 		readline.PcItem(infoCommand),
 		readline.PcItem(clearCommand),
-		readline.PcItem(formatCommand),
+		readline.PcItem(formatCommand,
+			readline.PcItem("--check"),
+		),
 		readline.PcItem(globalsCommand),
 		readline.PcItem(versionCommand),
 		readline.PcItem(configCommand,
@@ -178,10 +166,17 @@ func newCompleter() *readline.PrefixCompleter {
 		readline.PcItem(makefileCommand,
 			readline.PcItem("migrate"),
 		),
+		readline.PcItem(importCommand,
+			readline.PcItem(importNPM),
+			readline.PcItem(importJust),
+			readline.PcItem(importTask),
+		),
 		readline.PcItem(dataCommand),
 		readline.PcItem(aliasCommand,
 			readline.PcItem("set"),
-			readline.PcItem("remove"),
+			readline.PcItem("remove",
+				readline.PcItemDynamic(aliasCompleter),
+			),
 		),
 		readline.PcItem(todoCommand,
 			readline.PcItem("add"),
@@ -190,6 +185,10 @@ func newCompleter() *readline.PrefixCompleter {
 			),
 		),
 		readline.PcItem(generateCommand,
+			readline.PcItem(generateMakefile),
+			readline.PcItem(generateWorkflow),
+			readline.PcItem(generateDockerfile),
+			readline.PcItem(generateBinary),
 			readline.PcItemDynamic(commandCompleter),
 		),
 		readline.PcItem(colorsCommand,
@@ -242,6 +241,62 @@ func newCompleter() *readline.PrefixCompleter {
 			),
 		),
 		readline.PcItem(wikiCommand),
+		readline.PcItem(upCommand,
+			readline.PcItemDynamic(serviceGroupCompleter),
+		),
+		readline.PcItem(jobsCommand),
+		readline.PcItem(fgCommand,
+			readline.PcItemDynamic(jobIDCompleter),
+		),
+		readline.PcItem(bgCommand,
+			readline.PcItemDynamic(jobIDCompleter),
+		),
+		readline.PcItem(waitCommand,
+			readline.PcItemDynamic(jobIDCompleter),
+		),
+		readline.PcItem(validateCommand),
+		readline.PcItem(schemaCommand,
+			readline.PcItem("commands"),
+			readline.PcItem("config"),
+			readline.PcItem("data"),
+			readline.PcItem("write"),
+		),
+		readline.PcItem(graphCommand,
+			readline.PcItem(graphDOT,
+				readline.PcItemDynamic(commandCompleter),
+			),
+			readline.PcItem(graphMermaid,
+				readline.PcItemDynamic(commandCompleter),
+			),
+			readline.PcItem(graphJSON,
+				readline.PcItemDynamic(commandCompleter),
+			),
+			readline.PcItemDynamic(commandCompleter),
+		),
+		readline.PcItem(historyCommand,
+			readline.PcItem("show"),
+			readline.PcItem("stats",
+				readline.PcItemDynamic(commandCompleter),
+			),
+			readline.PcItem("prune"),
+			readline.PcItem("clear"),
+			readline.PcItemDynamic(commandCompleter),
+		),
+		readline.PcItem(reportCommand,
+			readline.PcItem(reportMarkdown,
+				readline.PcItemDynamic(fileCompleter),
+			),
+			readline.PcItem(reportHTML,
+				readline.PcItemDynamic(fileCompleter),
+			),
+			readline.PcItemDynamic(fileCompleter),
+		),
+		readline.PcItem(daemonCommand,
+			readline.PcItem("start"),
+			readline.PcItem(daemonStop),
+			readline.PcItem(daemonStatus),
+		),
+		readline.PcItem(dashboardCommand),
 		// completions for common shell commands
 		readline.PcItem("git",
 			readline.PcItem("add"),
@@ -39,6 +39,9 @@ var (
 	// ErrConfigFileIsADirectory means the config file is a directory, thats wrong
 	ErrConfigFileIsADirectory = errors.New("the config file is a directory")
 
+	// ErrInvalidConfigField means the config has no field with the name
+	ErrInvalidConfigField = errors.New("invalid config field")
+
 	// path for project config file
 	projectConfigPath string
 
@@ -48,9 +51,6 @@ var (
 	// path for command scripts
 	scriptDir = zeusDir + "/scripts"
 
-	// regex for matching YAML keys from config file contents
-	configYamlField = regexp.MustCompile("^(\\s)*[A-Z]+(.|\\s)*:")
-
 	// regex for matching YAML keys from commands, config or data file
 	yamlField = regexp.MustCompile("^(\\s)*[a-z]+(.|\\s)*:")
 )
@@ -74,6 +74,9 @@ type configFields struct {
 	CodeSnippetScope    int                      `yaml:"codeSnippetScope"`
 	PortWebPanel        int                      `yaml:"portWebPanel"`
 	PortGlueServer      int                      `yaml:"portGlueServer"`
+	WebBindAddress      string                   `yaml:"webBindAddress"`
+	WebTLS              bool                     `yaml:"webTLS"`
+	MetricsToken        string                   `yaml:"metricsToken"`
 	HistoryFile         bool                     `yaml:"historyFile"`
 	ExitOnInterrupt     bool                     `yaml:"exitOnInterrupt"`
 	DisableTimestamps   bool                     `yaml:"disableTimestamps"`
@@ -81,12 +84,16 @@ type configFields struct {
 	MakefileOverview    bool                     `yaml:"makefileOverview"`
 	StopOnError         bool                     `yaml:"stopOnError"`
 	DumpScriptOnError   bool                     `yaml:"dumpScriptOnError"`
+	RunHistory          bool                     `yaml:"runHistory"`
+	RunHistoryLimit     int                      `yaml:"runHistoryLimit"`
+	RunHistoryMaxAge    string                   `yaml:"runHistoryMaxAge"`
 	ColorProfile        string                   `yaml:"colorProfile"`
 	DateFormat          string                   `yaml:"dateFormat"`
 	TodoFilePath        string                   `yaml:"todoFilePath"`
 	Editor              string                   `yaml:"editor"`
 	ColorProfiles       map[string]*ColorProfile `yaml:"colorProfiles"`
 	Languages           []*Language              `yaml:"languages"`
+	Notifications       []*notificationSink      `yaml:"notifications"`
 }
 
 // newConfig returns the default configuration in case there is no config file
@@ -105,12 +112,15 @@ func newConfig() *config {
 			RecursionDepth:      1,
 			HistoryLimit:        20,
 			PortWebPanel:        8080,
+			WebBindAddress:      "127.0.0.1",
 			CodeSnippetScope:    15,
 			ExitOnInterrupt:     true,
 			DisableTimestamps:   false,
 			PrintBuiltins:       false,
 			StopOnError:         true,
 			DumpScriptOnError:   true,
+			RunHistory:          true,
+			RunHistoryLimit:     1000,
 			// default: german date format DD-MM-YYYY
 			DateFormat:   "02-01-2006",
 			TodoFilePath: "TODO.md",
@@ -138,32 +148,8 @@ func validateConfig(path string) (data []byte, warnings []string, err error) {
 		return nil, warnings, err
 	}
 
-	var (
-		items        = configItems()
-		parsedFields []string
-		foundField   bool
-	)
-
-	for i, line := range strings.Split(string(c), "\n") {
-		field := configYamlField.FindString(line)
-		if field != "" && !strings.HasPrefix(field, "    ") {
-			field = strings.TrimSuffix(strings.TrimSpace(field), ":")
-			for _, item := range items {
-				if field == strings.TrimSpace(string(item.GetName())) {
-					for _, f := range parsedFields {
-						if f == field {
-							warnings = append(warnings, "line "+strconv.Itoa(i)+": duplicate config field: "+field)
-						}
-					}
-					parsedFields = append(parsedFields, field)
-					foundField = true
-				}
-			}
-			if !foundField && field != "colorProfiles" && field != "languages" {
-				warnings = append(warnings, "line "+strconv.Itoa(i)+": unknown config field: "+field)
-			}
-			foundField = false
-		}
+	for _, e := range schemas["config"].check(string(c)) {
+		warnings = append(warnings, e.Error())
 	}
 
 	return c, warnings, nil
@@ -359,6 +345,18 @@ func (c *config) getFieldInfo(field string) string {
 // set a config field to a specified value by its name
 func (c *config) setValue(field, value string) {
 
+	err := c.set(field, value)
+	if err != nil {
+		Log.WithError(err).Error("failed to set config field ", field)
+		return
+	}
+
+	Log.Info("set config field ", field, " to ", value)
+}
+
+// set a config field by its name, apply the config and write it to disk
+func (c *config) set(field, value string) error {
+
 	c.Lock()
 
 	// check if the named field exists on the struct
@@ -367,42 +365,43 @@ func (c *config) setValue(field, value string) {
 	c.Unlock()
 
 	if !f.IsValid() {
-		Log.Error("invalid config field: ", field)
-		return
+		return errors.New(ErrInvalidConfigField.Error() + ": " + field)
 	}
 
 	switch f.Kind() {
 	case reflect.Bool:
 		b, err := strconv.ParseBool(value)
 		if err != nil {
-			Log.WithError(err).Error("invalid boolean value: ", value)
-			return
+			return errors.New("invalid boolean value: " + value)
 		}
 
+		c.Lock()
 		f.SetBool(b)
-
-		Log.Info("set config field ", field, " to ", value)
+		c.Unlock()
 
 	case reflect.Int:
 		i, err := strconv.ParseInt(value, 10, 0)
 		if err != nil {
-			Log.WithError(err).Error("invalid integer value: ", value)
-			return
+			return errors.New("invalid integer value: " + value)
 		}
 
+		c.Lock()
 		f.SetInt(i)
+		c.Unlock()
 
-		Log.Info("set config field ", field, " to ", value)
 	case reflect.String:
+		c.Lock()
 		f.SetString(value)
-		Log.Info("set config field ", field, " to ", value)
+		c.Unlock()
+
 	default:
-		Log.Error("unknown type: ", f.Kind())
-		return
+		return errors.New("unknown type: " + f.Kind().String())
 	}
 
 	c.handle()
 	c.update()
+
+	return nil
 }
 
 // handle the config by applying updated values
@@ -0,0 +1,150 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"sync"
+)
+
+// ErrCancelled means the invocation has been cancelled
+var ErrCancelled = errors.New("execution cancelled")
+
+// execContext carries everything a single invocation of a command or command chain needs
+// each invocation gets its own context, so the shell, the event engine and the web interface
+// can run chains concurrently without sharing any state
+type execContext struct {
+
+	// progress counters and running processes of the invocation
+	status *status
+
+	// arguments for the command that is currently executed
+	args []string
+
+	// environment for the spawned processes
+	env []string
+
+	// cancellation of the invocation
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// output sink for the spawned processes
+	stdout io.Writer
+	stderr io.Writer
+
+	// stdin is only set for invocations that are attached to the terminal
+	stdin io.Reader
+
+	// logger for the progress messages
+	out *log.Logger
+
+	// background invocations are spawned in their own process group without stdin
+	background bool
+
+	// set for commands that are invoked as dependency of another command
+	dependency bool
+
+	// current trace span, nil if tracing is disabled
+	span *traceSpan
+
+	// run history recorder, nil if the history is disabled
+	history *runRecorder
+
+	// output stream for the web interface, nil if the web interface is not running
+	stream *outputStream
+
+	// current run or command in the dashboard
+	node *activityNode
+}
+
+// create an execution context attached to the terminal
+func newExecContext() *execContext {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &execContext{
+		status: newStatus(),
+		env:    os.Environ(),
+		ctx:    ctx,
+		cancel: cancel,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		stdin:  os.Stdin,
+		out:    l,
+	}
+}
+
+// create an execution context for invocations that run in the background
+// for example background jobs and chains triggered by events
+func newBackgroundContext() *execContext {
+
+	c := newExecContext()
+	c.stdin = nil
+	c.background = true
+
+	return c
+}
+
+// create an execution context that writes all output into the supplied writer
+func newOutputContext(w io.Writer) *execContext {
+
+	// stdout and stderr of the processes are copied concurrently
+	w = &syncWriter{w: w}
+
+	c := newBackgroundContext()
+	c.stdout = w
+	c.stderr = w
+	c.out = log.New(w, "", 0)
+
+	return c
+}
+
+// withArgs returns a copy of the context for invoking a command with the given arguments
+// status, environment, cancellation and output are shared with the parent
+func (c *execContext) withArgs(args []string) *execContext {
+	child := *c
+	child.args = args
+	return &child
+}
+
+// cancelled checks if the invocation has been cancelled
+func (c *execContext) cancelled() bool {
+	return c.ctx.Err() != nil
+}
+
+// done returns a channel that is closed when the invocation is cancelled
+func (c *execContext) done() <-chan struct{} {
+	return c.ctx.Done()
+}
+
+// syncWriter serializes writes to the underlying writer
+type syncWriter struct {
+	w io.Writer
+	sync.Mutex
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.w.Write(p)
+}
@@ -0,0 +1,778 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrDaemonNotRunning means there is no daemon listening on the socket of the project
+	ErrDaemonNotRunning = errors.New("no daemon running for this project")
+
+	// ErrDaemonRunning means a daemon is already listening on the socket of the project
+	ErrDaemonRunning = errors.New("daemon already running")
+)
+
+const (
+	// time to wait for a started daemon to accept connections
+	daemonStartTimeout = 10 * time.Second
+
+	// exit code of the client when it was interrupted
+	daemonInterruptedCode = 130
+)
+
+// message types of the daemon protocol
+const (
+	daemonRun    = "run"
+	daemonStatus = "status"
+	daemonStop   = "stop"
+	daemonOutput = "output"
+	daemonInput  = "input"
+	daemonEOF    = "eof"
+	daemonExit   = "exit"
+)
+
+// output streams of the daemon protocol
+const (
+	daemonStdout = "stdout"
+	daemonStderr = "stderr"
+)
+
+// path for the socket of the daemon
+func daemonSocketPath() string {
+	return filepath.Join(zeusDir, "daemon.sock")
+}
+
+// path for the output of a daemon started in the background
+func daemonLogPath() string {
+	return filepath.Join(zeusDir, "daemon.log")
+}
+
+// daemonMessage is sent over the socket, one JSON object per line
+// clients send run, status and stop requests, a run request is followed by the input of the client
+// the daemon replies with output messages and a final exit or status message
+type daemonMessage struct {
+	Type   string            `json:"type"`
+	Args   []string          `json:"args,omitempty"`
+	Env    []string          `json:"env,omitempty"`
+	Stream string            `json:"stream,omitempty"`
+	Data   string            `json:"data,omitempty"`
+	Input  []byte            `json:"input,omitempty"`
+	Code   int               `json:"code,omitempty"`
+	Error  string            `json:"error,omitempty"`
+	Status *daemonStatusInfo `json:"status,omitempty"`
+}
+
+// daemonStatusInfo describes a running daemon
+type daemonStatusInfo struct {
+	PID       int       `json:"pid"`
+	Version   string    `json:"version"`
+	Dir       string    `json:"dir"`
+	Start     time.Time `json:"start"`
+	Commands  int       `json:"commands"`
+	Events    int       `json:"events"`
+	Processes int       `json:"processes"`
+	Runs      int       `json:"runs"`
+}
+
+// daemon keeps the project loaded and runs commands for the clients
+type daemon struct {
+	listener net.Listener
+	start    time.Time
+
+	// number of runs in progress
+	runs int
+
+	// closed when the daemon was stopped
+	done chan struct{}
+
+	sync.Mutex
+}
+
+// listen on the socket, a stale socket of a crashed daemon is replaced
+func newDaemon(path string) (*daemon, error) {
+
+	if _, err := requestDaemonStatus(path); err == nil {
+		return nil, ErrDaemonRunning
+	}
+	os.Remove(path)
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the owner may run commands through the daemon
+	err = os.Chmod(path, 0600)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return &daemon{
+		listener: listener,
+		start:    time.Now(),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// accept connections until the daemon is stopped
+func (d *daemon) serve() {
+	for {
+		conn, err := d.listener.Accept()
+		if err != nil {
+			select {
+			case <-d.done:
+				return
+			default:
+				Log.WithError(err).Error("daemon: failed to accept connection")
+				continue
+			}
+		}
+		go d.handle(conn)
+	}
+}
+
+// stop accepting connections and remove the socket
+func (d *daemon) stop() {
+
+	d.Lock()
+	defer d.Unlock()
+
+	select {
+	case <-d.done:
+		return
+	default:
+	}
+
+	close(d.done)
+	d.listener.Close()
+}
+
+// handle a client connection
+func (d *daemon) handle(conn net.Conn) {
+
+	defer conn.Close()
+
+	var (
+		r   = bufio.NewReader(conn)
+		out = &daemonWriter{enc: json.NewEncoder(conn)}
+		req daemonMessage
+	)
+
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(line, &req)
+	if err != nil {
+		out.send(&daemonMessage{Type: daemonExit, Code: 1, Error: "invalid request: " + err.Error()})
+		return
+	}
+
+	switch req.Type {
+	case daemonStatus:
+		out.send(&daemonMessage{Type: daemonStatus, Status: d.status()})
+
+	case daemonStop:
+		Log.Info("daemon: stopping")
+		out.send(&daemonMessage{Type: daemonExit})
+		d.stop()
+
+	case daemonRun:
+		err := d.run(r, out, &req)
+		if err != nil {
+			_, code, _ := runResult(err)
+			if code < 0 {
+				code = daemonInterruptedCode
+			}
+			out.send(&daemonMessage{Type: daemonExit, Code: code, Error: ansiEscape.ReplaceAllString(err.Error(), "")})
+			return
+		}
+		out.send(&daemonMessage{Type: daemonExit})
+
+	default:
+		out.send(&daemonMessage{Type: daemonExit, Code: 1, Error: "unknown request type: " + req.Type})
+	}
+}
+
+// status of the daemon
+func (d *daemon) status() *daemonStatusInfo {
+
+	s := &daemonStatusInfo{
+		PID:     os.Getpid(),
+		Version: version,
+		Dir:     workingDir,
+		Start:   d.start,
+	}
+
+	d.Lock()
+	s.Runs = d.runs
+	d.Unlock()
+
+	cmdMap.Lock()
+	s.Commands = len(cmdMap.items)
+	cmdMap.Unlock()
+
+	projectData.Lock()
+	for _, e := range projectData.fields.Events {
+		if e.Command != "internal" {
+			s.Events++
+		}
+	}
+	projectData.Unlock()
+
+	processMapMutex.Lock()
+	s.Processes = len(processMap)
+	processMapMutex.Unlock()
+
+	return s
+}
+
+// resolve the arguments of a client to a command chain
+// the arguments are evaluated like the commandline arguments of zeus: a command, a chain or an alias
+func daemonChain(args []string) ([]string, error) {
+
+	if len(args) == 0 {
+		return nil, errors.New("no command supplied")
+	}
+
+	if _, err := cmdMap.getCommand(args[0]); err == nil {
+		return []string{strings.Join(args, " ")}, nil
+	}
+
+	if strings.Contains(args[0], commandChainSeparator) {
+		return expandChainAliases(args[0])
+	}
+
+	projectData.Lock()
+	command, ok := projectData.fields.Aliases[args[0]]
+	projectData.Unlock()
+
+	if ok {
+		line, err := expandAlias(command, args[1:])
+		if err != nil {
+			return nil, errors.New("invalid arguments for alias " + args[0] + ": " + err.Error())
+		}
+		if isPipeline(line) {
+			return nil, errors.New("alias " + args[0] + " contains a pipeline, which is not supported by the daemon")
+		}
+		return strings.Split(line, commandChainSeparator), nil
+	}
+
+	return nil, errors.New(ErrUnknownCommand.Error() + ": " + args[0])
+}
+
+// run a command chain with the environment and input of the client and send the output to the client
+// the run is cancelled when the client disconnects
+func (d *daemon) run(r *bufio.Reader, out *daemonWriter, req *daemonMessage) error {
+
+	fields, err := daemonChain(req.Args)
+	if err != nil {
+		return err
+	}
+
+	cmdChain, ok := validCommandChain(fields)
+	if !ok {
+		return errors.New("invalid commandChain: " + strings.Join(req.Args, " "))
+	}
+
+	// the processes read the input of the client from a pipe
+	stdin, input, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer stdin.Close()
+
+	d.Lock()
+	d.runs++
+	d.Unlock()
+
+	defer func() {
+		d.Lock()
+		d.runs--
+		d.Unlock()
+	}()
+
+	ctx := newBackgroundContext()
+	ctx.stdin = stdin
+	ctx.stdout = &syncWriter{w: &daemonStream{w: out, name: daemonStdout, fallback: os.Stdout}}
+	ctx.stderr = &syncWriter{w: &daemonStream{w: out, name: daemonStderr, fallback: os.Stderr}}
+	ctx.out = log.New(ctx.stdout, "", 0)
+	if len(req.Env) > 0 {
+		ctx.env = req.Env
+	}
+
+	// copy the input of the client into the pipe
+	// reading fails when the client disconnects, which cancels the run
+	go func() {
+		dec := json.NewDecoder(r)
+		for {
+			var msg daemonMessage
+			if err := dec.Decode(&msg); err != nil {
+				input.Close()
+				ctx.cancel()
+				return
+			}
+			switch msg.Type {
+			case daemonInput:
+				input.Write(msg.Input)
+			case daemonEOF:
+				input.Close()
+			}
+		}
+	}()
+
+	return cmdChain.exec(ctx, fields)
+}
+
+// daemonWriter sends messages to a client
+type daemonWriter struct {
+	enc    *json.Encoder
+	closed bool
+	sync.Mutex
+}
+
+// daemonStream sends the output of a stream to the client
+// once the client is gone, the output of processes that are still running goes to the daemon log
+type daemonStream struct {
+	w        *daemonWriter
+	name     string
+	fallback io.Writer
+}
+
+func (s *daemonStream) Write(p []byte) (int, error) {
+
+	s.w.Lock()
+	closed := s.w.closed
+	s.w.Unlock()
+
+	if !closed && s.w.send(&daemonMessage{Type: daemonOutput, Stream: s.name, Data: string(p)}) == nil {
+		return len(p), nil
+	}
+
+	return s.fallback.Write(p)
+}
+
+// send a message, returns an error if the client is gone
+func (w *daemonWriter) send(msg *daemonMessage) error {
+
+	w.Lock()
+	defer w.Unlock()
+
+	if w.closed {
+		return ErrDaemonNotRunning
+	}
+
+	err := w.enc.Encode(msg)
+	if err != nil {
+		w.closed = true
+	}
+
+	return err
+}
+
+/*
+ *	Client
+ */
+
+// connect to the daemon and send a request
+func dialDaemon(path string, req *daemonMessage) (net.Conn, *json.Decoder, error) {
+
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return nil, nil, ErrDaemonNotRunning
+	}
+
+	err = json.NewEncoder(conn).Encode(req)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	return conn, json.NewDecoder(conn), nil
+}
+
+// request the status of the daemon
+func requestDaemonStatus(path string) (*daemonStatusInfo, error) {
+
+	conn, dec, err := dialDaemon(path, &daemonMessage{Type: daemonStatus})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var msg daemonMessage
+	err = dec.Decode(&msg)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Status == nil {
+		return nil, errors.New("invalid status response: " + msg.Error)
+	}
+
+	return msg.Status, nil
+}
+
+// ask the daemon to stop
+func requestDaemonStop(path string) error {
+
+	conn, dec, err := dialDaemon(path, &daemonMessage{Type: daemonStop})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var msg daemonMessage
+	return dec.Decode(&msg)
+}
+
+// daemonClient contains the environment and the standard streams of a client
+type daemonClient struct {
+	env    []string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// run a command on the daemon with the environment and input of the client
+// the output is copied to stdout and stderr of the client
+// returns the exit code for the client
+func requestDaemonRun(path string, args []string, client *daemonClient, interrupt <-chan os.Signal) (int, error) {
+
+	conn, dec, err := dialDaemon(path, &daemonMessage{Type: daemonRun, Args: args, Env: client.env})
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if client.stdin != nil {
+		go sendDaemonInput(conn, client.stdin)
+	} else {
+		json.NewEncoder(conn).Encode(&daemonMessage{Type: daemonEOF})
+	}
+
+	// closing the connection cancels the run
+	interrupted := make(chan struct{})
+	go func() {
+		if _, ok := <-interrupt; ok {
+			close(interrupted)
+			conn.Close()
+		}
+	}()
+
+	for {
+		var msg daemonMessage
+		err := dec.Decode(&msg)
+		if err != nil {
+			select {
+			case <-interrupted:
+				return daemonInterruptedCode, nil
+			default:
+				return 1, errors.New("lost connection to the daemon: " + err.Error())
+			}
+		}
+
+		switch msg.Type {
+		case daemonOutput:
+			if msg.Stream == daemonStderr {
+				io.WriteString(client.stderr, msg.Data)
+			} else {
+				io.WriteString(client.stdout, msg.Data)
+			}
+		case daemonExit:
+			if msg.Error != "" {
+				return msg.Code, errors.New(msg.Error)
+			}
+			return msg.Code, nil
+		}
+	}
+}
+
+// copy the input of the client to the daemon until EOF or until the connection is closed
+func sendDaemonInput(conn net.Conn, stdin io.Reader) {
+
+	var (
+		enc = json.NewEncoder(conn)
+		buf = make([]byte, 32*1024)
+	)
+
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			if enc.Encode(&daemonMessage{Type: daemonInput, Input: buf[:n]}) != nil {
+				return
+			}
+		}
+		if err != nil {
+			enc.Encode(&daemonMessage{Type: daemonEOF})
+			return
+		}
+	}
+}
+
+// check if the arguments are forwarded to a daemon
+// builtins, flags, pipelines and traced invocations are always handled by the client
+func forwardToDaemon(args []string) bool {
+
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return false
+	}
+
+	if _, ok := builtins[args[0]]; ok {
+		return false
+	}
+
+	// traces are recorded by the process that executes the commands
+	activeTracerMutex.Lock()
+	tracing := activeTracer != nil
+	activeTracerMutex.Unlock()
+	if tracing {
+		return false
+	}
+
+	switch args[0] {
+	case daemonCommand, bootstrapCommand, "zeus":
+		return false
+	}
+
+	return !isPipelineArgs(args)
+}
+
+// send the commandline arguments to the daemon if one is running
+// returns false if there is no daemon for the project
+func runOnDaemon(args []string) (int, bool) {
+
+	if !forwardToDaemon(args) {
+		return 0, false
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	code, err := requestDaemonRun(daemonSocketPath(), args, &daemonClient{
+		env:    os.Environ(),
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}, interrupt)
+	if err == ErrDaemonNotRunning {
+		return 0, false
+	}
+	if err != nil {
+		Log.Error(err)
+		if code == 0 {
+			code = 1
+		}
+	}
+
+	return code, true
+}
+
+/*
+ *	Builtin
+ */
+
+func printDaemonUsageErr() {
+	l.Println(ErrInvalidUsage)
+	l.Println("usage: daemon [start] [stop] [status]")
+}
+
+// handle daemon command
+// the run subcommand is only available on the commandline, it runs the daemon in the foreground
+func handleDaemonCommand(args []string) error {
+
+	if len(args) < 2 {
+		args = append(args, daemonStatus)
+	}
+
+	if len(args) > 2 {
+		printDaemonUsageErr()
+		return ErrInvalidUsage
+	}
+
+	var err error
+	switch args[1] {
+	case "start":
+		err = startDaemon()
+	case daemonStop:
+		err = requestDaemonStop(daemonSocketPath())
+		if err == nil {
+			l.Println("daemon stopped")
+		}
+	case daemonStatus:
+		err = printDaemonStatus()
+	default:
+		printDaemonUsageErr()
+		return ErrInvalidUsage
+	}
+
+	if err != nil {
+		l.Println(err)
+	}
+
+	return err
+}
+
+// print the status of the daemon
+func printDaemonStatus() error {
+
+	s, err := requestDaemonStatus(daemonSocketPath())
+	if err != nil {
+		return err
+	}
+
+	l.Println(cp.Text + pad("PID", 14) + cp.Prompt + strconv.Itoa(s.PID))
+	l.Println(cp.Text + pad("Version", 14) + cp.Prompt + s.Version)
+	l.Println(cp.Text + pad("Directory", 14) + cp.Prompt + s.Dir)
+	l.Println(cp.Text + pad("Uptime", 14) + cp.Prompt + formatDuration(time.Since(s.Start)))
+	l.Println(cp.Text + pad("Commands", 14) + cp.Prompt + strconv.Itoa(s.Commands))
+	l.Println(cp.Text + pad("Events", 14) + cp.Prompt + strconv.Itoa(s.Events))
+	l.Println(cp.Text + pad("Processes", 14) + cp.Prompt + strconv.Itoa(s.Processes))
+	l.Println(cp.Text + pad("Runs", 14) + cp.Prompt + strconv.Itoa(s.Runs) + cp.Reset)
+
+	return nil
+}
+
+// start the daemon in the background and wait until it accepts connections
+func startDaemon() error {
+
+	if _, err := requestDaemonStatus(daemonSocketPath()); err == nil {
+		return ErrDaemonRunning
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	logFile, err := os.OpenFile(daemonLogPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(exe, daemonCommand, "run")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+
+	// detach from the terminal, the daemon must survive the shell
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	deadline := time.Now().Add(daemonStartTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case err := <-exited:
+			return errors.New("daemon exited, see " + daemonLogPath() + " for details: " + errString(err))
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		if s, err := requestDaemonStatus(daemonSocketPath()); err == nil {
+			l.Println("daemon started with PID " + strconv.Itoa(s.PID) + ", output goes to " + daemonLogPath())
+			return nil
+		}
+	}
+
+	return errors.New("daemon did not start within " + daemonStartTimeout.String() + ", see " + daemonLogPath())
+}
+
+// message of an error that can be nil
+func errString(err error) string {
+	if err == nil {
+		return "exit status 0"
+	}
+	return err.Error()
+}
+
+// run the daemon in the foreground until it is stopped
+// watchers for the config, the CommandsFile and the events are running like in the interactive shell
+func runDaemon() error {
+
+	d, err := newDaemon(daemonSocketPath())
+	if err != nil {
+		return err
+	}
+	defer os.Remove(daemonSocketPath())
+
+	// the interactive mode already started the watchers
+	if !conf.fields.Interactive {
+
+		go conf.watch("")
+
+		if conf.fields.AutoFormat {
+			go f.watchScriptDir("")
+			go f.watchGlobalsDir("")
+		}
+
+		if _, err := os.Stat(commandsFilePath); err == nil {
+			go watchCommandsFile(commandsFilePath, "")
+		}
+	}
+
+	// serve the webinterface and the metrics
+	if conf.fields.WebInterface {
+		go StartWebListener(false)
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	go func() {
+		s := <-sig
+		Log.Info("daemon: received ", s)
+		d.stop()
+	}()
+
+	Log.Info("daemon: listening on ", daemonSocketPath(), " with PID ", os.Getpid())
+
+	d.serve()
+
+	// kill the processes that were spawned by the daemon
+	cleanup()
+
+	return nil
+}
@@ -0,0 +1,1156 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dreadl0ck/readline"
+	"github.com/mgutz/ansi"
+)
+
+var (
+	// runs, commands and events shown by the dashboard
+	activity = &activityMonitor{}
+
+	// ErrNoTerminal means the dashboard was not started in a terminal
+	ErrNoTerminal = errors.New("the dashboard requires a terminal")
+)
+
+const (
+	// number of runs kept, the oldest finished runs are removed first
+	maxActivityRuns = 50
+
+	// number of fired events kept
+	maxActivityEvents = 100
+
+	// number of output lines kept per run and command
+	maxActivityLines = 500
+
+	// longer output lines are cut
+	maxActivityLineLength = 512
+
+	// interval for redrawing the dashboard
+	dashboardRefresh = 500 * time.Millisecond
+)
+
+// state of a command that has not been started yet
+const stateWaiting = "waiting"
+
+// activityNode is a run or a command of a run
+// runs are the roots of the tree, their children are the invoked commands and dependencies
+type activityNode struct {
+	name       string
+	args       string
+	dependency bool
+	state      string
+	start      time.Time
+	end        time.Time
+	children   []*activityNode
+
+	// output lines and the last unterminated line
+	lines   []string
+	partial string
+
+	// run of the node, roots point to themselves
+	run *activityNode
+
+	// set for commands that started their own run
+	owner bool
+
+	// cancels the invocation, only set for runs
+	cancel context.CancelFunc
+}
+
+// activityEvent is a fired event
+type activityEvent struct {
+	time    time.Time
+	op      string
+	file    string
+	command string
+}
+
+// activityMonitor keeps track of the recent runs and events
+// all nodes are protected by the mutex of the monitor
+type activityMonitor struct {
+
+	// oldest first
+	runs   []*activityNode
+	events []*activityEvent
+
+	// output of new invocations is captured while the dashboard is open
+	open bool
+
+	sync.Mutex
+}
+
+// add a run and remove the oldest finished runs if there are too many
+func (m *activityMonitor) addRun(n *activityNode) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.runs = append(m.runs, n)
+
+	for i := 0; i < len(m.runs) && len(m.runs) > maxActivityRuns; {
+		if m.runs[i].state == stateRunning {
+			i++
+			continue
+		}
+		m.runs = append(m.runs[:i], m.runs[i+1:]...)
+	}
+}
+
+// record a fired event
+func (m *activityMonitor) addEvent(op, file, command string) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.events = append(m.events, &activityEvent{
+		time:    time.Now(),
+		op:      op,
+		file:    file,
+		command: command,
+	})
+	if len(m.events) > maxActivityEvents {
+		m.events = m.events[len(m.events)-maxActivityEvents:]
+	}
+}
+
+// check if output should be captured for a writer
+// output that goes to the terminal directly is only captured while the dashboard is open,
+// so commands keep their terminal otherwise
+func (m *activityMonitor) capture(w io.Writer) bool {
+
+	if _, ok := w.(*os.File); !ok {
+		return true
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	return m.open
+}
+
+// trackRun adds a run for the dashboard
+// the context is returned unchanged if the invocation is already tracked
+func (c *execContext) trackRun(chain string) (*execContext, *activityNode) {
+
+	if c.node != nil {
+		return c, nil
+	}
+
+	n := &activityNode{
+		name:   chain,
+		state:  stateRunning,
+		start:  time.Now(),
+		cancel: c.cancel,
+	}
+	n.run = n
+
+	ctx := c.withNode(n)
+	activity.addRun(n)
+
+	return ctx, n
+}
+
+// trackCommand adds the command to the run of the invocation
+// commands invoked outside of a chain start a new run, that is finished along with the command
+func (c *execContext) trackCommand(name string, dependency bool) (*execContext, *activityNode) {
+
+	ctx, run := c.trackRun(strings.TrimSpace(name + " " + strings.Join(c.args, " ")))
+
+	n := &activityNode{
+		name:       name,
+		args:       strings.Join(ctx.args, " "),
+		dependency: dependency,
+		state:      stateWaiting,
+		start:      time.Now(),
+		run:        ctx.node.run,
+		owner:      run != nil,
+	}
+
+	activity.Lock()
+	ctx.node.children = append(ctx.node.children, n)
+	activity.Unlock()
+
+	return ctx.withNode(n), n
+}
+
+// activityHook tracks the runs and their commands for the dashboard
+func activityHook(ctx *execContext, inv *invocation) (*execContext, *invocationObserver) {
+
+	if inv.kind == invocationChain {
+		ctx, run := ctx.trackRun(inv.line)
+		if run == nil {
+			return ctx, nil
+		}
+		return ctx, &invocationObserver{
+			finished: func(inv *invocation) {
+				run.setState(inv.state)
+			},
+		}
+	}
+
+	ctx, n := ctx.trackCommand(inv.name, inv.kind == invocationDependency)
+
+	o := &invocationObserver{
+		state: func(inv *invocation, state string) {
+			n.setState(state)
+		},
+	}
+
+	// commands invoked outside of a chain finish their run
+	if n.owner {
+		o.finished = func(inv *invocation) {
+			n.run.setState(inv.state)
+		}
+	}
+
+	return ctx, o
+}
+
+// withNode returns a copy of the context for the node
+// the output is written to the node as well, if it is captured
+func (c *execContext) withNode(n *activityNode) *execContext {
+
+	child := *c
+	child.node = n
+
+	if activity.capture(c.stdout) {
+		child.stdout = io.MultiWriter(c.stdout, n)
+		child.stderr = io.MultiWriter(c.stderr, n)
+		child.out = log.New(io.MultiWriter(c.out.Writer(), n), c.out.Prefix(), c.out.Flags())
+	}
+
+	return &child
+}
+
+// set the state of a run or command
+func (n *activityNode) setState(state string) {
+	if n == nil {
+		return
+	}
+
+	activity.Lock()
+	defer activity.Unlock()
+
+	n.state = state
+	if state != stateRunning {
+		n.end = time.Now()
+	}
+}
+
+// time since the node was started, until it finished
+func (n *activityNode) elapsed(now time.Time) time.Duration {
+	if n.end.IsZero() {
+		return now.Sub(n.start)
+	}
+	return n.end.Sub(n.start)
+}
+
+// Write adds the output to the lines of the node
+func (n *activityNode) Write(p []byte) (int, error) {
+
+	activity.Lock()
+	defer activity.Unlock()
+
+	parts := strings.Split(n.partial+stripANSI(string(p)), "\n")
+	for _, line := range parts[:len(parts)-1] {
+		n.lines = append(n.lines, cleanLine(line))
+	}
+
+	n.partial = parts[len(parts)-1]
+	if len(n.partial) > maxActivityLineLength {
+		n.lines = append(n.lines, cleanLine(n.partial))
+		n.partial = ""
+	}
+
+	if len(n.lines) > maxActivityLines {
+		n.lines = n.lines[len(n.lines)-maxActivityLines:]
+	}
+
+	return len(p), nil
+}
+
+// output lines including the unterminated line, activity must be locked by the caller
+func (n *activityNode) output() []string {
+	if n.partial == "" {
+		return n.lines
+	}
+	return append(n.lines[:len(n.lines):len(n.lines)], cleanLine(n.partial))
+}
+
+// remove ANSI escape sequences
+func stripANSI(s string) string {
+
+	if strings.IndexByte(s, 0x1b) < 0 {
+		return s
+	}
+
+	var (
+		b    strings.Builder
+		data = []byte(s)
+	)
+	for i := 0; i < len(data); {
+		if data[i] != 0x1b {
+			b.WriteByte(data[i])
+			i++
+			continue
+		}
+		n, _, _, ok := parseEscape(data[i:])
+		if !ok {
+			break
+		}
+		i += n
+	}
+
+	return b.String()
+}
+
+// prepare an output line for the terminal
+// carriage returns overwrite the line, like progress bars do
+func cleanLine(line string) string {
+
+	line = strings.TrimRight(line, "\r")
+	if i := strings.LastIndexByte(line, '\r'); i >= 0 {
+		line = line[i+1:]
+	}
+	line = strings.Replace(line, "\t", "    ", -1)
+
+	var b strings.Builder
+	for _, r := range line {
+		if r < 32 || r == 127 {
+			continue
+		}
+		if b.Len() >= maxActivityLineLength {
+			break
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+/*
+ *	Dashboard
+ */
+
+type dashboardPane int
+
+const (
+	paneRuns dashboardPane = iota
+	paneProcesses
+)
+
+// dashboard is the state of the terminal UI
+type dashboard struct {
+	width  int
+	height int
+
+	// pane that receives the selection keys
+	focus dashboardPane
+
+	// selected run or command, and PID of the selected process
+	selected *activityNode
+	process  int
+
+	// number of lines the output is scrolled up from the bottom
+	scroll int
+
+	// the output of the selected node fills the screen
+	attached bool
+
+	// PID of the process to attach to, handled by the caller
+	attachPID int
+
+	// feedback for the last key
+	message string
+}
+
+// dashboardRow is a line in the runs pane
+type dashboardRow struct {
+	node  *activityNode
+	depth int
+}
+
+// rows of the runs pane, newest run first, activity must be locked by the caller
+func (m *activityMonitor) rows() []dashboardRow {
+
+	var (
+		rows []dashboardRow
+		add  func(n *activityNode, depth int)
+	)
+
+	add = func(n *activityNode, depth int) {
+		rows = append(rows, dashboardRow{node: n, depth: depth})
+		for _, child := range n.children {
+			add(child, depth+1)
+		}
+	}
+
+	for i := len(m.runs) - 1; i >= 0; i-- {
+		add(m.runs[i], 0)
+	}
+
+	return rows
+}
+
+// spawned processes, sorted by PID
+func dashboardProcesses() []Process {
+
+	processMapMutex.Lock()
+	var list []Process
+	for _, p := range processMap {
+		list = append(list, *p)
+	}
+	processMapMutex.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].PID < list[j].PID
+	})
+
+	return list
+}
+
+// select the first row if the selected node is gone, activity must be locked by the caller
+func (d *dashboard) ensureSelection(rows []dashboardRow) int {
+
+	for i, r := range rows {
+		if r.node == d.selected {
+			return i
+		}
+	}
+
+	if len(rows) == 0 {
+		d.selected = nil
+		return -1
+	}
+
+	d.selected = rows[0].node
+	d.scroll = 0
+
+	return 0
+}
+
+// select the first process if the selected process is gone
+func (d *dashboard) ensureProcess(procs []Process) int {
+
+	for i, p := range procs {
+		if p.PID == d.process {
+			return i
+		}
+	}
+
+	if len(procs) == 0 {
+		d.process = 0
+		return -1
+	}
+
+	d.process = procs[0].PID
+
+	return 0
+}
+
+// handle a key, returns true if the dashboard should be closed
+func (d *dashboard) handleKey(key string) bool {
+
+	d.message = ""
+
+	switch key {
+	case "q", "ctrl-c":
+		return true
+	case "esc":
+		if !d.attached {
+			return true
+		}
+		d.attached = false
+	case "tab":
+		if d.focus == paneRuns {
+			d.focus = paneProcesses
+		} else {
+			d.focus = paneRuns
+		}
+	case "up", "k":
+		d.move(-1)
+	case "down", "j":
+		d.move(1)
+	case "pgup":
+		d.scroll += d.logHeight() / 2
+	case "pgdn":
+		d.scroll -= d.logHeight() / 2
+		if d.scroll < 0 {
+			d.scroll = 0
+		}
+	case "c":
+		d.cancel()
+	case "r":
+		d.restart()
+	case "a", "enter":
+		d.attach()
+	}
+
+	return false
+}
+
+// move the selection in the focused pane
+func (d *dashboard) move(offset int) {
+
+	if d.focus == paneProcesses {
+		procs := dashboardProcesses()
+		i := d.ensureProcess(procs) + offset
+		if i >= 0 && i < len(procs) {
+			d.process = procs[i].PID
+		}
+		return
+	}
+
+	activity.Lock()
+	defer activity.Unlock()
+
+	rows := activity.rows()
+	i := d.ensureSelection(rows) + offset
+	if i >= 0 && i < len(rows) {
+		d.selected = rows[i].node
+		d.scroll = 0
+	}
+}
+
+// cancel the run of the selected node or kill the selected process
+func (d *dashboard) cancel() {
+
+	if d.focus == paneProcesses {
+		if d.process == 0 {
+			d.message = "no process selected"
+			return
+		}
+		pid := strconv.Itoa(d.process)
+		err := exec.Command("kill", pid).Run()
+		if err != nil {
+			d.message = "failed to kill PID " + pid + ": " + err.Error()
+			return
+		}
+		deleteProcessByPID(d.process)
+		d.message = "killed PID " + pid
+		return
+	}
+
+	activity.Lock()
+	var (
+		run     *activityNode
+		running bool
+	)
+	if d.selected != nil {
+		run = d.selected.run
+		running = run.state == stateRunning
+	}
+	activity.Unlock()
+
+	if !running {
+		d.message = "no running run selected"
+		return
+	}
+
+	run.cancel()
+	d.message = "cancelled " + run.name
+}
+
+// run the chain of the selected node again, a running chain is cancelled first
+func (d *dashboard) restart() {
+
+	if d.selected == nil {
+		d.message = "no run selected"
+		return
+	}
+
+	activity.Lock()
+	var (
+		run     = d.selected.run
+		running = run.state == stateRunning
+	)
+	activity.Unlock()
+
+	if running {
+		run.cancel()
+	}
+
+	_, err := startAPIRun(strings.Split(run.name, commandChainSeparator))
+	if err != nil {
+		d.message = "failed to restart " + run.name + ": " + err.Error()
+		return
+	}
+
+	// the new run is added at the top
+	d.selected = nil
+	d.message = "restarted " + run.name
+}
+
+// show the output of the selected node on the whole screen
+// or attach to the selected process
+func (d *dashboard) attach() {
+
+	if d.focus == paneProcesses {
+		if d.process == 0 {
+			d.message = "no process selected"
+			return
+		}
+		d.attachPID = d.process
+		return
+	}
+
+	d.attached = !d.attached
+	d.scroll = 0
+}
+
+// number of output lines on the screen
+func (d *dashboard) logHeight() int {
+	if d.attached {
+		return d.height - 3
+	}
+	return d.height - 3 - d.eventsHeight()
+}
+
+func (d *dashboard) eventsHeight() int {
+	h := d.height / 4
+	if h < 3 {
+		h = 3
+	}
+	return h
+}
+
+// render the screen
+// every line is padded to the full width, so the previous frame is overwritten
+func (d *dashboard) render() string {
+
+	var (
+		w   = d.width
+		h   = d.height
+		now = time.Now()
+	)
+
+	if w < 40 || h < 12 {
+		return "\x1b[H\x1b[2J" + "terminal too small"
+	}
+
+	procs := dashboardProcesses()
+	d.ensureProcess(procs)
+
+	activity.Lock()
+	defer activity.Unlock()
+
+	rows := activity.rows()
+	d.ensureSelection(rows)
+
+	lines := []string{d.header(w, now, len(activity.runs), len(procs))}
+	body := h - 2
+
+	if d.attached {
+		lines = append(lines, d.logPane(w, body)...)
+	} else {
+		var (
+			eventsHeight = d.eventsHeight()
+			leftWidth    = w * 2 / 5
+			rightWidth   = w - leftWidth - 1
+		)
+		body -= eventsHeight
+		procsHeight := body / 3
+
+		left := append(d.runsPane(rows, leftWidth, body-procsHeight, now), d.processesPane(procs, leftWidth, procsHeight)...)
+		right := d.logPane(rightWidth, body)
+		for i := range left {
+			lines = append(lines, left[i]+ansi.LightBlack+"│"+ansi.Reset+right[i])
+		}
+		lines = append(lines, d.eventsPane(w, eventsHeight)...)
+	}
+
+	lines = append(lines, d.footer(w))
+
+	return "\x1b[H" + strings.Join(lines, "\r\n")
+}
+
+func (d *dashboard) header(w int, now time.Time, runs, procs int) string {
+	left := " ZEUS dashboard · " + filepath.Base(workingDir)
+	right := "runs " + strconv.Itoa(runs) + " · processes " + strconv.Itoa(procs) + " · " + now.Format("15:04:05") + " "
+	return "\x1b[7m" + fitColumns(left, right, w) + ansi.Reset
+}
+
+func (d *dashboard) footer(w int) string {
+	keys := " ↑↓ select  tab pane  c cancel  r restart  a attach  pgup/pgdn scroll  q quit"
+	if d.attached {
+		keys = " esc detach  pgup/pgdn scroll  q quit"
+	}
+	return ansi.LightBlack + fitColumns(keys, d.message+" ", w) + ansi.Reset
+}
+
+// title line of a pane
+func paneTitle(title string, w int, focused bool) string {
+	if focused {
+		return "\x1b[1;4m" + fit(" "+title, w) + ansi.Reset
+	}
+	return "\x1b[1m" + fit(" "+title, w) + ansi.Reset
+}
+
+// the runs with their commands and dependencies
+func (d *dashboard) runsPane(rows []dashboardRow, w, h int, now time.Time) []string {
+
+	lines := []string{paneTitle("Runs", w, d.focus == paneRuns)}
+
+	// keep the selection visible
+	var (
+		visible = h - 1
+		start   = 0
+	)
+	for i, r := range rows {
+		if r.node == d.selected && i >= visible {
+			start = i - visible + 1
+		}
+	}
+
+	for i := start; i < len(rows) && len(lines) < h; i++ {
+
+		var (
+			n     = rows[i].node
+			label = n.name
+		)
+		if n.run != n && n.args != "" {
+			label += " " + n.args
+		}
+
+		text := fitColumns(" "+strings.Repeat("  ", rows[i].depth)+stateSymbol(n.state)+" "+label, n.state+" "+formatDuration(n.elapsed(now))+" ", w)
+
+		switch {
+		case n == d.selected && d.focus == paneRuns:
+			lines = append(lines, "\x1b[7m"+text+ansi.Reset)
+		case n == d.selected:
+			lines = append(lines, "\x1b[1m"+stateANSIColor(n.state)+text+ansi.Reset)
+		default:
+			lines = append(lines, stateANSIColor(n.state)+text+ansi.Reset)
+		}
+	}
+
+	return fillPane(lines, w, h)
+}
+
+// the spawned processes
+func (d *dashboard) processesPane(procs []Process, w, h int) []string {
+
+	lines := []string{paneTitle("Processes", w, d.focus == paneProcesses)}
+
+	for _, p := range procs {
+		if len(lines) == h {
+			break
+		}
+		text := fit(" "+pad(strconv.Itoa(p.PID), 8)+p.Name, w)
+		if p.PID == d.process && d.focus == paneProcesses {
+			text = "\x1b[7m" + text + ansi.Reset
+		}
+		lines = append(lines, text)
+	}
+
+	return fillPane(lines, w, h)
+}
+
+// the output of the selected node
+func (d *dashboard) logPane(w, h int) []string {
+
+	if d.selected == nil {
+		return fillPane([]string{paneTitle("Output", w, false), fit(" no runs yet", w)}, w, h)
+	}
+
+	var (
+		output  = d.selected.output()
+		visible = h - 1
+		title   = "Output: " + d.selected.name
+	)
+
+	if d.selected.args != "" && d.selected.run != d.selected {
+		title += " " + d.selected.args
+	}
+
+	if max := len(output) - visible; d.scroll > max {
+		d.scroll = max
+	}
+	if d.scroll < 0 {
+		d.scroll = 0
+	}
+	if d.scroll > 0 {
+		title += " (scrolled up " + strconv.Itoa(d.scroll) + " lines)"
+	}
+
+	end := len(output) - d.scroll
+	start := end - visible
+	if start < 0 {
+		start = 0
+	}
+
+	lines := []string{paneTitle(title, w, d.attached)}
+	for _, line := range output[start:end] {
+		lines = append(lines, fit(" "+line, w))
+	}
+
+	if len(output) == 0 {
+		lines = append(lines, fit(" no output captured", w))
+	}
+
+	return fillPane(lines, w, h)
+}
+
+// the fired events, newest first
+func (d *dashboard) eventsPane(w, h int) []string {
+
+	lines := []string{paneTitle("Events", w, false)}
+
+	for i := len(activity.events) - 1; i >= 0 && len(lines) < h; i-- {
+		e := activity.events[i]
+		lines = append(lines, fit(" "+e.time.Format("15:04:05")+"  "+pad(e.op, 7)+" "+e.file+" → "+e.command, w))
+	}
+
+	return fillPane(lines, w, h)
+}
+
+// pad the pane with empty lines
+func fillPane(lines []string, w, h int) []string {
+	for len(lines) < h {
+		lines = append(lines, strings.Repeat(" ", w))
+	}
+	return lines[:h]
+}
+
+// cut or pad the text to the width
+func fit(s string, w int) string {
+
+	n := utf8.RuneCountInString(s)
+	if n <= w {
+		return s + strings.Repeat(" ", w-n)
+	}
+	if w < 1 {
+		return ""
+	}
+
+	return string([]rune(s)[:w-1]) + "…"
+}
+
+// left aligned and right aligned text on a line of the width
+// the left text is cut if both do not fit
+func fitColumns(left, right string, w int) string {
+
+	r := utf8.RuneCountInString(right)
+	if r >= w {
+		return fit(left, w)
+	}
+
+	return fit(left, w-r) + right
+}
+
+func stateSymbol(state string) string {
+	switch state {
+	case stateRunning:
+		return "▶"
+	case stateFinished:
+		return "✔"
+	case stateFailed:
+		return "✘"
+	case stateSkipped:
+		return "↷"
+	case runCancelled:
+		return "■"
+	default:
+		return "○"
+	}
+}
+
+func stateANSIColor(state string) string {
+	switch state {
+	case stateRunning:
+		return ansi.Yellow
+	case stateFinished:
+		return ansi.Green
+	case stateFailed:
+		return ansi.Red
+	case runCancelled:
+		return ansi.Magenta
+	default:
+		return ansi.LightBlack
+	}
+}
+
+// decode the keys in the terminal input
+func dashboardKeys(b []byte) []string {
+
+	var keys []string
+	for i := 0; i < len(b); {
+
+		switch b[i] {
+		case 0x1b:
+			// SS3 sequences are sent for the arrow keys in application mode
+			if i+2 < len(b) && b[i+1] == 'O' {
+				switch b[i+2] {
+				case 'A':
+					keys = append(keys, "up")
+				case 'B':
+					keys = append(keys, "down")
+				}
+				i += 3
+				continue
+			}
+
+			n, params, final, ok := parseEscape(b[i:])
+			if !ok || b[i+1] != '[' {
+				keys = append(keys, "esc")
+				i++
+				continue
+			}
+			i += n
+
+			switch {
+			case final == 'A':
+				keys = append(keys, "up")
+			case final == 'B':
+				keys = append(keys, "down")
+			case final == '~' && params == "5":
+				keys = append(keys, "pgup")
+			case final == '~' && params == "6":
+				keys = append(keys, "pgdn")
+			}
+
+		case 0x03:
+			keys = append(keys, "ctrl-c")
+			i++
+		case '\r', '\n':
+			keys = append(keys, "enter")
+			i++
+		case '\t':
+			keys = append(keys, "tab")
+			i++
+		default:
+			r, size := utf8.DecodeRune(b[i:])
+			keys = append(keys, string(r))
+			i += size
+		}
+	}
+
+	return keys
+}
+
+// read the terminal input in the background
+// after each chunk the reader waits for the dashboard, so no input is consumed after it was closed
+func readDashboardKeys(r io.Reader) (<-chan []byte, chan<- bool) {
+
+	var (
+		keys = make(chan []byte)
+		next = make(chan bool)
+	)
+
+	go func() {
+		defer close(keys)
+
+		buf := make([]byte, 64)
+		for {
+			n, err := r.Read(buf)
+			if err != nil {
+				return
+			}
+			keys <- append([]byte{}, buf[:n]...)
+			if !<-next {
+				return
+			}
+		}
+	}()
+
+	return keys, next
+}
+
+var (
+	// output of new invocations is discarded while the dashboard is open
+	dashboardNull     *os.File
+	dashboardNullOnce sync.Once
+)
+
+// redirect the terminal output while the dashboard is open
+// commands and log messages would be written over the screen, their output is captured instead
+// returns the terminal and a function that restores the output
+func muteTerminal() (*os.File, func(), error) {
+
+	var err error
+	dashboardNullOnce.Do(func() {
+		dashboardNull, err = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	})
+	if dashboardNull == nil {
+		return nil, nil, errors.New("failed to open " + os.DevNull + ": " + errString(err))
+	}
+
+	var (
+		stdout = os.Stdout
+		stderr = os.Stderr
+		lOut   = l.Writer()
+	)
+
+	os.Stdout = dashboardNull
+	os.Stderr = dashboardNull
+	l.SetOutput(ioutil.Discard)
+
+	Log.Lock()
+	logOut := Log.Out
+	Log.Out = ioutil.Discard
+	Log.Unlock()
+
+	activity.Lock()
+	activity.open = true
+	activity.Unlock()
+
+	return stdout, func() {
+		activity.Lock()
+		activity.open = false
+		activity.Unlock()
+
+		Log.Lock()
+		Log.Out = logOut
+		Log.Unlock()
+
+		l.SetOutput(lOut)
+		os.Stdout = stdout
+		os.Stderr = stderr
+	}, nil
+}
+
+func printDashboardUsageErr() {
+	l.Println(ErrInvalidUsage)
+	l.Println("usage: dashboard")
+}
+
+// handle dashboard shell command
+// shows the runs, processes and events until q is pressed
+func handleDashboardCommand(args []string) error {
+
+	if len(args) > 1 {
+		printDashboardUsageErr()
+		return ErrInvalidUsage
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !readline.IsTerminal(fd) || !readline.IsTerminal(int(os.Stdout.Fd())) {
+		l.Println(ErrNoTerminal)
+		return ErrNoTerminal
+	}
+
+	state, err := readline.MakeRaw(fd)
+	if err != nil {
+		l.Println(err)
+		return err
+	}
+	defer readline.Restore(fd, state)
+
+	term, restore, err := muteTerminal()
+	if err != nil {
+		l.Println(err)
+		return err
+	}
+	defer restore()
+
+	// alternate screen without cursor
+	term.WriteString("\x1b[?1049h\x1b[?25l")
+	defer term.WriteString("\x1b[?25h\x1b[?1049l")
+
+	var (
+		d            = &dashboard{}
+		keys, next   = readDashboardKeys(os.Stdin)
+		ticker       = time.NewTicker(dashboardRefresh)
+		lastW, lastH int
+	)
+	defer ticker.Stop()
+
+	draw := func() {
+		w, h, err := readline.GetSize(int(term.Fd()))
+		if err == nil {
+			d.width, d.height = w, h
+		}
+
+		frame := d.render()
+		if d.width != lastW || d.height != lastH {
+			frame = "\x1b[2J" + frame
+			lastW, lastH = d.width, d.height
+		}
+		term.WriteString(frame)
+	}
+	draw()
+
+	for {
+		select {
+		case <-ticker.C:
+			draw()
+
+		case b, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			var quit bool
+			for _, key := range dashboardKeys(b) {
+				if d.handleKey(key) {
+					quit = true
+					break
+				}
+			}
+			if quit {
+				next <- false
+				return nil
+			}
+
+			if d.attachPID != 0 {
+				attachScreen(fd, state, term, d.attachPID)
+				d.attachPID = 0
+				lastW = 0
+			}
+
+			next <- true
+			draw()
+		}
+	}
+}
+
+// attach to a detached process with screen, like procs attach
+// the terminal is restored while screen is running
+func attachScreen(fd int, state *readline.State, term *os.File, pid int) {
+
+	term.WriteString("\x1b[?25h\x1b[?1049l")
+	readline.Restore(fd, state)
+
+	cmd := exec.Command("screen", "-r", strconv.Itoa(pid))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = term
+	cmd.Stderr = term
+	cmd.Env = os.Environ()
+	cmd.Run()
+
+	readline.MakeRaw(fd)
+	term.WriteString("\x1b[?1049h\x1b[?25l")
+}
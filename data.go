@@ -162,6 +162,11 @@ func loadEvents() {
 		// addEvent will create a new eventID so we need to clean up the entry for the previous one
 		delete(projectData.fields.Events, e.ID)
 
+		if err := checkEventCommand(fields); err != nil {
+			Log.WithError(err).Error("not loading event for path " + e.Path)
+			continue
+		}
+
 		// copy values from struct
 		var (
 			path          = e.Path
@@ -177,9 +182,10 @@ func loadEvents() {
 
 				Log.Debug("event fired, name: ", event.Name, " path: ", path)
 
-				// validate commandChain
-				if cmdChain, ok := validCommandChain(fields); ok {
-					cmdChain.exec(fields)
+				if _, ok := builtins[fields[0]]; ok {
+					handleLine(command)
+				} else if cmdChain, ok := validCommandChain(fields); ok {
+					cmdChain.exec(newBackgroundContext(), fields)
 				} else {
 
 					Log.Debug("passing chain to shell")
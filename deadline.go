@@ -18,7 +18,10 @@
 
 package main
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 func printDeadlineUsageErr() {
 	l.Println(ErrInvalidUsage)
@@ -58,22 +61,34 @@ func addDeadline(args []string) {
 		return
 	}
 
+	err := setDeadline(args[0])
+	if err != nil {
+		Log.WithError(err).Error("failed to set deadline")
+		return
+	}
+
+	Log.Info("added deadline for ", args[0])
+}
+
+// validate and set the deadline, the date is parsed with the configured date format
+func setDeadline(date string) error {
+
 	conf.Lock()
 	format := conf.fields.DateFormat
 	conf.Unlock()
 
 	// check if date is valid
-	t, err := time.Parse(format, args[0])
+	t, err := time.Parse(format, date)
 	if err != nil {
-		Log.WithError(err).Error("failed to parse date")
-		return
+		return errors.New("failed to parse date: " + err.Error())
 	}
 
 	projectData.Lock()
-	projectData.fields.Deadline = t.Format(conf.fields.DateFormat)
+	projectData.fields.Deadline = t.Format(format)
 	projectData.Unlock()
 	projectData.update()
-	Log.Info("added deadline for ", args[0])
+
+	return nil
 }
 
 // remove the deadline from project data
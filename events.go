@@ -36,10 +36,36 @@ var (
 	// ErrInvalidEventType means the given event type string is invalid
 	ErrInvalidEventType = errors.New("invalid fsnotify event type. available types are: WRITE | REMOVE | RENAME | CHMOD")
 
+	// ErrUnknownEvent means there is no event with the ID
+	ErrUnknownEvent = errors.New("unknown event")
+
 	// ErrInvalidUsage means the command was used incorrectly
 	ErrInvalidUsage = errors.New("invalid usage")
+
+	// ErrEventBuiltin means a builtin that must not run in the background was added to an event
+	ErrEventBuiltin = errors.New("builtin can not be run by events")
+
+	// builtins that can be run by events
+	// events fire in the background, so builtins that control the shell or change its state are not allowed
+	eventBuiltins = map[string]bool{
+		reportCommand: true,
+	}
 )
 
+// check if the command of an event can be run in the background
+func checkEventCommand(fields []string) error {
+
+	if len(fields) == 0 {
+		return errors.New("no command supplied")
+	}
+
+	if _, ok := builtins[fields[0]]; ok && !eventBuiltins[fields[0]] {
+		return errors.New(ErrEventBuiltin.Error() + ": " + fields[0])
+	}
+
+	return nil
+}
+
 // temporarely disable change event
 func blockWriteEvent() {
 	disableWriteEventMutex.Lock()
@@ -97,7 +123,10 @@ func handleEventsCommand(args []string) {
 
 	switch args[1] {
 	case "remove":
-		removeEvent(args[2])
+		err := removeEvent(args[2])
+		if err != nil {
+			Log.Error(err)
+		}
 	case "add":
 		registerEvent(args)
 
@@ -114,20 +143,6 @@ func registerEvent(args []string) {
 		return
 	}
 
-	// check if event type is valid
-	op, err := getEventType(args[2])
-	if err != nil {
-		Log.Error(err)
-		return
-	}
-
-	// check if path exists
-	_, err = os.Stat(args[3])
-	if err != nil {
-		Log.Error(err)
-		return
-	}
-
 	var (
 		fields   []string
 		filetype string
@@ -140,40 +155,79 @@ func registerEvent(args []string) {
 		fields = args[4:]
 	}
 
-	if filetype != "" && len(fields) == 0 {
-		Log.Error("no command supplied")
-		return
+	err := createEvent(args[2], args[3], filetype, fields)
+	if err != nil {
+		Log.Error(err)
+	}
+}
+
+// validate and watch a custom event
+func createEvent(opType, path, filetype string, fields []string) error {
+
+	// check if event type is valid
+	op, err := getEventType(opType)
+	if err != nil {
+		return err
+	}
+
+	// check if path exists
+	_, err = os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if filetype != "" && !strings.HasPrefix(filetype, ".") {
+		return errors.New("invalid file type, must start with a dot: " + filetype)
+	}
+
+	err = checkEventCommand(fields)
+	if err != nil {
+		return err
 	}
 
 	if _, ok := validCommandChain(fields); ok {
 		Log.Info("adding command chain")
-	} else {
+	} else if _, ok := builtins[fields[0]]; !ok {
 		Log.Info("adding shell command")
 	}
 
 	chain := strings.Join(fields, " ")
-	go func() {
-		e := newEvent(args[3], op, "custom event", filetype, "", chain, func(event fsnotify.Event) {
-
-			Log.Debug("event fired, name: ", event.Name, " path: ", args[3])
-
-			if cmdChain, ok := validCommandChain(fields); ok {
-				cmdChain.exec(fields)
+	e := newEvent(path, op, "custom event", filetype, "", chain, func(event fsnotify.Event) {
+
+		Log.Debug("event fired, name: ", event.Name, " path: ", path)
+
+		if _, ok := builtins[fields[0]]; ok {
+			// only the builtins in eventBuiltins are added to events
+			handleLine(chain)
+		} else if cmdChain, ok := validCommandChain(fields); ok {
+			ctx, span := newBackgroundContext().startSpan("event "+path, spanEvent, false)
+			span.set("file", event.Name)
+			span.set("op", event.Op.String())
+			span.finish(cmdChain.exec(ctx, fields))
+		} else {
+
+			// its a shell command
+			if len(fields) > 1 {
+				passCommandToShell(fields[0], fields[1:])
 			} else {
-
-				// its a shell command
-				if len(fields) > 1 {
-					passCommandToShell(fields[0], fields[1:])
-				} else {
-					passCommandToShell(fields[0], []string{})
-				}
+				passCommandToShell(fields[0], []string{})
 			}
-		})
+		}
+	})
+
+	// register the event right away, the watcher is started in the background
+	projectData.Lock()
+	projectData.fields.Events[e.ID] = e
+	projectData.Unlock()
+
+	go func() {
 		err := addEvent(e)
 		if err != nil {
-			Log.Error("failed to watch path: ", args[3])
+			Log.Error("failed to watch path: ", path)
 		}
 	}()
+
+	return nil
 }
 
 // parse command type string and fsnotify type
@@ -205,7 +259,7 @@ func printEvents() {
 }
 
 // remove the event for the given path
-func removeEvent(id string) {
+func removeEvent(id string) error {
 
 	projectData.Lock()
 
@@ -225,11 +279,11 @@ func removeEvent(id string) {
 
 		// update project data
 		projectData.update()
-		return
+		return nil
 	}
 	projectData.Unlock()
 
-	Log.Error("event with ID ", id, " does not exist")
+	return errors.New(ErrUnknownEvent.Error() + ": " + id)
 }
 
 // create a new event
@@ -311,6 +365,8 @@ func addEvent(e *Event) error {
 					disableWriteEventMutex.Unlock()
 
 					// fire handler
+					activity.addEvent(event.Op.String(), event.Name, e.Command)
+					metrics.eventFired(e.Path, event.Op.String())
 					e.handler(event)
 				}
 			case err := <-watcher.Errors:
@@ -352,6 +408,10 @@ func reloadEvent(e *Event) {
 		if conf.fields.AutoFormat {
 			go f.watchScriptDir(e.ID)
 		}
+	case "globals formatter watcher":
+		if conf.fields.AutoFormat {
+			go f.watchGlobalsDir(e.ID)
+		}
 	case "commandsFile watcher":
 		go watchCommandsFile(commandsFilePath, e.ID)
 	default:
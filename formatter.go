@@ -19,130 +19,667 @@
 package main
 
 import (
+	"bytes"
 	"errors"
+	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v2"
 )
 
-// generic formatter type
+var (
+	// ErrNoFormatter means there is no formatter configured for the language
+	ErrNoFormatter = errors.New("no formatter configured")
+
+	// ErrFormatterNotFound means the formatter configured for the language is not installed
+	ErrFormatterNotFound = errors.New("formatter not found")
+
+	// ErrUnformattedFiles means the format check found files that are not formatted
+	ErrUnformattedFiles = errors.New("files are not formatted")
+
+	// matches the beginning of a literal exec block in the CommandsFile
+	execBlock = regexp.MustCompile(`^(\s*)exec:\s*\|[-+]?\s*$`)
+
+	// number of unchanged lines printed around each change in a diff
+	diffContext = 3
+)
+
+// formatter runs the formatters configured for each language
+// formatters read the code from stdin and write the formatted code to stdout
 type formatter struct {
-	language *Language
 
-	// path to binary
-	binPath string
+	// formatters that could not be found, they will only be reported once
+	missing map[string]bool
+
+	sync.Mutex
 }
 
-// initialize the formatter to handle shell scripts
-func newFormatter(path string, l *Language) *formatter {
+// result of formatting a single file
+type formatResult struct {
+	path      string
+	original  string
+	formatted string
+
+	// set if the file or parts of it were not formatted, because the formatter is not installed
+	unchecked bool
+}
+
+func (r *formatResult) changed() bool {
+	return r.original != r.formatted
+}
+
+// initialize the formatter
+func newFormatter() *formatter {
 	return &formatter{
-		language: l,
-		binPath:  path,
+		missing: make(map[string]bool, 0),
 	}
 }
 
-// format a single shell file on disk
-func (f *formatter) formatPath(path string) error {
+// format code with the formatter configured for the language
+func (f *formatter) formatCode(lang *Language, code string) (string, error) {
 
-	var cLog = Log.WithField("prefix", "formatPath")
-	cLog.Debug("formatting: ", path)
-	return nil
+	fields := strings.Fields(lang.Formatter)
+	if len(fields) == 0 {
+		return code, ErrNoFormatter
+	}
+
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		f.Lock()
+		if !f.missing[fields[0]] {
+			Log.Warn("formatter for " + lang.Name + " not found: " + fields[0])
+			f.missing[fields[0]] = true
+		}
+		f.Unlock()
+		return code, ErrFormatterNotFound
+	}
+
+	var (
+		stdout bytes.Buffer
+		stderr bytes.Buffer
+		cmd    = exec.Command(fields[0], fields[1:]...)
+	)
+
+	cmd.Stdin = strings.NewReader(code)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return code, errors.New(lang.Formatter + ": " + err.Error() + ": " + strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
 }
 
-// walk the zeus directory and run formatPath on all files
-func (f *formatter) formatzeusDir() error {
+// get the language for a file by its extension
+// some languages share the same extension (sh and bash)
+// in this case languages with a formatter are preferred, then the first by name
+func languageForFile(path string) (*Language, error) {
+
+	var (
+		ext   = filepath.Ext(path)
+		match *Language
+	)
+
+	ls.Lock()
+	defer ls.Unlock()
+
+	for _, lang := range ls.items {
+		if lang.FileExtension != ext {
+			continue
+		}
+		if match == nil {
+			match = lang
+			continue
+		}
+		hasFormatter, matchHasFormatter := lang.Formatter != "", match.Formatter != ""
+		if (hasFormatter && !matchHasFormatter) || (hasFormatter == matchHasFormatter && lang.Name < match.Name) {
+			match = lang
+		}
+	}
+
+	if match == nil {
+		return nil, ErrUnsupportedLanguage
+	}
+
+	return match, nil
+}
+
+// format a single script file
+func (f *formatter) formatFile(path string) (*formatResult, error) {
+
+	lang, err := languageForFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := f.formatCode(lang, string(c))
+	if err != nil && err != ErrFormatterNotFound {
+		return nil, err
+	}
+
+	return &formatResult{
+		path:      path,
+		original:  string(c),
+		formatted: formatted,
+		unchecked: err == ErrFormatterNotFound,
+	}, nil
+}
+
+// format all exec blocks in the CommandsFile
+// each block is formatted with the formatter for the language of its command
+// and reinserted with its original indentation
+func (f *formatter) formatCommandsFile(path string) (*formatResult, error) {
+
+	c, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	commandsFile := newCommandsFile()
+	err = yaml.Unmarshal(c, commandsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		lines         = strings.Split(string(c), "\n")
+		out           []string
+		unchecked     bool
+		commandIndent = -1
+		inCommands    bool
+		currentName   string
+	)
+
+	for i := 0; i < len(lines); i++ {
+
+		line := lines[i]
+		out = append(out, line)
+
+		// keep track of the current command
+		if strings.HasPrefix(line, "commands:") {
+			inCommands = true
+			continue
+		}
+		if isTopLevelKey(line) {
+			inCommands = false
+			continue
+		}
+		if inCommands && strings.HasSuffix(strings.TrimSpace(line), ":") && !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			indent := countLeadingSpace(line)
+			if commandIndent == -1 {
+				commandIndent = indent
+			}
+			if indent == commandIndent {
+				currentName = strings.TrimSuffix(strings.TrimSpace(line), ":")
+			}
+		}
+
+		m := execBlock.FindStringSubmatch(line)
+		if m == nil || !inCommands {
+			continue
+		}
+
+		// collect the block
+		var (
+			execIndent  = len(m[1])
+			blockIndent = -1
+			end         = i + 1
+		)
+		for ; end < len(lines); end++ {
+			if strings.TrimSpace(lines[end]) == "" {
+				continue
+			}
+			indent := countLeadingSpace(lines[end])
+			if indent <= execIndent {
+				break
+			}
+			if blockIndent == -1 {
+				blockIndent = indent
+			}
+		}
+
+		// trailing empty lines are not part of the block
+		for end > i+1 && strings.TrimSpace(lines[end-1]) == "" {
+			end--
+		}
+
+		if blockIndent == -1 {
+			continue
+		}
+
+		var block []string
+		for _, b := range lines[i+1 : end] {
+			if len(b) >= blockIndent {
+				block = append(block, b[blockIndent:])
+			} else {
+				block = append(block, "")
+			}
+		}
+
+		formatted, err := f.formatBlock(commandsFile, currentName, strings.Join(block, "\n")+"\n")
+		if err != nil {
+			if err != ErrNoFormatter && err != ErrFormatterNotFound {
+				return nil, errors.New(path + ": line " + strconv.Itoa(i+1) + ": " + currentName + ": " + err.Error())
+			}
+			if err == ErrFormatterNotFound {
+				unchecked = true
+			}
+
+			// keep the block as it is
+			out = append(out, lines[i+1:end]...)
+			i = end - 1
+			continue
+		}
+
+		prefix := strings.Repeat(" ", blockIndent)
+		for _, b := range strings.Split(strings.TrimRight(formatted, "\n"), "\n") {
+			if strings.TrimSpace(b) == "" {
+				out = append(out, "")
+			} else {
+				out = append(out, prefix+b)
+			}
+		}
+
+		i = end - 1
+	}
+
+	return &formatResult{
+		path:      path,
+		original:  string(c),
+		formatted: strings.Join(out, "\n"),
+		unchecked: unchecked,
+	}, nil
+}
+
+// format the exec block of a command from the CommandsFile
+func (f *formatter) formatBlock(commandsFile *CommandsFile, name, code string) (string, error) {
+
+	langName := commandsFile.Language
+	if d, ok := commandsFile.Commands[name]; ok && d.Language != "" {
+		langName = d.Language
+	}
+
+	lang, err := ls.getLang(langName)
+	if err != nil {
+		return code, err
+	}
+
+	return f.formatCode(lang, code)
+}
+
+// check if the line contains a YAML key without indentation
+func isTopLevelKey(line string) bool {
+	return len(line) > 0 && line[0] != ' ' && line[0] != '\t' && line[0] != '#' && strings.Contains(line, ":")
+}
+
+// format all scripts, globals and the CommandsFile
+// the results are not written to disk
+func (f *formatter) formatzeusDir() (results []*formatResult, err error) {
 
 	var cLog = Log.WithField("prefix", "formatzeusDir")
 
 	info, err := os.Stat(scriptDir)
 	if err != nil {
 		cLog.WithError(err).Error("path does not exist")
-		return err
+		return nil, err
 	}
 	if !info.IsDir() {
-		return errors.New("scriptDir path is not a directory")
+		return nil, errors.New("scriptDir path is not a directory")
 	}
 
-	return filepath.Walk(scriptDir, func(path string, info os.FileInfo, err error) error {
+	for _, dir := range []string{scriptDir, zeusDir + "/globals"} {
+
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
 
 		// no recursion for now
-		if info.IsDir() {
-			return nil
+		for _, file := range files {
+
+			if file.IsDir() {
+				continue
+			}
+
+			r, err := f.formatFile(filepath.Join(dir, file.Name()))
+			if err != nil {
+				if err == ErrNoFormatter || err == ErrUnsupportedLanguage {
+					continue
+				}
+				cLog.WithError(err).Error("failed to format path: " + file.Name())
+				return nil, err
+			}
+			results = append(results, r)
 		}
+	}
 
+	if _, err := os.Stat(commandsFilePath); err == nil {
+		r, err := f.formatCommandsFile(commandsFilePath)
 		if err != nil {
-			cLog.WithError(err).Error("error walking zeus directory")
-			return err
+			return nil, err
 		}
+		results = append(results, r)
+	}
 
-		err = f.formatPath(path)
-		if err != nil && !os.IsNotExist(err) {
-			cLog.WithError(err).Error("failed to format path: " + path)
-			return err
-		}
+	return results, nil
+}
+
+// write the formatted contents to disk, if they changed
+func (r *formatResult) write() error {
+
+	if !r.changed() {
 		return nil
-	})
+	}
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return err
+	}
+
+	// ignore the write event for the formatted file
+	blockWriteEvent()
+
+	return ioutil.WriteFile(r.path, []byte(r.formatted), info.Mode())
+}
+
+// format a single file on disk
+func (f *formatter) formatPath(path string) error {
+
+	var (
+		r   *formatResult
+		err error
+	)
+
+	if path == commandsFilePath {
+		r, err = f.formatCommandsFile(path)
+	} else {
+		r, err = f.formatFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	return r.write()
 }
 
 /*
  *	Utils
  */
 
-// truncate file and seek to the beginning
-func empty(f *os.File) error {
-	if err := f.Truncate(0); err != nil {
-		return err
+func printFormatCommandUsageErr() {
+	l.Println(ErrInvalidUsage)
+	l.Println("usage: format [--check]")
+}
+
+// handle format shell command
+// in check mode, the diff for all unformatted files is printed and ErrUnformattedFiles is returned
+func (f *formatter) handleFormatCommand(args []string) error {
+
+	var check bool
+
+	if len(args) > 1 {
+		if len(args) > 2 || args[1] != "--check" {
+			printFormatCommandUsageErr()
+			return ErrInvalidUsage
+		}
+		check = true
+	}
+
+	if check {
+		return f.checkCommand()
 	}
-	_, err := f.Seek(0, 0)
-	return err
+
+	f.formatCommand()
+	return nil
 }
 
 // run the formatter for all files in the zeus dir
 // calculates runtime and displays error
 func (f *formatter) formatCommand() {
 
-	var (
-		start = time.Now()
-		err   = f.formatzeusDir()
-	)
+	start := time.Now()
+
+	results, err := f.formatzeusDir()
 	if err != nil {
 		l.Println("error formatting: ", err)
+		return
+	}
+
+	var count int
+	for _, r := range results {
+		if r.changed() {
+			err = r.write()
+			if err != nil {
+				l.Println("error writing "+r.path+": ", err)
+				continue
+			}
+			l.Println(printPrompt() + "formatted " + cp.Prompt + r.path + cp.Reset)
+			count++
+		}
+	}
+
+	l.Println(printPrompt()+"formatted zeus directory ("+strconv.Itoa(count)+" files changed) in ", time.Now().Sub(start))
+}
+
+// print a diff for all files that are not formatted
+// files that can not be checked because their formatter is not installed fail the check as well
+func (f *formatter) checkCommand() error {
+
+	results, err := f.formatzeusDir()
+	if err != nil {
+		l.Println("error formatting: ", err)
+		return err
+	}
+
+	var unformatted, unchecked int
+	for _, r := range results {
+		if r.changed() {
+			unformatted++
+			l.Print(diff(r.path, r.original, r.formatted))
+		}
+		if r.unchecked {
+			unchecked++
+			l.Println(printPrompt() + "could not check " + cp.Prompt + r.path + cp.Text + ": formatter not found" + cp.Reset)
+		}
+	}
+
+	if unformatted > 0 {
+		l.Println(printPrompt() + strconv.Itoa(unformatted) + " files are not formatted" + cp.Reset)
+		return ErrUnformattedFiles
+	}
+
+	if unchecked > 0 {
+		l.Println(printPrompt() + strconv.Itoa(unchecked) + " files could not be checked" + cp.Reset)
+		return ErrFormatterNotFound
 	}
-	l.Println(printPrompt()+"formatted zeus directory in ", time.Now().Sub(start))
+
+	l.Println(printPrompt() + "all files are formatted" + cp.Reset)
+	return nil
 }
 
 // watch the zeus dir changes and run format on write event
 func (f *formatter) watchScriptDir(eventID string) {
+	f.watchDir(scriptDir, "formatter watcher", eventID)
+}
+
+// watch the globals dir changes and run format on write event
+func (f *formatter) watchGlobalsDir(eventID string) {
+
+	path := zeusDir + "/globals"
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+
+	f.watchDir(path, "globals formatter watcher", eventID)
+}
+
+// watch a directory and format the scripts on write event
+func (f *formatter) watchDir(path, name, eventID string) {
 
 	// dont add a new watcher when the event exists
 	projectData.Lock()
 	for _, e := range projectData.fields.Events {
-		if e.Name == "formatter watcher" {
+		if e.Name == name {
 			projectData.Unlock()
 			return
 		}
 	}
 	projectData.Unlock()
 
-	err := addEvent(newEvent(scriptDir, fsnotify.Write, "formatter watcher", "", eventID, "internal", func(event fsnotify.Event) {
+	err := addEvent(newEvent(path, fsnotify.Write, name, "", eventID, "internal", func(event fsnotify.Event) {
 
 		// check if its a valid script
-		if strings.HasSuffix(event.Name, ".sh") {
-
-			// ignore further WRITE events while formatting a script
-			blockWriteEvent()
+		if _, err := languageForFile(event.Name); err != nil {
+			return
+		}
 
-			// format script
-			err := f.formatPath(event.Name)
-			if err != nil {
-				Log.WithError(err).Error("failed to format file")
-			}
+		// format script
+		err := f.formatPath(event.Name)
+		if err != nil && err != ErrNoFormatter {
+			Log.WithError(err).Error("failed to format file")
 		}
 	}))
 	if err != nil {
-		Log.Error("failed to watch path: ", scriptDir)
+		Log.Error("failed to watch path: ", path)
+	}
+}
+
+/*
+ *	Diff
+ */
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	text string
+}
+
+// compute the line operations that turn a into b
+// using the longest common subsequence
+func diffLines(a, b []string) (ops []diffOp) {
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for k := len(b) - 1; k >= 0; k-- {
+			if a[i] == b[k] {
+				lcs[i][k] = lcs[i+1][k+1] + 1
+			} else if lcs[i+1][k] >= lcs[i][k+1] {
+				lcs[i][k] = lcs[i+1][k]
+			} else {
+				lcs[i][k] = lcs[i][k+1]
+			}
+		}
+	}
+
+	i, k := 0, 0
+	for i < len(a) && k < len(b) {
+		switch {
+		case a[i] == b[k]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			k++
+		case lcs[i+1][k] >= lcs[i][k+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[k]})
+			k++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
 	}
+	for ; k < len(b); k++ {
+		ops = append(ops, diffOp{'+', b[k]})
+	}
+
+	return
+}
+
+// create a unified diff between the original and the formatted file contents
+func diff(path, original, formatted string) string {
+
+	var (
+		ops = diffLines(strings.Split(original, "\n"), strings.Split(formatted, "\n"))
+		out bytes.Buffer
+	)
+
+	out.WriteString(cp.Text + "--- " + path + "\n+++ " + path + " (formatted)\n" + cp.Reset)
+
+	for i := 0; i < len(ops); {
+
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// find the end of the hunk, changes closer than twice the context are merged
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+		end += diffContext
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		// line number in the original file
+		line := 1
+		for _, op := range ops[:start] {
+			if op.kind != '+' {
+				line++
+			}
+		}
+
+		out.WriteString(cp.Prompt + "@@ line " + strconv.Itoa(line) + " @@\n" + cp.Reset)
+		for _, op := range ops[start:end] {
+			switch op.kind {
+			case '-':
+				out.WriteString(cp.Prompt + "-" + op.text + cp.Reset + "\n")
+			case '+':
+				out.WriteString(cp.CmdArgs + "+" + op.text + cp.Reset + "\n")
+			default:
+				out.WriteString(" " + op.text + "\n")
+			}
+		}
+
+		i = end
+	}
+
+	return out.String()
 }
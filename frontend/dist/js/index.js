@@ -1,2 +1,19 @@
-$(document).ready(function(){$('#btn-wiki').click(function(){window.open("/wiki","_blank");});$('#btn-scripts').click(function(){window.open("/scripts","_blank");});$('#btn-config').click(function(){console.log("toggle config panel");});$('#btn-quit').click(function(){window.location="/quit";setTimeout(function(){window.close();},1000);});var socket=glue();socket.onMessage(function(data){console.log("onMessage: "+data);});socket.on("connected",function(){console.log("connected");});socket.on("connecting",function(){console.log("connecting");});socket.on("disconnected",function(){console.log("disconnected");});socket.on("reconnecting",function(){console.log("reconnecting");});socket.on("error",function(e,msg){console.log("error: "+msg);});socket.on("connect_timeout",function(){console.log("connect_timeout");});socket.on("timeout",function(){console.log("timeout");});socket.on("discard_send_buffer",function(){console.log("some data could not be send and was discarded.");});});function spinnerON(){$('#main-spinner').toggle(true);}
+$(document).ready(function(){$.ajaxSetup({headers:{"X-CSRF-Token":getCookie("zeus-csrf")}});$('#btn-wiki').click(function(){window.open("/wiki","_blank");});$('#btn-scripts').click(function(){window.open("/scripts","_blank");});$('#btn-config').click(function(){$('#settings').toggle();if($('#settings').is(':visible')){loadSettings();}});initSettings();$('#btn-graph').click(function(){$('#graph').toggle();if($('#graph').is(':visible')){loadGraph();}});$('#btn-runs').click(function(){$('#runs').toggle();});$('#btn-quit').click(function(){$.post("/quit");setTimeout(function(){window.close();},1000);});var socket=glue();socket.onMessage(function(data){console.log("onMessage: "+data);var msg;try{msg=JSON.parse(data);}catch(e){return;}if(msg.type==="state"){setNodeState(msg.command,msg.state);}if(msg.type==="runs"){$.each(msg.runs,function(i,run){updateRun(run);});}if(msg.type==="run"){updateRun(msg);}if(msg.type==="settings"&&$('#settings').is(':visible')){loadSettings();}});$('#run-tabs').on("click",".run-tab",function(){selectRun(socket,$(this).attr("data-id"));});$('#run-form').submit(function(e){e.preventDefault();var chain=$.trim($('#run-chain').val());if(chain===""){return;}$.ajax({url:"/api/run",method:"POST",contentType:"application/json",data:JSON.stringify({chain:chain})}).done(function(status){$('#run-chain').val("");updateRun({id:status.id,chain:status.chain,state:status.state});selectRun(socket,status.id);}).fail(function(xhr){showRunError(xhr);});});$('#run-cancel').click(function(){if(selectedRun===null){return;}$.ajax({url:"/api/runs/"+selectedRun,method:"DELETE"}).fail(function(xhr){showRunError(xhr);});});socket.on("connected",function(){console.log("connected");});socket.on("connecting",function(){console.log("connecting");});socket.on("disconnected",function(){console.log("disconnected");});socket.on("reconnecting",function(){console.log("reconnecting");});socket.on("error",function(e,msg){console.log("error: "+msg);});socket.on("connect_timeout",function(){console.log("connect_timeout");});socket.on("timeout",function(){console.log("timeout");});socket.on("discard_send_buffer",function(){console.log("some data could not be send and was discarded.");});});
+var selectedRun=null;function updateRun(run){var tab=$('#run-tabs .run-tab').filter(function(){return $(this).attr("data-id")===run.id;});if(tab.length===0){tab=$('<button class="run-tab"/>').attr("data-id",run.id).text(run.chain);$('#run-tabs').prepend(tab);}$.each(graphStates.concat(["cancelled"]),function(i,s){tab.removeClass(s);});tab.addClass(run.state);if(run.id===selectedRun){$('#run-state').text(run.state);}}
+function selectRun(socket,id){if(selectedRun===id){return;}if(selectedRun!==null){socket.send(JSON.stringify({type:"unsubscribe",id:selectedRun}));socket.channel("run:"+selectedRun).onMessage(function(){});}selectedRun=id;$('#run-tabs .run-tab').removeClass("selected").filter(function(){return $(this).attr("data-id")===id;}).addClass("selected");$('#console').html("");$('#run-state').text("");socket.channel("run:"+id).onMessage(function(data){var msg;try{msg=JSON.parse(data);}catch(e){return;}switch(msg.type){case"replay":$('#console').html(msg.html);$('#run-state').text(msg.state);scrollConsole();break;case"output":$('#console').append(msg.html);scrollConsole();break;case"state":$('#run-state').text(msg.command+": "+msg.state);break;case"unknown":$('#console').text("the output of this run is no longer available");break;}});socket.send(JSON.stringify({type:"subscribe",id:id}));}
+function scrollConsole(){var c=$('#console');c.scrollTop(c.prop("scrollHeight"));}
+function showRunError(xhr){var msg=xhr.statusText;if(xhr.responseJSON&&xhr.responseJSON.error){msg=xhr.responseJSON.error;}$('#run-state').text("error: "+msg);}
+function loadSettings(){$.getJSON("/api/settings",function(settings){renderSettings(settings);}).fail(function(xhr){showSettingsError(xhr);});}
+function saveSettings(method,url,data){$.ajax({url:url,method:method,contentType:"application/json",data:data===undefined?undefined:JSON.stringify(data)}).done(function(settings){$('#settings-error').text("");renderSettings(settings);}).fail(function(xhr){showSettingsError(xhr);});}
+function showSettingsError(xhr){var msg=xhr.statusText;if(xhr.responseJSON&&xhr.responseJSON.error){msg=xhr.responseJSON.error;}$('#settings-error').text(msg);}
+function removeButton(url){return $('<button class="zeus-button">REMOVE</button>').click(function(){saveSettings("DELETE",url);});}
+function settingsRow(cells,elements){var row=$('<tr/>');$.each(cells,function(i,c){row.append($('<td/>').text(c));});$.each(elements,function(i,e){row.append($('<td/>').append(e));});return row;}
+function renderSettings(s){var config=$('#settings-config').empty();$.each(s.config,function(i,f){var input;if(f.type==="bool"){input=$('<input type="checkbox"/>').prop("checked",f.value);}else{input=$('<input/>').attr("type",f.type==="int"?"number":"text").val(f.value);}input.change(function(){var value=f.type==="bool"?$(this).is(':checked'):$(this).val();saveSettings("PUT","/api/config/"+encodeURIComponent(f.name),{value:value});});config.append(settingsRow([f.name],[input]));});$('#settings-build').text(s.buildNumber);$('#settings-author').val(s.author);$('#settings-deadline').val(s.deadline).attr("placeholder",s.dateFormat);$('#settings-milestone-date').attr("placeholder",s.dateFormat);var aliases=$('#settings-aliases').empty();$.each(Object.keys(s.aliases).sort(),function(i,name){aliases.append(settingsRow([name,s.aliases[name]],[removeButton("/api/aliases/"+encodeURIComponent(name))]));});var milestones=$('#settings-milestones').empty();$.each(s.milestones,function(i,m){var percent=$('<input type="number" min="0" max="100"/>').val(m.percentComplete).change(function(){saveSettings("PUT","/api/milestones/"+encodeURIComponent(m.name),{percent:$(this).val()});});milestones.append(settingsRow([m.name,m.date,m.description],[percent,removeButton("/api/milestones/"+encodeURIComponent(m.name))]));});var keys=$('#settings-keys').empty(),keySelect=$('#settings-key').empty();$.each(s.keys,function(i,key){keySelect.append($('<option/>').val(key).text(key));if(s.keyBindings[key]!==undefined){keys.append(settingsRow([key,s.keyBindings[key]],[removeButton("/api/keys/"+encodeURIComponent(key))]));}});var events=$('#settings-events').empty();$.each(s.events,function(i,e){events.append(settingsRow([e.op,e.path,e.fileExtension,e.command],[removeButton("/api/events/"+encodeURIComponent(e.id))]));});}
+function initSettings(){$('#settings-author-form').submit(function(e){e.preventDefault();saveSettings("PUT","/api/author",{name:$('#settings-author').val()});});$('#settings-author-remove').click(function(){saveSettings("DELETE","/api/author");});$('#settings-deadline-form').submit(function(e){e.preventDefault();saveSettings("PUT","/api/deadline",{date:$('#settings-deadline').val()});});$('#settings-deadline-remove').click(function(){saveSettings("DELETE","/api/deadline");});$('#settings-alias-form').submit(function(e){e.preventDefault();saveSettings("POST","/api/aliases",{name:$('#settings-alias-name').val(),command:$('#settings-alias-command').val()});});$('#settings-milestone-form').submit(function(e){e.preventDefault();saveSettings("POST","/api/milestones",{name:$('#settings-milestone-name').val(),date:$('#settings-milestone-date').val(),description:$('#settings-milestone-description').val()});});$('#settings-key-form').submit(function(e){e.preventDefault();saveSettings("PUT","/api/keys/"+encodeURIComponent($('#settings-key').val()),{command:$('#settings-key-command').val()});});$('#settings-event-form').submit(function(e){e.preventDefault();saveSettings("POST","/api/events",{op:$('#settings-event-op').val(),path:$('#settings-event-path').val(),fileExtension:$('#settings-event-filetype').val(),command:$('#settings-event-command').val()});});}
+var graphStates=["running","finished","failed","skipped"];function loadGraph(){spinnerON();$.getJSON("/api/graph",function(graph){renderGraph(graph);}).always(function(){spinnerOFF();});}
+function renderGraph(graph){var nodeWidth=150,nodeHeight=36,columnWidth=200,rowHeight=56,layers={},rows={},positions={},i,j;for(i=0;i<graph.nodes.length;i++){layers[graph.nodes[i].name]=0;}for(i=0;i<graph.nodes.length;i++){for(j=0;j<(graph.edges||[]).length;j++){var e=graph.edges[j];if(layers[e.to]<layers[e.from]+1){layers[e.to]=layers[e.from]+1;}}}var width=0,height=0;for(i=0;i<graph.nodes.length;i++){var name=graph.nodes[i].name,layer=layers[name],row=rows[layer]||0;rows[layer]=row+1;positions[name]={x:20+layer*columnWidth,y:20+row*rowHeight};width=Math.max(width,positions[name].x+nodeWidth+20);height=Math.max(height,positions[name].y+nodeHeight+20);}var svg='<svg xmlns="http://www.w3.org/2000/svg" width="'+width+'" height="'+height+'">'+'<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto">'+'<path d="M 0 0 L 10 5 L 0 10 z"/></marker></defs>';for(i=0;i<(graph.edges||[]).length;i++){var from=positions[graph.edges[i].from],to=positions[graph.edges[i].to];svg+='<line class="graph-edge" marker-end="url(#arrow)" x1="'+(from.x+nodeWidth)+'" y1="'+(from.y+nodeHeight/2)+'" x2="'+to.x+'" y2="'+(to.y+nodeHeight/2)+'"/>';}for(i=0;i<graph.nodes.length;i++){var n=graph.nodes[i],p=positions[n.name],classes="graph-node";if(n.async){classes+=" async";}if(n.outputs){classes+=" outputs";}if(n.state){classes+=" "+n.state;}svg+='<g class="'+classes+'" data-command="'+escapeHTML(n.name)+'">'+'<title>'+escapeHTML(n.description||n.name)+'</title>'+'<rect rx="6" ry="6" x="'+p.x+'" y="'+p.y+'" width="'+nodeWidth+'" height="'+nodeHeight+'"/>'+'<text x="'+(p.x+nodeWidth/2)+'" y="'+(p.y+nodeHeight/2+5)+'">'+escapeHTML(n.name)+'</text>'+'</g>';}$('#graph').html(svg+'</svg>');}
+function setNodeState(command,state){$('#graph .graph-node').each(function(){if($(this).attr("data-command")===command){var node=$(this);$.each(graphStates,function(i,s){node.removeClass(s);});node.addClass(state);}});}
+function getCookie(name){var cookies=document.cookie.split(";");for(var i=0;i<cookies.length;i++){var c=$.trim(cookies[i]);if(c.indexOf(name+"=")===0){return c.substring(name.length+1);}}return"";}
+function escapeHTML(text){return $('<div/>').text(text).html().split('"').join("&quot;");}
+function spinnerON(){$('#main-spinner').toggle(true);}
 function spinnerOFF(){$('#main-spinner').toggle(false);}
\ No newline at end of file
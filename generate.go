@@ -19,17 +19,68 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// ErrDependencyCycle means the dependencies of a command form a cycle
+	ErrDependencyCycle = errors.New("dependency cycle")
+
+	// ErrConflictingArgs means a dependency is invoked more than once with different arguments
+	// the generated output contains each command only once
+	ErrConflictingArgs = errors.New("command invoked with different arguments")
+
+	// ErrRepeatedCommand means a command of the chain already ran earlier in the chain, or as a dependency
+	ErrRepeatedCommand = errors.New("command runs more than once in the chain")
+
+	// characters that are not allowed in generated target, stage and step names
+	invalidTargetChars = regexp.MustCompile("[^a-z0-9_.-]+")
 )
 
+// targets for the generate builtin, besides standalone scripts
+const (
+	generateMakefile   = "makefile"
+	generateWorkflow   = "workflow"
+	generateDockerfile = "dockerfile"
+)
+
+// base image for the generated Dockerfile, can be overwritten with --build-arg
+const defaultBaseImage = "debian:stable-slim"
+
+// a command in the generated output
+type generateNode struct {
+	cmd  *command
+	lang *Language
+
+	// arguments for the invocation
+	args []string
+
+	// names of the nodes that have to run before this one
+	deps []string
+}
+
+// generatePlan contains all commands of a command chain and their dependencies
+// in the order they have to be executed
+type generatePlan struct {
+	nodes []*generateNode
+
+	// the chain as supplied by the user
+	chain string
+}
+
 // Generate a standalone version of a single command or commandChain.
 // If all commands are of the same language, generate a single script,
 // if there are multiple scripting languages involved, generate a directory with all required scripts.
+// The chain can also be exported as Makefile, CI workflow, Dockerfile or Go executable.
 func handleGenerateCommand(args []string) {
 
 	if len(args) < 3 {
@@ -37,233 +88,510 @@ func handleGenerateCommand(args []string) {
 		return
 	}
 
-	var (
-		chain      commandChain
-		ok         bool
-		mixed      bool
-		language   string
-		arguments  []string
-		outputName = zeusDir + "/generated/" + args[1]
-	)
+	switch args[1] {
+	case generateMakefile, generateWorkflow, generateDockerfile:
+		if len(args) < 4 {
+			printGenerateCommandUsageErr()
+			return
+		}
 
-	// check if its a valid command chain
-	if chain, ok = validCommandChain(args[2:]); !ok {
-		l.Println("invalid command chain")
+		err := generateTarget(args[1], args[2], args[3:])
+		if err != nil {
+			l.Println(err)
+		}
+		return
+	case generateBinary:
+		if len(args) < 4 {
+			printGenerateCommandUsageErr()
+			return
+		}
+
+		err := generateBinaryTarget(args[2], args[3:])
+		if err != nil {
+			l.Println(err)
+		}
+		return
+	}
+
+	plan, err := newGeneratePlan(args[2:])
+	if err != nil {
+		l.Println(err)
 		return
 	}
 
 	// make sure generated dir exists
-	os.Mkdir(zeusDir+"/generated", 0744)
+	os.MkdirAll(zeusDir+"/generated", 0744)
 
-	// check if it contains multiple languages
-	for _, cmd := range chain {
-		if language == "" {
-			language = cmd.language
-		}
-		if language != cmd.language {
-			mixed = true
-			break
-		}
+	outputName := zeusDir + "/generated/" + args[1]
+
+	if plan.mixed() {
+		err = plan.generateMixed(outputName)
+	} else {
+		err = plan.generateSingle(outputName)
+	}
+	if err != nil {
+		l.Println(err)
 	}
+}
 
-	if mixed {
+// create the plan for a command chain
+// dependencies are resolved recursively and added before the commands that need them
+// each command appears only once, invoking it again with different arguments is an error
+func newGeneratePlan(chainArgs []string) (*generatePlan, error) {
 
-		// create a directory for multiple scripts
-		err := os.Mkdir(outputName, 0744)
-		if err != nil {
-			Log.WithError(err).Error("failed to create directory for mixed commandChain")
-			return
+	var (
+		line  = strings.Join(chainArgs, " ")
+		plan  = &generatePlan{chain: line}
+		nodes = make(map[string]*generateNode, 0)
+		state = make(map[string]int, 0)
+		add   func(invocation string, trace []string) (*generateNode, error)
+	)
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	add = func(invocation string, trace []string) (*generateNode, error) {
+
+		fields := strings.Fields(invocation)
+		if len(fields) == 0 {
+			return nil, ErrEmptyDependency
+		}
+
+		switch state[fields[0]] {
+		case visiting:
+			return nil, errors.New(ErrDependencyCycle.Error() + ": " + strings.Join(append(trace, fields[0]), " "+commandChainSeparator+" "))
+		case visited:
+			n := nodes[fields[0]]
+			if strings.Join(n.args, " ") != strings.Join(fields[1:], " ") {
+				return nil, errors.New(ErrConflictingArgs.Error() + ": " + fields[0] + ": '" + strings.Join(n.args, " ") + "' and '" + strings.Join(fields[1:], " ") + "'")
+			}
+			return n, nil
 		}
 
-		f, err := os.Create(outputName + "/run.sh")
+		cmd, err := cmdMap.getCommand(fields[0])
 		if err != nil {
-			Log.WithError(err).Error("failed to create run script")
-			return
+			return nil, errors.New("invalid command: " + fields[0])
 		}
 
-		err = f.Chmod(0700)
+		lang, err := cmd.getLanguage()
 		if err != nil {
-			Log.WithError(err).Error("failed to make run script executable")
-			return
+			return nil, errors.New(cmd.name + ": " + err.Error() + ": " + cmd.language)
+		}
+
+		state[fields[0]] = visiting
+
+		n := &generateNode{
+			cmd:  cmd,
+			lang: lang,
+			args: fields[1:],
+		}
+
+		trace = append(append([]string{}, trace...), fields[0])
+
+		for _, d := range cmd.dependencies {
+			dep, err := add(d, trace)
+			if err != nil {
+				return nil, err
+			}
+			n.deps = append(n.deps, dep.cmd.name)
 		}
 
-		f.WriteString("#!/bin/bash\n\n")
-		f.WriteString("# generated by ZEUS v" + version + " @ " + time.Now().String() + "\n")
-		f.WriteString("# commandChain: " + strings.Join(args[2:], " ") + "\n")
-		f.WriteString("\n./" + filepath.Base(chain[0].path) + "\n")
-		f.Close()
+		state[fields[0]] = visited
+		nodes[fields[0]] = n
+		plan.nodes = append(plan.nodes, n)
 
-		l.Println("generated " + outputName + "/run.sh")
+		return n, nil
 	}
 
-	chainSlice := strings.Split(strings.Join(args[3:], " "), commandChainSeparator)
-	if len(chainSlice) > 0 {
-		arguments = strings.Fields(chainSlice[0])
+	var previous *generateNode
+	for _, invocation := range strings.Split(line, commandChainSeparator) {
+
+		if fields := strings.Fields(invocation); len(fields) > 0 && state[fields[0]] == visited {
+			return nil, errors.New(ErrRepeatedCommand.Error() + ": " + fields[0])
+		}
+
+		n, err := add(invocation, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// each element of the chain runs after the previous one
+		if previous != nil && !contains(n.deps, previous.cmd.name) {
+			n.deps = append(n.deps, previous.cmd.name)
+		}
+		previous = n
 	}
 
-	// iterate over the chain and generate scripts
-	for i, cmd := range chain {
-		f, lang, err := generateScript(outputName, mixed, cmd, arguments)
+	return plan, nil
+}
+
+// check if the plan contains commands in different languages
+func (p *generatePlan) mixed() bool {
+	for _, n := range p.nodes {
+		if n.lang.Name != p.nodes[0].lang.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// the last command of the plan
+func (p *generatePlan) last() *generateNode {
+	return p.nodes[len(p.nodes)-1]
+}
+
+// generate a single script that contains all commands of the plan
+func (p *generatePlan) generateSingle(outputName string) error {
+
+	var (
+		lang = p.nodes[0].lang
+		b    bytes.Buffer
+	)
+
+	b.WriteString(lang.Bang + "\n")
+	b.WriteString(generateHeader(lang, p.chain))
+	b.WriteString(generateGlobalCode(lang))
+
+	for _, n := range p.nodes {
+
+		arguments, code, err := n.code()
 		if err != nil {
-			l.Println(err)
-			return
+			return err
 		}
 
-		// inject call to next script
-		if i < len(chain)-1 {
+		b.WriteString("\n" + lang.Comment + " " + n.cmd.name + "\n")
+		b.WriteString(arguments)
+		b.WriteString(code)
+		b.WriteString("\n")
+	}
 
-			nextCmd := chain[i+1]
+	err := ioutil.WriteFile(outputName, b.Bytes(), 0700)
+	if err != nil {
+		return err
+	}
 
-			nextLang, err := nextCmd.getLanguage()
-			if err != nil {
-				l.Println(nextCmd.name + ": " + err.Error() + ": " + nextCmd.language)
-				return
-			}
+	l.Println("generated " + outputName)
+	return nil
+}
+
+// generate a directory with one script per command and a run.sh script
+// that executes all of them in order
+func (p *generatePlan) generateMixed(outputName string) error {
+
+	// create a directory for multiple scripts
+	err := os.MkdirAll(outputName, 0744)
+	if err != nil {
+		return errors.New("failed to create directory for mixed commandChain: " + err.Error())
+	}
+
+	var b bytes.Buffer
+	b.WriteString("#!/bin/bash\n\n")
+	b.WriteString("# generated by ZEUS v" + version + " @ " + time.Now().String() + "\n")
+	b.WriteString("# commandChain: " + p.chain + "\n\n")
+	b.WriteString("set -e\n")
+	b.WriteString("DIR=\"$(dirname \"$0\")\"\n\n")
 
-			f.WriteString("\n" + lang.Comment + " execute next script: " + filepath.Base(nextCmd.path) + "\n")
-			f.WriteString(lang.ExecOpPrefix + nextLang.Interpreter + " " + filepath.Base(nextCmd.path) + lang.ExecOpSuffix + "\n")
+	for _, n := range p.nodes {
+
+		path, err := n.generateScript(outputName)
+		if err != nil {
+			return err
 		}
-		f.Close()
+
+		b.WriteString(n.lang.Interpreter + " \"$DIR/" + filepath.Base(path) + "\"\n")
+	}
+
+	err = ioutil.WriteFile(outputName+"/run.sh", b.Bytes(), 0700)
+	if err != nil {
+		return errors.New("failed to create run script: " + err.Error())
 	}
+
+	l.Println("generated " + outputName + "/run.sh")
+	return nil
 }
 
-// generate a single script
-// if mixed each script will be put into the outputName directory
-// if not, a single script file will be generated
-// returns the fileDeskriptor, language and an error
-func generateScript(outputName string, mixed bool, cmd *command, args []string) (*os.File, *Language, error) {
+// generate a standalone script for a single command in the given directory
+// returns the path of the script
+func (n *generateNode) generateScript(dir string) (string, error) {
+
+	arguments, code, err := n.code()
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	b.WriteString(n.lang.Bang + "\n")
+	b.WriteString(generateHeader(n.lang, n.invocation()))
+	b.WriteString(arguments)
+	b.WriteString(generateGlobalCode(n.lang))
+	b.WriteString("\n")
+	b.WriteString(code)
+	b.WriteString("\n")
 
-	var arguments string
+	path := filepath.Join(dir, n.cmd.name+n.lang.FileExtension)
 
-	// get language for current command
-	lang, err := cmd.getLanguage()
+	err = ioutil.WriteFile(path, b.Bytes(), 0700)
 	if err != nil {
-		cmd.dump()
-		return nil, nil, errors.New(cmd.name + ": " + err.Error() + ": " + cmd.language)
+		return "", err
 	}
 
+	l.Println("generated " + path)
+	return path, nil
+}
+
+// returns the code for the arguments and the script of a command
+func (n *generateNode) code() (arguments string, code string, err error) {
+
 	// handle arguments
-	if len(cmd.args) > 0 {
-		if len(args) > 0 {
-			arguments, err = cmd.parseArguments(args)
-			if err != nil {
-				return nil, nil, err
-			}
+	if len(n.cmd.args) > 0 && len(n.args) > 0 {
+		arguments, err = n.cmd.parseArguments(n.args)
+		if err != nil {
+			return "", "", errors.New(n.cmd.name + ": " + err.Error())
 		}
 	}
 
-	if mixed {
-		// create new file in output directory
-		outputName = outputName + "/" + cmd.name + lang.FileExtension
+	code, err = n.script()
+	return arguments, code, err
+}
+
+// returns the script of a command
+func (n *generateNode) script() (string, error) {
+
+	if n.cmd.exec != "" {
+		return n.cmd.exec, nil
 	}
 
-	f, err := os.Create(outputName)
+	c, err := ioutil.ReadFile(n.cmd.path)
 	if err != nil {
-		return nil, nil, err
+		return "", errors.New("failed to read: " + n.cmd.path)
 	}
 
+	return string(c), nil
+}
+
+// the command line for the command including its arguments
+func (n *generateNode) invocation() string {
+	return strings.TrimSpace(n.cmd.name + " " + strings.Join(n.args, " "))
+}
+
+// name for a target, stage or step of the command
+func (n *generateNode) targetName() string {
+	return targetName(n.cmd.name)
+}
+
+func targetName(name string) string {
+	return strings.Trim(invalidTargetChars.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+}
+
+// header comment for generated scripts
+func generateHeader(lang *Language, chain string) string {
 	header := lang.Comment + " generated by ZEUS v" + version + "\n"
 	header += lang.Comment + " Timestamp: " + time.Now().Format(timestampFormat) + "\n"
+	header += lang.Comment + " commandChain: " + chain + "\n\n"
+	return header
+}
 
-	// insert bang and args
-	f.WriteString(lang.Bang + "\n" + header + "\n" + arguments + "\n")
+// global variables and the language specific global code
+func generateGlobalCode(lang *Language) string {
 
-	f.WriteString(generateGlobals(lang))
+	out := generateGlobals(lang)
 
-	// add language specific global code
 	code, err := ioutil.ReadFile(zeusDir + "/globals/globals" + lang.FileExtension)
 	if err == nil {
-		f.WriteString("\n")
-		f.Write(code)
-		f.WriteString("\n")
+		out += "\n" + string(code) + "\n"
 	}
 
-	// add dependencies
-	err = addDependencies(cmd, f, lang, outputName, mixed)
+	return out
+}
+
+// export a command chain into a Makefile, CI workflow or Dockerfile
+// all files are written to zeus/generated/<outputName>, together with one script per command
+// the generated files expect to be executed from the project root
+func generateTarget(target, outputName string, chainArgs []string) error {
+
+	plan, err := newGeneratePlan(chainArgs)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	// add script of current command
-	if cmd.exec == "" {
-		c, err := ioutil.ReadFile(cmd.path)
+	var (
+		dir        = filepath.Join(zeusDir, "generated", outputName)
+		scriptsDir = filepath.Join(dir, "scripts")
+		scripts    = make(map[string]string, len(plan.nodes))
+	)
+
+	err = os.MkdirAll(scriptsDir, 0744)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range plan.nodes {
+		path, err := n.generateScript(scriptsDir)
 		if err != nil {
-			l.Println("failed to read: " + cmd.path)
-			return nil, nil, err
+			return err
 		}
-		f.Write(c)
-	} else {
-		f.WriteString(cmd.exec)
+		scripts[n.cmd.name] = strings.TrimSpace(n.lang.Interpreter+" "+n.lang.FlagStopOnError) + " " + path
 	}
 
-	// make script executable
-	err = os.Chmod(outputName, 0700)
+	var (
+		file     string
+		contents []byte
+	)
+
+	switch target {
+	case generateMakefile:
+		file = "Makefile"
+		contents = plan.makefile(scripts)
+	case generateWorkflow:
+		file = "workflow.yml"
+		contents, err = plan.workflow(outputName, scripts)
+	case generateDockerfile:
+		file = "Dockerfile"
+		contents = plan.dockerfile(scripts)
+	}
 	if err != nil {
-		Log.Error("failed to make script executable")
-		return nil, nil, err
+		return err
 	}
 
-	l.Println("generated " + outputName)
+	path := filepath.Join(dir, file)
 
-	return f, lang, nil
+	err = ioutil.WriteFile(path, contents, 0644)
+	if err != nil {
+		return err
+	}
+
+	l.Println("generated " + path)
+	return nil
 }
 
-func addDependencies(cmd *command, f *os.File, lang *Language, outputName string, mixed bool) error {
+// generate a GNU Makefile
+// every command is a phony target with its dependencies as prerequisites
+func (p *generatePlan) makefile(scripts map[string]string) []byte {
 
-	for i, d := range cmd.dependencies {
+	var (
+		b       bytes.Buffer
+		targets []string
+	)
 
-		fields := strings.Fields(d)
-		if len(fields) == 0 {
-			return ErrEmptyDependency
-		}
+	for _, n := range p.nodes {
+		targets = append(targets, n.targetName())
+	}
 
-		// lookup
-		dep, err := cmdMap.getCommand(fields[0])
-		if err != nil {
-			return errors.New("invalid dependency: " + err.Error())
+	b.WriteString("# generated by ZEUS v" + version + "\n")
+	b.WriteString("# commandChain: " + p.chain + "\n")
+	b.WriteString("# usage: make -f <path to this file> [target]\n\n")
+	b.WriteString(".PHONY: all " + strings.Join(targets, " ") + "\n\n")
+	b.WriteString("all: " + p.last().targetName() + "\n")
+
+	for _, n := range p.nodes {
+
+		var prerequisites []string
+		for _, d := range n.deps {
+			prerequisites = append(prerequisites, targetName(d))
 		}
 
-		// get language for current command
-		depLang, err := dep.getLanguage()
-		if err != nil {
-			l.Println(dep.name+":", err, ", language:", dep.language)
-			return err
+		b.WriteString("\n")
+		if n.cmd.description != "" {
+			b.WriteString("# " + n.cmd.description + "\n")
 		}
+		b.WriteString(strings.TrimSpace(n.targetName()+": "+strings.Join(prerequisites, " ")) + "\n")
+		b.WriteString("\t" + strings.Replace(scripts[n.cmd.name], "$", "$$", -1) + "\n")
+	}
 
-		if lang.Name == depLang.Name {
-			// add to current script
-			if dep.exec == "" {
-				c, err := ioutil.ReadFile(dep.path)
-				if err != nil {
-					l.Println("failed to read: " + dep.path)
-					return err
-				}
-				f.Write(c)
-			} else {
-				f.WriteString(dep.exec)
-			}
-		} else {
-			// dependency script is in another language
-			// generate a new script and inject a call to it
-			generateScript(outputName, mixed, dep, []string{})
-
-			fields := strings.Fields(cmd.dependencies[i+1])
-			if len(fields) == 0 {
-				return ErrEmptyDependency
-			}
+	return b.Bytes()
+}
 
-			// lookup
-			nextDep, err := cmdMap.getCommand(fields[0])
-			if err != nil {
-				return err
-			}
+// GitHub Actions workflow
+type ciWorkflow struct {
+	Name string            `yaml:"name"`
+	On   []string          `yaml:"on"`
+	Jobs map[string]*ciJob `yaml:"jobs"`
+}
+
+type ciJob struct {
+	RunsOn string    `yaml:"runs-on"`
+	Steps  []*ciStep `yaml:"steps"`
+}
 
-			f.WriteString("\n" + lang.Comment + " execute next script: " + filepath.Base(nextDep.path) + "\n")
-			f.WriteString(lang.ExecOpPrefix + lang.Interpreter + " " + filepath.Base(nextDep.path) + lang.ExecOpSuffix + "\n")
+type ciStep struct {
+	ID   string `yaml:"id,omitempty"`
+	Name string `yaml:"name,omitempty"`
+	Uses string `yaml:"uses,omitempty"`
+	Run  string `yaml:"run,omitempty"`
+}
+
+// generate a CI workflow with one step per command
+// all steps run in a single job, so they share the working directory like a local invocation
+func (p *generatePlan) workflow(name string, scripts map[string]string) ([]byte, error) {
+
+	job := &ciJob{
+		RunsOn: "ubuntu-latest",
+		Steps: []*ciStep{
+			{Uses: "actions/checkout@v2"},
+		},
+	}
+
+	for _, n := range p.nodes {
+		stepName := n.cmd.name
+		if n.cmd.description != "" {
+			stepName += ": " + n.cmd.description
 		}
+		job.Steps = append(job.Steps, &ciStep{
+			ID:   "zeus-" + n.targetName(),
+			Name: stepName,
+			Run:  scripts[n.cmd.name],
+		})
+	}
 
-		f.WriteString("\n")
+	b, err := yaml.Marshal(&ciWorkflow{
+		Name: name,
+		On:   []string{"push", "pull_request"},
+		Jobs: map[string]*ciJob{
+			targetName(name): job,
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return append([]byte("# generated by ZEUS v"+version+"\n# commandChain: "+p.chain+"\n\n"), b...), nil
+}
+
+// generate a Dockerfile with one stage per command
+// each stage builds upon the previous one, so a build can stop at any command with --target
+func (p *generatePlan) dockerfile(scripts map[string]string) []byte {
+
+	var b bytes.Buffer
+
+	b.WriteString("# generated by ZEUS v" + version + "\n")
+	b.WriteString("# commandChain: " + p.chain + "\n")
+	b.WriteString("# usage: docker build -f <path to this file> [--target <command>] .\n\n")
+	b.WriteString("ARG BASE_IMAGE=" + defaultBaseImage + "\n\n")
+	b.WriteString("FROM ${BASE_IMAGE} AS zeus-base\n")
+	b.WriteString("WORKDIR /src\n")
+	b.WriteString("COPY . /src\n")
+
+	previous := "zeus-base"
+	for _, n := range p.nodes {
+		b.WriteString("\n")
+		if n.cmd.description != "" {
+			b.WriteString("# " + n.cmd.description + "\n")
+		}
+		b.WriteString("FROM " + previous + " AS " + n.targetName() + "\n")
+		b.WriteString("RUN " + scripts[n.cmd.name] + "\n")
+		previous = n.targetName()
+	}
+
+	return b.Bytes()
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
 }
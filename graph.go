@@ -0,0 +1,452 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownGraphFormat means the requested graph format is not supported
+var ErrUnknownGraphFormat = errors.New("unknown graph format")
+
+// graph formats
+const (
+	graphDOT     = "dot"
+	graphMermaid = "mermaid"
+	graphJSON    = "json"
+)
+
+// states of a command during and after its last run
+const (
+	stateRunning  = "running"
+	stateFinished = "finished"
+	stateFailed   = "failed"
+	stateSkipped  = "skipped"
+)
+
+var (
+	// states of the commands in their last run
+	commandStates = &runStates{
+		items: make(map[string]string, 0),
+	}
+
+	// characters that are not allowed in mermaid node identifiers
+	invalidMermaidChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+// runStates tracks the state of each command in its last run
+type runStates struct {
+	items map[string]string
+	sync.RWMutex
+}
+
+// commandStateHook tracks the state of the commands in their last run
+func commandStateHook(ctx *execContext, inv *invocation) (*execContext, *invocationObserver) {
+
+	if inv.kind == invocationChain {
+		return ctx, nil
+	}
+
+	return ctx, &invocationObserver{
+		state: func(inv *invocation, state string) {
+			commandStates.set(inv.name, state)
+		},
+	}
+}
+
+// set the state of a command and notify the connected web interfaces
+func (s *runStates) set(name, state string) {
+
+	s.Lock()
+	s.items[name] = state
+	s.Unlock()
+
+	socketstoreMutex.Lock()
+	store := socketstore
+	socketstoreMutex.Unlock()
+
+	if store != nil {
+		b, err := json.Marshal(map[string]string{
+			"type":    "state",
+			"command": name,
+			"state":   state,
+		})
+		if err == nil {
+			store.Broadcast(string(b))
+		}
+	}
+}
+
+// get the state of a command in its last run
+// returns an empty string if it did not run yet
+func (s *runStates) get(name string) string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.items[name]
+}
+
+// states of all commands in their last run
+// the run history provides the states of earlier zeus invocations,
+// states of runs in this process take precedence, they include running commands
+func lastCommandStates() map[string]string {
+
+	states := make(map[string]string, 0)
+
+	runs, err := loadHistory()
+	if err != nil {
+		Log.WithError(err).Debug("failed to load the run history")
+	}
+
+	for _, run := range runs {
+		for _, e := range run.Commands {
+			switch e.Status {
+			case runOK:
+				states[e.Name] = stateFinished
+			case runFailed, runCancelled:
+				states[e.Name] = stateFailed
+			case runSkipped:
+				states[e.Name] = stateSkipped
+			}
+		}
+	}
+
+	commandStates.RLock()
+	for name, state := range commandStates.items {
+		states[name] = state
+	}
+	commandStates.RUnlock()
+
+	return states
+}
+
+// a node of the dependency graph
+type graphNode struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Async       bool     `json:"async,omitempty"`
+	Outputs     []string `json:"outputs,omitempty"`
+	State       string   `json:"state,omitempty"`
+}
+
+// an edge from a dependency to the command that depends on it
+type graphEdge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Args []string `json:"args,omitempty"`
+}
+
+// dependencyGraph is the resolved dependency graph of the commands
+type dependencyGraph struct {
+	Nodes []*graphNode `json:"nodes"`
+	Edges []*graphEdge `json:"edges"`
+}
+
+// resolve the dependency graph for a command
+// if name is empty, the graph contains all commands
+func newDependencyGraph(name string) (*dependencyGraph, error) {
+
+	var (
+		graph   = &dependencyGraph{}
+		visited = make(map[string]bool, 0)
+		states  = lastCommandStates()
+		add     func(c *command) error
+	)
+
+	add = func(c *command) error {
+
+		if visited[c.name] {
+			return nil
+		}
+		visited[c.name] = true
+
+		graph.Nodes = append(graph.Nodes, &graphNode{
+			Name:        c.name,
+			Description: c.description,
+			Async:       c.async,
+			Outputs:     c.outputs,
+			State:       states[c.name],
+		})
+
+		for _, d := range c.dependencies {
+
+			fields := strings.Fields(d)
+			if len(fields) == 0 {
+				return ErrEmptyDependency
+			}
+
+			dep, err := cmdMap.getCommand(fields[0])
+			if err != nil {
+				return errors.New("invalid dependency " + fields[0] + " of command " + c.name)
+			}
+
+			graph.Edges = append(graph.Edges, &graphEdge{
+				From: dep.name,
+				To:   c.name,
+				Args: fields[1:],
+			})
+
+			err = add(dep)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if name != "" {
+		c, err := cmdMap.getCommand(name)
+		if err != nil {
+			return nil, err
+		}
+		return graph, add(c)
+	}
+
+	cmdMap.Lock()
+	var commands []*command
+	for _, c := range cmdMap.items {
+		commands = append(commands, c)
+	}
+	cmdMap.Unlock()
+
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].name < commands[j].name
+	})
+
+	for _, c := range commands {
+		err := add(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return graph, nil
+}
+
+// render the graph in the Graphviz DOT language
+func (g *dependencyGraph) dot() string {
+
+	var b strings.Builder
+
+	b.WriteString("digraph zeus {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=\"rounded,filled\", fillcolor=white, fontname=\"Helvetica\"];\n\n")
+
+	for _, n := range g.Nodes {
+
+		var (
+			attrs = []string{"label=" + strconv.Quote(n.Name)}
+			style = "rounded,filled"
+		)
+
+		if n.Description != "" {
+			attrs = append(attrs, "tooltip="+strconv.Quote(n.Description))
+		}
+		if n.Async {
+			style += ",dashed"
+		}
+		if len(n.Outputs) > 0 {
+			attrs = append(attrs, "peripheries=2", "xlabel="+strconv.Quote(strings.Join(n.Outputs, "\n")))
+		}
+		if color := stateColor(n.State); color != "" {
+			attrs = append(attrs, "fillcolor="+strconv.Quote(color))
+		}
+		if style != "rounded,filled" {
+			attrs = append(attrs, "style="+strconv.Quote(style))
+		}
+
+		b.WriteString("\t" + strconv.Quote(n.Name) + " [" + strings.Join(attrs, ", ") + "];\n")
+	}
+
+	if len(g.Edges) > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, e := range g.Edges {
+		b.WriteString("\t" + strconv.Quote(e.From) + " -> " + strconv.Quote(e.To))
+		if len(e.Args) > 0 {
+			b.WriteString(" [label=" + strconv.Quote(strings.Join(e.Args, " ")) + "]")
+		}
+		b.WriteString(";\n")
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// render the graph as a mermaid flowchart
+func (g *dependencyGraph) mermaid() string {
+
+	var (
+		b       strings.Builder
+		classes = make(map[string][]string, 0)
+	)
+
+	b.WriteString("graph LR\n")
+
+	for _, n := range g.Nodes {
+
+		id := mermaidID(n.Name)
+		b.WriteString("    " + id + "[\"" + strings.Replace(n.Name, "\"", "#quot;", -1) + "\"]\n")
+
+		if n.Async {
+			classes["async"] = append(classes["async"], id)
+		}
+		if len(n.Outputs) > 0 {
+			classes["outputs"] = append(classes["outputs"], id)
+		}
+		if n.State != "" {
+			classes[n.State] = append(classes[n.State], id)
+		}
+	}
+
+	for _, e := range g.Edges {
+		if len(e.Args) > 0 {
+			b.WriteString("    " + mermaidID(e.From) + " -- \"" + strings.Join(e.Args, " ") + "\" --> " + mermaidID(e.To) + "\n")
+		} else {
+			b.WriteString("    " + mermaidID(e.From) + " --> " + mermaidID(e.To) + "\n")
+		}
+	}
+
+	for _, class := range []string{"async", "outputs", stateRunning, stateFinished, stateFailed, stateSkipped} {
+		if ids, ok := classes[class]; ok {
+			b.WriteString("    class " + strings.Join(ids, ",") + " " + class + "\n")
+		}
+	}
+
+	b.WriteString("    classDef async stroke-dasharray: 5 5\n")
+	b.WriteString("    classDef outputs stroke-width:3px\n")
+	for _, state := range []string{stateRunning, stateFinished, stateFailed, stateSkipped} {
+		b.WriteString("    classDef " + state + " fill:" + stateColor(state) + "\n")
+	}
+
+	return b.String()
+}
+
+// identifier for a node in a mermaid flowchart
+func mermaidID(name string) string {
+	return invalidMermaidChars.ReplaceAllString(name, "_")
+}
+
+// fill color for a command state
+func stateColor(state string) string {
+	switch state {
+	case stateRunning:
+		return "#ffd700"
+	case stateFinished:
+		return "#98fb98"
+	case stateFailed:
+		return "#fa8072"
+	case stateSkipped:
+		return "#d3d3d3"
+	}
+	return ""
+}
+
+// render the graph in the given format
+func (g *dependencyGraph) render(format string) (string, error) {
+	switch format {
+	case graphDOT:
+		return g.dot(), nil
+	case graphMermaid:
+		return g.mermaid(), nil
+	case graphJSON:
+		b, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(b) + "\n", nil
+	}
+	return "", ErrUnknownGraphFormat
+}
+
+func printGraphUsageErr() {
+	l.Println("usage: graph [dot | mermaid | json] [command]")
+}
+
+// handle graph shell commands
+func handleGraphCommand(args []string) error {
+
+	var (
+		format = graphDOT
+		name   string
+	)
+
+	args = args[1:]
+	if len(args) > 0 {
+		switch args[0] {
+		case graphDOT, graphMermaid, graphJSON:
+			format = args[0]
+			args = args[1:]
+		}
+	}
+
+	switch len(args) {
+	case 0:
+	case 1:
+		name = args[0]
+	default:
+		printGraphUsageErr()
+		return ErrInvalidUsage
+	}
+
+	graph, err := newDependencyGraph(name)
+	if err != nil {
+		l.Println(err)
+		return err
+	}
+
+	out, err := graph.render(format)
+	if err != nil {
+		l.Println(err)
+		return err
+	}
+
+	l.Print(out)
+	return nil
+}
+
+// serve the dependency graph as JSON
+// the command query parameter restricts the graph to a command and its dependencies
+var graphHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+	graph, err := newDependencyGraph(r.URL.Query().Get("command"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	b, err := json.Marshal(graph)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(b)
+})
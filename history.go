@@ -0,0 +1,1066 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrUnknownRun means there is no run with the requested id in the history
+	ErrUnknownRun = errors.New("unknown run")
+
+	// ErrInvalidAge means an age could not be parsed
+	ErrInvalidAge = errors.New("invalid age, use a duration like 12h, 30d or 4w")
+
+	// serializes writes to the history file of this process
+	// other processes are excluded with a file lock, see lockHistory
+	historyMutex = &sync.Mutex{}
+
+	// git user name, the fallback for the author of a run
+	gitUser     string
+	gitUserOnce sync.Once
+)
+
+// run and command status in the history
+const (
+	runOK        = "ok"
+	runFailed    = "failed"
+	runCancelled = "cancelled"
+	runSkipped   = "skipped"
+)
+
+// number of latest executions compared to the older ones for the duration trend
+const trendWindow = 10
+
+// path for the run history
+func historyPath() string {
+	return zeusDir + "/history.jsonl"
+}
+
+// historyRun is a single invocation of a command or command chain
+type historyRun struct {
+	ID       string          `json:"id"`
+	Chain    string          `json:"chain"`
+	Start    time.Time       `json:"start"`
+	Duration time.Duration   `json:"duration"`
+	Status   string          `json:"status"`
+	ExitCode int             `json:"exitCode"`
+	Error    string          `json:"error,omitempty"`
+	Commit   string          `json:"commit,omitempty"`
+	Author   string          `json:"author,omitempty"`
+	Commands []*historyEntry `json:"commands"`
+}
+
+// historyEntry is the execution of a command during a run
+type historyEntry struct {
+	Name       string        `json:"name"`
+	Args       []string      `json:"args,omitempty"`
+	Dependency bool          `json:"dependency,omitempty"`
+	Start      time.Time     `json:"start"`
+	Duration   time.Duration `json:"duration"`
+	Status     string        `json:"status"`
+	ExitCode   int           `json:"exitCode"`
+	Error      string        `json:"error,omitempty"`
+
+	// set if the command started the run
+	owner    bool
+	recorder *runRecorder
+}
+
+// runRecorder collects the commands of a run
+// commands of async chains finish concurrently
+type runRecorder struct {
+	run *historyRun
+	sync.Mutex
+}
+
+// startRun records a new run for the invocation
+// returns a copy of the context carrying the recorder
+// the context is returned unchanged if a run is already recorded or the history is disabled
+func (c *execContext) startRun(chain string) (*execContext, *runRecorder) {
+
+	if c.history != nil {
+		return c, nil
+	}
+
+	conf.Lock()
+	enabled := conf.fields.RunHistory
+	conf.Unlock()
+
+	if !enabled {
+		return c, nil
+	}
+
+	r := &runRecorder{
+		run: &historyRun{
+			ID:    randomString()[:8],
+			Chain: chain,
+			Start: time.Now(),
+		},
+	}
+
+	child := *c
+	child.history = r
+
+	return &child, r
+}
+
+// recordCommand adds the command to the run of the invocation
+// commands invoked outside of a chain start a new run, that is saved when the command finishes
+func (c *execContext) recordCommand(name string, dependency bool) (*execContext, *historyEntry) {
+
+	ctx, r := c.startRun(strings.TrimSpace(name + " " + strings.Join(c.args, " ")))
+	if ctx.history == nil {
+		return ctx, nil
+	}
+
+	e := &historyEntry{
+		Name:       name,
+		Args:       ctx.args,
+		Dependency: dependency,
+		Start:      time.Now(),
+		owner:      r != nil,
+		recorder:   ctx.history,
+	}
+
+	ctx.history.Lock()
+	ctx.history.run.Commands = append(ctx.history.run.Commands, e)
+	ctx.history.Unlock()
+
+	return ctx, e
+}
+
+// historyHook records the run of a chain, or the commands of a run
+func historyHook(ctx *execContext, inv *invocation) (*execContext, *invocationObserver) {
+
+	if inv.kind == invocationChain {
+		ctx, run := ctx.startRun(inv.line)
+		if run == nil {
+			return ctx, nil
+		}
+		return ctx, &invocationObserver{
+			finished: run.finish,
+		}
+	}
+
+	ctx, entry := ctx.recordCommand(inv.name, inv.kind == invocationDependency)
+	if entry == nil {
+		return ctx, nil
+	}
+
+	return ctx, &invocationObserver{
+		finished: entry.finish,
+	}
+}
+
+// finish the command and save the run if the command started it
+func (e *historyEntry) finish(inv *invocation) {
+	if e == nil {
+		return
+	}
+
+	e.recorder.Lock()
+	e.Duration = inv.duration
+	e.Status, e.ExitCode, e.Error = inv.status, inv.exitCode, inv.message
+	e.recorder.Unlock()
+
+	if e.owner {
+		e.recorder.finish(inv)
+	}
+}
+
+// finish the run and append it to the history
+// commit and author are resolved when the run is saved, so starting a run does not wait for git
+func (r *runRecorder) finish(inv *invocation) {
+	if r == nil {
+		return
+	}
+
+	var (
+		commit = gitCommit()
+		author = historyAuthor()
+	)
+
+	r.Lock()
+	run := r.run
+	run.Duration = time.Since(run.Start)
+	run.Commit = commit
+	run.Author = author
+	run.Status, run.ExitCode, run.Error = inv.status, inv.exitCode, inv.message
+
+	// the exit code of the run is the one of the command that caused the failure
+	// dependencies are recorded after the commands that depend on them
+	if run.Status == runFailed {
+		for i := len(run.Commands) - 1; i >= 0; i-- {
+			if e := run.Commands[i]; e.Status == runFailed && e.ExitCode > 0 {
+				run.ExitCode = e.ExitCode
+				break
+			}
+		}
+	}
+
+	b, err := json.Marshal(run)
+	r.Unlock()
+	if err != nil {
+		Log.WithError(err).Error("failed to marshal run")
+		return
+	}
+
+	err = appendHistory(b)
+	if err != nil {
+		Log.WithError(err).Error("failed to write run history")
+	}
+}
+
+// status, exit code and error message for the result of an execution
+func runResult(err error) (status string, exitCode int, message string) {
+
+	if err == nil {
+		return runOK, 0, ""
+	}
+
+	if err == ErrCancelled {
+		return runCancelled, -1, err.Error()
+	}
+
+	if e, ok := err.(*exec.ExitError); ok {
+		return runFailed, e.ExitCode(), err.Error()
+	}
+
+	return runFailed, 1, err.Error()
+}
+
+// short hash of the current git commit, empty if the project is not a git repository
+func gitCommit() string {
+	out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// author of a run: the project author if set, otherwise the git user name
+func historyAuthor() string {
+
+	projectData.Lock()
+	author := projectData.fields.Author
+	projectData.Unlock()
+
+	if author != "" {
+		return author
+	}
+
+	// the git user name is resolved once per process
+	gitUserOnce.Do(func() {
+		out, err := exec.Command("git", "config", "user.name").Output()
+		if err == nil {
+			gitUser = strings.TrimSpace(string(out))
+		}
+	})
+
+	return gitUser
+}
+
+// lock the history for this process and for other zeus processes of the project
+// the lock file is separate from the history, because the history is replaced when it is rewritten
+// returns a function to release the lock
+func lockHistory() (func(), error) {
+
+	historyMutex.Lock()
+
+	f, err := os.OpenFile(zeusDir+"/history.lock", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		historyMutex.Unlock()
+		return nil, err
+	}
+
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		f.Close()
+		historyMutex.Unlock()
+		return nil, err
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+		historyMutex.Unlock()
+	}, nil
+}
+
+// append a run to the history file
+// runs are stored one per line, so concurrent zeus processes can append without overwriting each other
+func appendHistory(run []byte) error {
+
+	unlock, err := lockHistory()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := os.OpenFile(historyPath(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(run, '\n'))
+	return err
+}
+
+// load all runs from the history, oldest first
+// a missing history file is not an error
+// no lock is needed, because the history is only appended to or replaced as a whole
+func loadHistory() ([]*historyRun, error) {
+
+	data, err := ioutil.ReadFile(historyPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var (
+		runs    []*historyRun
+		scanner = bufio.NewScanner(bytes.NewReader(data))
+		line    int
+	)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		run := new(historyRun)
+		err := json.Unmarshal(scanner.Bytes(), run)
+		if err != nil {
+			Log.Warn("ignoring invalid run in " + historyPath() + " line " + strconv.Itoa(line) + ": " + err.Error())
+			continue
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, scanner.Err()
+}
+
+// replace the history with the given runs
+func writeHistory(runs []*historyRun) error {
+
+	unlock, err := lockHistory()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return replaceHistory(runs)
+}
+
+// write the runs into a temporary file and move it over the history
+// so readers never see a partially written history
+// the history must be locked by the caller
+func replaceHistory(runs []*historyRun) error {
+
+	var b bytes.Buffer
+	for _, run := range runs {
+		data, err := json.Marshal(run)
+		if err != nil {
+			return err
+		}
+		b.Write(append(data, '\n'))
+	}
+
+	f, err := ioutil.TempFile(zeusDir, "history")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(b.Bytes())
+	if err == nil {
+		err = f.Chmod(0644)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	err = os.Rename(f.Name(), historyPath())
+	if err != nil {
+		os.Remove(f.Name())
+	}
+
+	return err
+}
+
+// parse an age like 12h, 30d or 4w
+func parseAge(s string) (time.Duration, error) {
+
+	if s == "" {
+		return 0, nil
+	}
+
+	for suffix, unit := range map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	} {
+		if strings.HasSuffix(s, suffix) {
+			n, err := strconv.Atoi(strings.TrimSuffix(s, suffix))
+			if err != nil || n < 0 {
+				return 0, ErrInvalidAge
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, ErrInvalidAge
+	}
+
+	return d, nil
+}
+
+// pruneHistory removes runs that are older than maxAge
+// and the oldest runs exceeding the limit
+// a limit or maxAge of zero disables the respective rule
+// returns the number of removed runs
+// the history stays locked until it was rewritten, so no run that is appended in the meantime gets lost
+func pruneHistory(limit int, maxAge time.Duration) (int, error) {
+
+	unlock, err := lockHistory()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	runs, err := loadHistory()
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		kept   []*historyRun
+		cutoff = time.Now().Add(-maxAge)
+	)
+	for _, run := range runs {
+		if maxAge > 0 && run.Start.Before(cutoff) {
+			continue
+		}
+		kept = append(kept, run)
+	}
+
+	if limit > 0 && len(kept) > limit {
+		kept = kept[len(kept)-limit:]
+	}
+
+	removed := len(runs) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	return removed, replaceHistory(kept)
+}
+
+// check if the history contains more runs than the limit or runs older than maxAge
+// only the lines are counted and the first run is decoded, so this is cheap enough for every start
+func historyExceeds(limit int, maxAge time.Duration) bool {
+
+	data, err := ioutil.ReadFile(historyPath())
+	if err != nil || len(data) == 0 {
+		return false
+	}
+
+	if limit > 0 && bytes.Count(data, []byte{'\n'}) > limit {
+		return true
+	}
+
+	if maxAge > 0 {
+
+		first := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			first = data[:i]
+		}
+
+		var run struct {
+			Start time.Time `json:"start"`
+		}
+		if json.Unmarshal(first, &run) == nil && run.Start.Before(time.Now().Add(-maxAge)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apply the prune policy from the config
+func pruneHistoryFromConfig() {
+
+	conf.Lock()
+	var (
+		enabled = conf.fields.RunHistory
+		limit   = conf.fields.RunHistoryLimit
+		age     = conf.fields.RunHistoryMaxAge
+	)
+	conf.Unlock()
+
+	if !enabled {
+		return
+	}
+
+	maxAge, err := parseAge(age)
+	if err != nil {
+		Log.WithError(err).Error("invalid runHistoryMaxAge: " + age)
+		return
+	}
+
+	if !historyExceeds(limit, maxAge) {
+		return
+	}
+
+	n, err := pruneHistory(limit, maxAge)
+	if err != nil {
+		Log.WithError(err).Error("failed to prune run history")
+		return
+	}
+
+	if n > 0 {
+		Log.Debug("pruned " + strconv.Itoa(n) + " runs from the history")
+	}
+}
+
+// historyFilter selects runs from the history
+type historyFilter struct {
+	command string
+	status  string
+	author  string
+	commit  string
+	since   time.Duration
+	limit   int
+}
+
+// parse filters in the key=value format
+// a value without a key is a command name
+func parseHistoryFilter(args []string) (*historyFilter, error) {
+
+	f := &historyFilter{
+		limit: 20,
+	}
+
+	for _, arg := range args {
+
+		i := strings.Index(arg, "=")
+		if i == -1 {
+			f.command = arg
+			continue
+		}
+
+		var (
+			key   = arg[:i]
+			value = arg[i+1:]
+			err   error
+		)
+
+		switch key {
+		case "command":
+			f.command = value
+		case "status":
+			switch value {
+			case runOK, runFailed, runCancelled:
+				f.status = value
+			default:
+				return nil, errors.New("invalid status: " + value + ", expected ok, failed or cancelled")
+			}
+		case "author":
+			f.author = value
+		case "commit":
+			f.commit = value
+		case "since":
+			f.since, err = parseAge(value)
+			if err != nil {
+				return nil, err
+			}
+		case "limit":
+			f.limit, err = strconv.Atoi(value)
+			if err != nil || f.limit < 0 {
+				return nil, errors.New("invalid limit: " + value)
+			}
+		default:
+			return nil, errors.New("unknown filter: " + key)
+		}
+	}
+
+	return f, nil
+}
+
+// check if a run matches the filter
+func (f *historyFilter) match(run *historyRun) bool {
+
+	if f.status != "" && run.Status != f.status {
+		return false
+	}
+	if f.author != "" && !strings.EqualFold(run.Author, f.author) {
+		return false
+	}
+	if f.commit != "" && !strings.HasPrefix(run.Commit, f.commit) {
+		return false
+	}
+	if f.since > 0 && run.Start.Before(time.Now().Add(-f.since)) {
+		return false
+	}
+	if f.command != "" {
+		for _, e := range run.Commands {
+			if e.Name == f.command {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// select the matching runs, oldest first
+func (f *historyFilter) apply(runs []*historyRun) []*historyRun {
+
+	var matches []*historyRun
+	for _, run := range runs {
+		if f.match(run) {
+			matches = append(matches, run)
+		}
+	}
+
+	return matches
+}
+
+// commandStats are the statistics of a command over the selected runs
+type commandStats struct {
+	name      string
+	runs      int
+	failures  int
+	skipped   int
+	durations []time.Duration
+}
+
+// share of failed executions
+func (s *commandStats) failureRate() float64 {
+	if s.runs == 0 {
+		return 0
+	}
+	return float64(s.failures) / float64(s.runs)
+}
+
+// percentile of the execution durations, p in [0, 100]
+func (s *commandStats) percentile(p float64) time.Duration {
+	return percentile(s.durations, p)
+}
+
+// change of the median duration of the latest executions compared to the older ones
+// returns false if there are not enough executions
+func (s *commandStats) trend() (float64, bool) {
+
+	if len(s.durations) <= trendWindow {
+		return 0, false
+	}
+
+	var (
+		older  = percentile(s.durations[:len(s.durations)-trendWindow], 50)
+		latest = percentile(s.durations[len(s.durations)-trendWindow:], 50)
+	)
+	if older == 0 {
+		return 0, false
+	}
+
+	return float64(latest-older) / float64(older), true
+}
+
+// nearest rank percentile, the durations are not modified
+func percentile(durations []time.Duration, p float64) time.Duration {
+
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+// collect the statistics for all commands in the runs
+// durations are in the order of execution, skipped commands have no duration
+func collectStats(runs []*historyRun) map[string]*commandStats {
+
+	stats := make(map[string]*commandStats, 0)
+	for _, run := range runs {
+		for _, e := range run.Commands {
+
+			s, ok := stats[e.Name]
+			if !ok {
+				s = &commandStats{name: e.Name}
+				stats[e.Name] = s
+			}
+
+			switch e.Status {
+			case runSkipped:
+				s.skipped++
+			case runFailed:
+				s.runs++
+				s.failures++
+				s.durations = append(s.durations, e.Duration)
+			case runOK:
+				s.runs++
+				s.durations = append(s.durations, e.Duration)
+			}
+		}
+	}
+
+	return stats
+}
+
+// round durations for display
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Minute:
+		return d.Round(time.Second).String()
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	default:
+		return d.Round(time.Millisecond).String()
+	}
+}
+
+// colored status for display
+func formatRunStatus(status string) string {
+	switch status {
+	case runOK:
+		return cp.Prompt + status + cp.Text
+	case runFailed, runCancelled:
+		return cp.CmdName + status + cp.Text
+	default:
+		return status
+	}
+}
+
+// print the runs, oldest first
+func printHistory(runs []*historyRun) {
+
+	if len(runs) == 0 {
+		l.Println("no runs recorded yet")
+		return
+	}
+
+	conf.Lock()
+	dateFormat := conf.fields.DateFormat + " 15:04:05"
+	conf.Unlock()
+
+	l.Println(cp.Text + pad("ID", 10) + pad("DATE", len(dateFormat)+2) + pad("DURATION", 11) + pad("STATUS", 11) + pad("COMMIT", 10) + pad("AUTHOR", 16) + "CHAIN")
+	for _, run := range runs {
+		l.Println(
+			cp.Prompt + pad(run.ID, 10) + cp.Text +
+				pad(run.Start.Format(dateFormat), len(dateFormat)+2) +
+				pad(formatDuration(run.Duration), 11) +
+				pad(formatRunStatus(run.Status), 11+len(formatRunStatus(run.Status))-len(run.Status)) +
+				pad(run.Commit, 10) +
+				pad(run.Author, 16) +
+				cp.CmdName + run.Chain + cp.Reset,
+		)
+	}
+}
+
+// print the details of a run
+func printRun(run *historyRun) {
+
+	conf.Lock()
+	dateFormat := conf.fields.DateFormat + " 15:04:05"
+	conf.Unlock()
+
+	l.Println(cp.Text + pad("ID", 14) + cp.Prompt + run.ID)
+	l.Println(cp.Text + pad("Chain", 14) + cp.CmdName + run.Chain)
+	l.Println(cp.Text + pad("Date", 14) + cp.Prompt + run.Start.Format(dateFormat))
+	l.Println(cp.Text + pad("Duration", 14) + cp.Prompt + formatDuration(run.Duration))
+	l.Println(cp.Text + pad("Status", 14) + formatRunStatus(run.Status))
+	l.Println(cp.Text + pad("ExitCode", 14) + cp.Prompt + strconv.Itoa(run.ExitCode))
+	if run.Error != "" {
+		l.Println(cp.Text + pad("Error", 14) + cp.Prompt + run.Error)
+	}
+	if run.Commit != "" {
+		l.Println(cp.Text + pad("Commit", 14) + cp.Prompt + run.Commit)
+	}
+	if run.Author != "" {
+		l.Println(cp.Text + pad("Author", 14) + cp.Prompt + run.Author)
+	}
+
+	l.Println(cp.Text + "\n" + pad("COMMAND", 24) + pad("DURATION", 11) + pad("STATUS", 11) + pad("EXIT", 6) + "ARGS")
+	for _, e := range run.Commands {
+
+		name := e.Name
+		if e.Dependency {
+			name = "  " + name
+		}
+
+		l.Println(
+			cp.CmdName + pad(name, 24) + cp.Text +
+				pad(formatDuration(e.Duration), 11) +
+				pad(formatRunStatus(e.Status), 11+len(formatRunStatus(e.Status))-len(e.Status)) +
+				pad(strconv.Itoa(e.ExitCode), 6) +
+				strings.Join(e.Args, " ") + cp.Reset,
+		)
+	}
+}
+
+// print the statistics of the commands, slowest commands first
+func printHistoryStats(runs []*historyRun) {
+
+	stats := collectStats(runs)
+	if len(stats) == 0 {
+		l.Println("no runs recorded yet")
+		return
+	}
+
+	var sorted []*commandStats
+	for _, s := range stats {
+		sorted = append(sorted, s)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if pi, pj := sorted[i].percentile(95), sorted[j].percentile(95); pi != pj {
+			return pi > pj
+		}
+		return sorted[i].name < sorted[j].name
+	})
+
+	l.Println(cp.Text + pad("COMMAND", 24) + pad("RUNS", 6) + pad("FAILED", 8) + pad("RATE", 8) + pad("SKIPPED", 9) + pad("P50", 11) + pad("P95", 11) + pad("MAX", 11) + "TREND")
+	for _, s := range sorted {
+
+		trend := "-"
+		if t, ok := s.trend(); ok {
+			trend = strconv.FormatFloat(t*100, 'f', 0, 64) + "%"
+			if t > 0 {
+				trend = "+" + trend
+			}
+		}
+
+		l.Println(
+			cp.CmdName + pad(s.name, 24) + cp.Text +
+				pad(strconv.Itoa(s.runs), 6) +
+				pad(strconv.Itoa(s.failures), 8) +
+				pad(strconv.FormatFloat(s.failureRate()*100, 'f', 1, 64)+"%", 8) +
+				pad(strconv.Itoa(s.skipped), 9) +
+				pad(formatDuration(s.percentile(50)), 11) +
+				pad(formatDuration(s.percentile(95)), 11) +
+				pad(formatDuration(s.percentile(100)), 11) +
+				trend + cp.Reset,
+		)
+	}
+}
+
+// print the daily duration percentiles of a command
+func printCommandTrend(runs []*historyRun, name string) {
+
+	conf.Lock()
+	dateFormat := conf.fields.DateFormat
+	conf.Unlock()
+
+	var (
+		days  []string
+		stats = make(map[string][]*historyRun, 0)
+	)
+	for _, run := range runs {
+		day := run.Start.Format(dateFormat)
+		if _, ok := stats[day]; !ok {
+			days = append(days, day)
+		}
+		stats[day] = append(stats[day], run)
+	}
+
+	l.Println(cp.Text + "\n" + pad("DAY", len(dateFormat)+2) + pad("RUNS", 6) + pad("FAILED", 8) + pad("P50", 11) + "P95")
+	for _, day := range days {
+		s, ok := collectStats(stats[day])[name]
+		if !ok || s.runs == 0 {
+			continue
+		}
+		l.Println(
+			cp.Prompt + pad(day, len(dateFormat)+2) + cp.Text +
+				pad(strconv.Itoa(s.runs), 6) +
+				pad(strconv.Itoa(s.failures), 8) +
+				pad(formatDuration(s.percentile(50)), 11) +
+				formatDuration(s.percentile(95)) + cp.Reset,
+		)
+	}
+}
+
+func printHistoryUsageErr() {
+	l.Println(ErrInvalidUsage)
+	l.Println("usage: history [filters] [show <id>] [stats [filters]] [prune [limit=<n>] [age=<age>]] [clear]")
+	l.Println("filters: [command=]<name> status=<ok|failed|cancelled> since=<age> author=<name> commit=<hash> limit=<n>")
+}
+
+// handle history shell command
+func handleHistoryCommand(args []string) error {
+
+	var sub string
+	if len(args) > 1 {
+		sub = args[1]
+	}
+
+	switch sub {
+	case "show":
+		if len(args) != 3 {
+			printHistoryUsageErr()
+			return ErrInvalidUsage
+		}
+
+		runs, err := loadHistory()
+		if err != nil {
+			l.Println(err)
+			return err
+		}
+
+		for _, run := range runs {
+			if run.ID == args[2] {
+				printRun(run)
+				return nil
+			}
+		}
+
+		l.Println(ErrUnknownRun, ": ", args[2])
+		return ErrUnknownRun
+
+	case "stats":
+		f, err := parseHistoryFilter(args[2:])
+		if err != nil {
+			l.Println(err)
+			printHistoryUsageErr()
+			return err
+		}
+
+		runs, err := loadHistory()
+		if err != nil {
+			l.Println(err)
+			return err
+		}
+
+		// the limit only applies to the listing
+		runs = f.apply(runs)
+		printHistoryStats(runs)
+		if f.command != "" {
+			printCommandTrend(runs, f.command)
+		}
+		return nil
+
+	case "prune":
+		conf.Lock()
+		var (
+			limit = conf.fields.RunHistoryLimit
+			age   = conf.fields.RunHistoryMaxAge
+		)
+		conf.Unlock()
+
+		for _, arg := range args[2:] {
+			var err error
+			switch {
+			case strings.HasPrefix(arg, "limit="):
+				limit, err = strconv.Atoi(strings.TrimPrefix(arg, "limit="))
+				if err != nil || limit < 0 {
+					err = errors.New("invalid limit: " + arg)
+				}
+			case strings.HasPrefix(arg, "age="):
+				age = strings.TrimPrefix(arg, "age=")
+			default:
+				err = ErrInvalidUsage
+			}
+			if err != nil {
+				l.Println(err)
+				printHistoryUsageErr()
+				return err
+			}
+		}
+
+		maxAge, err := parseAge(age)
+		if err != nil {
+			l.Println(err)
+			return err
+		}
+
+		n, err := pruneHistory(limit, maxAge)
+		if err != nil {
+			l.Println(err)
+			return err
+		}
+
+		l.Println("removed " + strconv.Itoa(n) + " runs from the history")
+		return nil
+
+	case "clear":
+		err := writeHistory(nil)
+		if err != nil {
+			l.Println(err)
+			return err
+		}
+		l.Println("cleared the run history")
+		return nil
+
+	default:
+		f, err := parseHistoryFilter(args[1:])
+		if err != nil {
+			l.Println(err)
+			printHistoryUsageErr()
+			return err
+		}
+
+		runs, err := loadHistory()
+		if err != nil {
+			l.Println(err)
+			return err
+		}
+
+		runs = f.apply(runs)
+		if f.limit > 0 && len(runs) > f.limit {
+			runs = runs[len(runs)-f.limit:]
+		}
+
+		printHistory(runs)
+		return nil
+	}
+}
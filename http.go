@@ -19,6 +19,7 @@
 package main
 
 import (
+	"crypto/tls"
 	"errors"
 	"net/http"
 	"os"
@@ -27,6 +28,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	rice "github.com/GeertJohan/go.rice"
 	"github.com/sirupsen/logrus"
@@ -56,11 +58,38 @@ func createRouter() *httprouter.Router {
 	r := httprouter.New()
 	r.HandlerFunc("GET", "/files/:type/:file", serveFiles)
 	r.HandlerFunc("GET", "/", serveHTTP)
-	r.HandlerFunc("GET", "/quit", quitHandler)
+	r.HandlerFunc("POST", "/quit", quitHandler)
 	r.HandlerFunc("GET", "/wiki", wikiIndexHandler)
 	r.HandlerFunc("GET", "/wiki/docs/:doc", wikiDocsHandler)
+	r.HandlerFunc("GET", "/wiki/commands", wikiCommandsHandler)
+	r.HandlerFunc("GET", "/api/wiki/search", wikiSearchHandler)
+	r.HandlerFunc("GET", "/api/wiki/page", wikiPageHandler)
+	r.HandlerFunc("PUT", "/api/wiki/page", wikiPageHandler)
+	r.HandlerFunc("GET", "/api/graph", graphHandler)
+	r.HandlerFunc("GET", "/api/commands", apiCommandsHandler)
+	r.HandlerFunc("POST", "/api/run", apiRunHandler)
+	r.HandlerFunc("GET", "/api/runs", apiRunsHandler)
+	r.HandlerFunc("GET", "/api/runs/:id", apiRunStatusHandler)
+	r.HandlerFunc("DELETE", "/api/runs/:id", apiRunStatusHandler)
+	r.HandlerFunc("GET", "/api/procs", apiProcsHandler)
+	r.HandlerFunc("GET", "/api/settings", settingsGetHandler)
+	r.HandlerFunc("PUT", "/api/config/:field", configSetHandler)
+	r.HandlerFunc("POST", "/api/aliases", aliasAddHandler)
+	r.HandlerFunc("DELETE", "/api/aliases/:name", aliasRemoveHandler)
+	r.HandlerFunc("POST", "/api/milestones", milestoneAddHandler)
+	r.HandlerFunc("PUT", "/api/milestones/:name", milestoneSetHandler)
+	r.HandlerFunc("DELETE", "/api/milestones/:name", milestoneRemoveHandler)
+	r.HandlerFunc("PUT", "/api/deadline", deadlineSetHandler)
+	r.HandlerFunc("DELETE", "/api/deadline", deadlineRemoveHandler)
+	r.HandlerFunc("PUT", "/api/author", authorSetHandler)
+	r.HandlerFunc("DELETE", "/api/author", authorRemoveHandler)
+	r.HandlerFunc("PUT", "/api/keys/:key", keySetHandler)
+	r.HandlerFunc("DELETE", "/api/keys/:key", keyRemoveHandler)
+	r.HandlerFunc("POST", "/api/events", eventAddHandler)
+	r.HandlerFunc("DELETE", "/api/events/:id", eventRemoveHandler)
+	r.HandlerFunc("GET", metricsPath, metricsHandler)
 	r.HandlerFunc("GET", "/glue/ws", glueWebSocketHandler)
-	r.HandlerFunc("POST", "/glue/ajax", glueAjaxHandler)
+	r.HandlerFunc("POST", glueAjaxPath, glueAjaxHandler)
 
 	return r
 }
@@ -87,7 +116,7 @@ func StartWebListener(openInBrowser bool) {
 
 		if openInBrowser {
 			if runtime.GOOS == "darwin" {
-				open("http://" + hostName + ":" + strconv.Itoa(conf.fields.PortWebPanel))
+				open(webURL("/"))
 			}
 			return
 		}
@@ -98,7 +127,38 @@ func StartWebListener(openInBrowser bool) {
 
 	distBox = rice.MustFindBox("frontend/dist")
 
-	showNote("serving on "+strconv.Itoa(conf.fields.PortWebPanel), "starting server...")
+	conf.Lock()
+	bindAddress := conf.fields.WebBindAddress
+	conf.Unlock()
+
+	if exposedBindAddress(bindAddress) {
+		cLog.Warn("the webinterface is reachable from other machines, anyone with the session token can run commands")
+	}
+
+	server := &http.Server{
+		Addr:              listenAddress(),
+		Handler:           secureHandler(createRouter()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if webTLSEnabled() {
+		cert, fp, err := loadOrCreateCertificate(tlsDir(), certificateHosts())
+		if err != nil {
+			cLog.WithError(err).Error("failed to load TLS certificate")
+			webInterfaceRunningMutex.Lock()
+			webInterfaceRunning = false
+			webInterfaceRunningMutex.Unlock()
+			return
+		}
+		server.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+		l.Println(cp.Text + "certificate fingerprint (SHA-256): " + fp)
+	}
+
+	showNote("serving on "+server.Addr, "starting server...")
+	l.Println(cp.Text + "webinterface: " + cp.Prompt + webURL("/") + cp.Reset)
 
 	socketstoreMutex.Lock()
 	socketstore = NewSocketStore()
@@ -106,9 +166,6 @@ func StartWebListener(openInBrowser bool) {
 
 	go runGlue()
 
-	// init router
-	r := createRouter()
-
 	conf.Lock()
 	if conf.fields.Debug {
 		// start asset watchers for development
@@ -118,7 +175,12 @@ func StartWebListener(openInBrowser bool) {
 	conf.Unlock()
 
 	// listen and serve
-	err := http.ListenAndServe(":"+strconv.Itoa(conf.fields.PortWebPanel), r)
+	var err error
+	if server.TLSConfig != nil {
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
 	if err != nil {
 		cLog.WithError(err).Error("failed to listen")
 	}
@@ -192,8 +254,9 @@ func runGlue() {
 	glueServerMutex.Lock()
 
 	// create a new glue server
+	// the sockets are served by the router, behind the authentication of the web server
 	glueServer = glue.NewServer(glue.Options{
-		HTTPListenAddress: ":" + strconv.Itoa(conf.fields.PortGlueServer),
+		HTTPSocketType: glue.HTTPSocketTypeNone,
 	})
 
 	// release the glue server on defer
@@ -283,8 +346,8 @@ func startSassWatcher() {
 // handle /quit route and exit application
 var quitHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
-	if r.Method != "GET" {
-		http.Error(w, "invalid method, only GET allowed", http.StatusMethodNotAllowed)
+	if r.Method != "POST" {
+		http.Error(w, "invalid method, only POST allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
@@ -0,0 +1,1210 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// ErrUnknownImportFormat means the format passed to the import builtin is not supported
+	ErrUnknownImportFormat = errors.New("unknown import format")
+
+	// ErrNoTaskFile means no file for the import format was found in the current directory
+	ErrNoTaskFile = errors.New("no task file found")
+)
+
+// import formats
+const (
+	importNPM  = "npm"
+	importJust = "just"
+	importTask = "task"
+)
+
+// importFormat describes a foreign task file
+type importFormat struct {
+
+	// what the tasks are called in the foreign format
+	kind string
+
+	// default file names
+	files []string
+
+	// parse the tasks and variables of a file
+	// tasks are returned with their original names
+	parse func(path string, contents []byte, r *migrationReport) ([]*migratedCommand, [][2]string, error)
+}
+
+var importFormats = map[string]*importFormat{
+	importNPM: {
+		kind:  "script",
+		files: []string{"package.json"},
+		parse: parsePackageJSON,
+	},
+	importJust: {
+		kind:  "recipe",
+		files: []string{"justfile", "Justfile", ".justfile"},
+		parse: parseJustfile,
+	},
+	importTask: {
+		kind:  "task",
+		files: []string{"Taskfile.yml", "Taskfile.yaml", "taskfile.yml", "taskfile.yaml"},
+		parse: parseTaskfile,
+	},
+}
+
+// sorted names of the supported import formats
+func importFormatNames() []string {
+	var names []string
+	for name := range importFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// find the file for the format in the current directory
+// returns an empty string if there is none
+func (f *importFormat) find() string {
+	for _, name := range f.files {
+		if _, err := os.Stat(name); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// read and parse a foreign task file
+func (f *importFormat) read(path string) ([]*migratedCommand, [][2]string, *migrationReport, error) {
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	r := &migrationReport{path: path}
+
+	commands, globals, err := f.parse(path, contents, r)
+	if err != nil {
+		return nil, nil, nil, errors.New(path + ": " + err.Error())
+	}
+
+	return commands, globals, r, nil
+}
+
+// import the tasks of a foreign file into the CommandsFile at path
+func importTasks(format, taskFile, path string) (*migrationReport, error) {
+
+	f, ok := importFormats[format]
+	if !ok {
+		return nil, ErrUnknownImportFormat
+	}
+	if taskFile == "" {
+		return nil, ErrNoTaskFile
+	}
+
+	commands, globals, r, err := f.read(taskFile)
+	if err != nil {
+		return nil, err
+	}
+
+	renameImported(commands, f.kind, r)
+
+	existing, data, err := takenNames(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		imported        []*migratedCommand
+		importedGlobals [][2]string
+	)
+	for _, c := range commands {
+		if r.available(c.line, f.kind, c.name, existing) {
+			imported = append(imported, c)
+		}
+	}
+	for _, g := range globals {
+		if existing[g[0]] {
+			r.add(0, "variable not migrated, the global "+g[0]+" already exists")
+			continue
+		}
+		importedGlobals = append(importedGlobals, g)
+	}
+
+	if len(imported) == 0 && len(importedGlobals) == 0 {
+		return r, ErrNothingToMigrate
+	}
+
+	return r, writeMigration(path, data, imported, importedGlobals, r)
+}
+
+// rename tasks whose names are not valid command names
+// and update the references in the dependencies
+func renameImported(commands []*migratedCommand, kind string, r *migrationReport) {
+
+	renamed := make(map[string]string, 0)
+	for _, c := range commands {
+		name := migratedCommandName(c.name)
+		if name != c.name {
+			r.add(c.line, kind+" "+c.name+" migrated as command "+name)
+			renamed[c.name] = name
+			c.name = name
+		}
+	}
+
+	for _, c := range commands {
+		for i, d := range c.dependencies {
+			fields := strings.Fields(d)
+			if name, ok := renamed[fields[0]]; ok {
+				fields[0] = name
+				c.dependencies[i] = strings.Join(fields, " ")
+			}
+		}
+	}
+}
+
+// declare an argument, the type is inferred from the default value
+// returns false if the default value can not be expressed in a declaration
+func argumentDeclaration(name, defaultValue string, optional bool) (string, bool) {
+
+	if !optional {
+		return name + ":" + argTypeString, true
+	}
+
+	if defaultValue == "" {
+		return name + ":" + argTypeString + "?", true
+	}
+
+	// the declaration syntax uses these as separators
+	if strings.ContainsAny(defaultValue, ":=") {
+		return name + ":" + argTypeString + "?", false
+	}
+
+	argType := argTypeString
+	if _, err := strconv.Atoi(defaultValue); err == nil {
+		argType = argTypeInt
+	} else if _, err := strconv.ParseFloat(defaultValue, 64); err == nil {
+		argType = argTypeFloat
+	} else if defaultValue == "true" || defaultValue == "false" {
+		argType = argTypeBool
+	}
+
+	return name + ":" + argType + "? = " + defaultValue, true
+}
+
+// line number of the first line that contains substr, starting at line from
+// returns 0 if there is none
+func lineOf(lines []string, substr string, from int) int {
+	if from < 1 {
+		from = 1
+	}
+	for i := from - 1; i < len(lines); i++ {
+		if strings.Contains(lines[i], substr) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// line number of the first indented line that declares the YAML key, starting at line from
+func lineOfKey(lines []string, key string, from int) int {
+	if from < 1 {
+		from = 1
+	}
+	for i := from - 1; i < len(lines); i++ {
+		trimmed := strings.TrimLeft(lines[i], " ")
+		if len(trimmed) == len(lines[i]) {
+			continue
+		}
+		for _, k := range []string{key, "'" + key + "'", "\"" + key + "\""} {
+			if rest := strings.TrimPrefix(trimmed, k+":"); rest != trimmed && (rest == "" || rest[0] == ' ') {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
+
+/*
+ *	npm
+ */
+
+var (
+	npmRunScript = regexp.MustCompile(`\bnpm\s+(?:run|run-script)\s+([^\s;&|]+)`)
+	npmLifecycle = regexp.MustCompile(`\bnpm\s+(test|start|stop|restart)\b`)
+)
+
+// parse the scripts of a package.json file
+// pre and post scripts are mapped to dependencies
+func parsePackageJSON(path string, contents []byte, r *migrationReport) ([]*migratedCommand, [][2]string, error) {
+
+	scripts, err := packageScripts(contents)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		lines    = strings.Split(string(contents), "\n")
+		from     = lineOf(lines, `"scripts"`, 1)
+		names    = make(map[string]bool, len(scripts))
+		commands []*migratedCommand
+	)
+	for _, s := range scripts {
+		names[s[0]] = true
+	}
+
+	for _, s := range scripts {
+
+		name, script := s[0], s[1]
+
+		c := &migratedCommand{
+			name:        name,
+			description: "imported from " + filepath.Base(path) + " script " + name,
+			line:        lineOf(lines, `"`+name+`"`, from),
+		}
+
+		if names["pre"+name] {
+			c.dependencies = append(c.dependencies, "pre"+name)
+		}
+		if base := strings.TrimPrefix(name, "post"); base != name && names[base] {
+			c.dependencies = append(c.dependencies, base)
+			r.add(c.line, "post script "+name+" depends on "+base+", run "+name+" to run both")
+		}
+
+		if strings.Contains(script, " -- ") {
+			r.add(c.line, "arguments passed with -- not converted: "+script)
+		}
+
+		script = npmRunScript.ReplaceAllStringFunc(script, func(s string) string {
+			return "zeus " + migratedCommandName(npmRunScript.FindStringSubmatch(s)[1])
+		})
+		script = npmLifecycle.ReplaceAllString(script, "zeus $1")
+
+		// npm adds the binaries of the installed packages to the PATH
+		c.exec = "export PATH=\"$PWD/node_modules/.bin:$PATH\"\n" + script
+
+		commands = append(commands, c)
+	}
+
+	return commands, nil, nil
+}
+
+// the scripts of a package.json file in the order of declaration
+func packageScripts(contents []byte) ([][2]string, error) {
+
+	dec := json.NewDecoder(bytes.NewReader(contents))
+
+	expectObject := func() error {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := t.(json.Delim); !ok || d != '{' {
+			return errors.New("expected an object")
+		}
+		return nil
+	}
+
+	if err := expectObject(); err != nil {
+		return nil, err
+	}
+
+	for dec.More() {
+
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		if key, _ := t.(string); key != "scripts" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := expectObject(); err != nil {
+			return nil, errors.New("scripts: " + err.Error())
+		}
+
+		var scripts [][2]string
+		for dec.More() {
+
+			t, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			var script string
+			if err := dec.Decode(&script); err != nil {
+				return nil, err
+			}
+
+			scripts = append(scripts, [2]string{fmt.Sprint(t), script})
+		}
+
+		return scripts, nil
+	}
+
+	return nil, nil
+}
+
+/*
+ *	just
+ */
+
+var (
+	justAssignment    = regexp.MustCompile(`^(export\s+)?([A-Za-z_][A-Za-z0-9_-]*)\s*:=\s*(.*)$`)
+	justIdentifier    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	justInterpolation = regexp.MustCompile(`\{\{(.*?)\}\}`)
+	justCommand       = regexp.MustCompile(`(^|[;&|]\s*)just(\s|$)`)
+)
+
+// interpreters of shebang recipes that map to ZEUS languages
+var shebangLanguages = map[string]string{
+	"bash":    "bash",
+	"sh":      "sh",
+	"python":  "python",
+	"python3": "python",
+	"node":    "javascript",
+	"ruby":    "ruby",
+	"lua":     "lua",
+}
+
+// a recipe and its unresolved dependencies
+type justRecipe struct {
+	cmd    *migratedCommand
+	params []string
+	deps   []string
+}
+
+// parse the recipes and variables of a justfile
+// recipe parameters are mapped to typed arguments
+func parseJustfile(path string, contents []byte, r *migrationReport) ([]*migratedCommand, [][2]string, error) {
+
+	var (
+		lines   = strings.Split(string(contents), "\n")
+		recipes []*justRecipe
+		globals [][2]string
+		comment string
+	)
+
+	for i := 0; i < len(lines); i++ {
+
+		var (
+			lineNum = i + 1
+			line    = lines[i]
+			trimmed = strings.TrimSpace(line)
+		)
+
+		if trimmed == "" {
+			comment = ""
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") {
+			comment = strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+			continue
+		}
+
+		if line[0] == ' ' || line[0] == '\t' {
+			r.add(lineNum, "indented line outside of a recipe not converted")
+			continue
+		}
+
+		recipeComment := comment
+		comment = ""
+
+		if strings.HasPrefix(trimmed, "[") {
+			r.add(lineNum, "attribute not converted: "+trimmed)
+			continue
+		}
+
+		switch strings.Fields(trimmed)[0] {
+		case "set", "alias", "import", "mod":
+			r.add(lineNum, strings.Fields(trimmed)[0]+" not converted: "+trimmed)
+			continue
+		}
+
+		if match := justAssignment.FindStringSubmatch(trimmed); match != nil {
+			globals = append(globals, [2]string{match[2], justValue(match[3], lineNum, r)})
+			continue
+		}
+
+		head, deps, ok := splitJustHeader(trimmed)
+		if !ok {
+			r.add(lineNum, "unrecognized line: "+trimmed)
+			continue
+		}
+
+		tokens := justTokens(strings.TrimPrefix(head, "@"))
+		if len(tokens) == 0 || !justIdentifier.MatchString(tokens[0]) {
+			r.add(lineNum, "unrecognized line: "+trimmed)
+			continue
+		}
+
+		rec := &justRecipe{
+			cmd: &migratedCommand{
+				name:        tokens[0],
+				description: recipeComment,
+				line:        lineNum,
+			},
+		}
+		if rec.cmd.description == "" {
+			rec.cmd.description = "imported from " + filepath.Base(path) + " recipe " + tokens[0]
+		}
+
+		// parameters
+		for _, p := range tokens[1:] {
+
+			var (
+				optional     bool
+				defaultValue string
+			)
+
+			p = strings.TrimPrefix(p, "$")
+			if p == "" {
+				continue
+			}
+
+			switch p[0] {
+			case '+', '*':
+				r.add(lineNum, "variadic parameter "+p+" migrated as a single argument")
+				optional = p[0] == '*'
+				p = p[1:]
+			}
+
+			if j := strings.Index(p, "="); j > 0 {
+				optional = true
+				defaultValue = p[j+1:]
+				p = p[:j]
+				if unquoted, ok := unquoteJust(defaultValue); ok {
+					defaultValue = unquoted
+				} else if _, err := strconv.ParseFloat(defaultValue, 64); err != nil {
+					r.add(lineNum, "default value of parameter "+p+" not converted: "+defaultValue)
+					defaultValue = ""
+				}
+			}
+
+			decl, ok := argumentDeclaration(p, defaultValue, optional)
+			if !ok {
+				r.add(lineNum, "default value of parameter "+p+" not converted: "+defaultValue)
+			}
+
+			rec.params = append(rec.params, p)
+			rec.cmd.arguments = append(rec.cmd.arguments, decl)
+		}
+
+		// dependencies
+		depTokens := justTokens(deps)
+		for j, d := range depTokens {
+			if d == "&&" {
+				r.add(lineNum, "subsequent dependencies not converted: "+strings.Join(depTokens[j+1:], " "))
+				break
+			}
+			rec.deps = append(rec.deps, d)
+		}
+
+		// body
+		var body []string
+		for i+1 < len(lines) && (strings.TrimSpace(lines[i+1]) == "" || lines[i+1][0] == ' ' || lines[i+1][0] == '\t') {
+			i++
+			body = append(body, lines[i])
+		}
+		for len(body) > 0 && strings.TrimSpace(body[len(body)-1]) == "" {
+			i--
+			body = body[:len(body)-1]
+		}
+
+		rec.cmd.exec = translateJustBody(rec, dedent(body), lineNum+1, r)
+		recipes = append(recipes, rec)
+	}
+
+	// resolve the dependencies, now that the parameters of all recipes are known
+	params := make(map[string][]string, len(recipes))
+	for _, rec := range recipes {
+		params[rec.cmd.name] = rec.params
+	}
+
+	var commands []*migratedCommand
+	for _, rec := range recipes {
+		for _, d := range rec.deps {
+
+			if !strings.HasPrefix(d, "(") {
+				rec.cmd.dependencies = append(rec.cmd.dependencies, d)
+				continue
+			}
+
+			fields := justTokens(strings.TrimSuffix(strings.TrimPrefix(d, "("), ")"))
+			if len(fields) == 0 {
+				continue
+			}
+
+			dep := fields[0]
+			for j, arg := range fields[1:] {
+				if j >= len(params[fields[0]]) {
+					r.add(rec.cmd.line, "too many arguments for dependency "+dep+": "+d)
+					break
+				}
+				value, ok := unquoteJust(arg)
+				if !ok || strings.ContainsAny(value, " \t") {
+					r.add(rec.cmd.line, "argument for dependency "+dep+" not converted: "+arg)
+					continue
+				}
+				dep += " " + params[fields[0]][j] + "=" + value
+			}
+			rec.cmd.dependencies = append(rec.cmd.dependencies, dep)
+		}
+		commands = append(commands, rec.cmd)
+	}
+
+	return commands, globals, nil
+}
+
+// split a recipe header into the name with its parameters and the dependencies
+// the colon may not be part of a quoted default value
+func splitJustHeader(line string) (head, deps string, ok bool) {
+
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ':':
+			if i+1 < len(line) && line[i+1] == '=' {
+				return "", "", false
+			}
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), true
+		}
+	}
+
+	return "", "", false
+}
+
+// split on whitespace, quoted strings and parenthesized groups are kept together
+func justTokens(s string) (tokens []string) {
+
+	var (
+		current strings.Builder
+		quote   byte
+		depth   int
+	)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case (c == ' ' || c == '\t') && depth == 0:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteByte(c)
+	}
+
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return
+}
+
+// remove the quotes of a just string literal
+func unquoteJust(s string) (string, bool) {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], true
+	}
+	return "", false
+}
+
+// convert the value of a just variable
+// backticks are evaluated by the shell, other expressions can not be converted
+func justValue(value string, line int, r *migrationReport) string {
+
+	if unquoted, ok := unquoteJust(value); ok {
+		return unquoted
+	}
+
+	if len(value) >= 2 && value[0] == '`' && value[len(value)-1] == '`' {
+		return "$(" + value[1:len(value)-1] + ")"
+	}
+
+	r.add(line, "expression not converted: "+value)
+
+	return value
+}
+
+// remove the common indentation of the lines
+func dedent(lines []string) []string {
+
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			out[i] = line[indent:]
+		} else {
+			out[i] = strings.TrimSpace(line)
+		}
+	}
+
+	return out
+}
+
+// translate the body of a recipe
+// shebang recipes are run with the language of the interpreter
+func translateJustBody(rec *justRecipe, body []string, firstLine int, r *migrationReport) string {
+
+	if len(body) == 0 {
+		return "# only runs the dependencies"
+	}
+
+	if strings.HasPrefix(body[0], "#!") {
+
+		fields := strings.Fields(strings.TrimPrefix(body[0], "#!"))
+		interpreter := filepath.Base(fields[0])
+		if interpreter == "env" && len(fields) > 1 {
+			interpreter = fields[len(fields)-1]
+		}
+
+		if lang, ok := shebangLanguages[interpreter]; ok {
+			rec.cmd.language = lang
+		} else {
+			r.add(firstLine, "interpreter not supported: "+interpreter)
+		}
+
+		body = body[1:]
+		firstLine++
+	}
+
+	var lines []string
+	for n, line := range body {
+
+		ignoreErrors := false
+
+		// the prefixes only apply to lines of recipes without a shebang
+		if rec.cmd.language == "" {
+			for len(line) > 0 && (line[0] == '@' || line[0] == '-') {
+				if line[0] == '-' {
+					ignoreErrors = true
+				}
+				line = line[1:]
+			}
+		}
+
+		line = strings.Replace(line, "{{{{", "\x00", -1)
+		line = justInterpolation.ReplaceAllStringFunc(line, func(s string) string {
+
+			expr := strings.TrimSpace(s[2 : len(s)-2])
+			if justIdentifier.MatchString(expr) && (rec.cmd.language == "" || rec.cmd.language == "bash" || rec.cmd.language == "sh") {
+				return "${" + expr + "}"
+			}
+
+			r.add(firstLine+n, "interpolation not converted: "+s)
+			return s
+		})
+		line = strings.Replace(line, "\x00", "{{", -1)
+
+		if rec.cmd.language == "" {
+			line = justCommand.ReplaceAllString(line, "${1}zeus${2}")
+		}
+
+		if ignoreErrors {
+			line += " || true"
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+/*
+ *	Taskfile
+ */
+
+var (
+	taskTemplate = regexp.MustCompile(`\{\{(.*?)\}\}`)
+	taskVariable = regexp.MustCompile(`^\.([A-Za-z_][A-Za-z0-9_]*)$`)
+)
+
+// top level keys of a Taskfile that can be converted
+var taskfileKeys = []string{"version", "vars", "env", "tasks"}
+
+// task keys that can be converted
+var taskKeys = []string{"desc", "summary", "deps", "cmds", "cmd", "vars", "env", "requires", "generates", "dir", "ignore_error", "silent"}
+
+type taskfile struct {
+	Vars  yaml.MapSlice `yaml:"vars"`
+	Env   yaml.MapSlice `yaml:"env"`
+	Tasks yaml.MapSlice `yaml:"tasks"`
+}
+
+type taskDefinition struct {
+	Desc        string        `yaml:"desc"`
+	Summary     string        `yaml:"summary"`
+	Deps        []interface{} `yaml:"deps"`
+	Cmds        []interface{} `yaml:"cmds"`
+	Cmd         string        `yaml:"cmd"`
+	Vars        yaml.MapSlice `yaml:"vars"`
+	Env         yaml.MapSlice `yaml:"env"`
+	Generates   []string      `yaml:"generates"`
+	Dir         string        `yaml:"dir"`
+	IgnoreError bool          `yaml:"ignore_error"`
+	Requires    struct {
+		Vars []interface{} `yaml:"vars"`
+	} `yaml:"requires"`
+}
+
+// parse the tasks and variables of a Taskfile
+// required variables and task variables are mapped to typed arguments
+func parseTaskfile(path string, contents []byte, r *migrationReport) ([]*migratedCommand, [][2]string, error) {
+
+	var (
+		lines   = strings.Split(string(contents), "\n")
+		doc     yaml.MapSlice
+		tf      taskfile
+		globals [][2]string
+	)
+
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return nil, nil, err
+	}
+	if err := yaml.Unmarshal(contents, &tf); err != nil {
+		return nil, nil, err
+	}
+
+	for _, item := range doc {
+		if key := fmt.Sprint(item.Key); !contains(taskfileKeys, key) {
+			r.add(lineOf(lines, key+":", 1), key+" not converted")
+		}
+	}
+
+	varsLine := lineOf(lines, "vars:", 1)
+	for _, v := range tf.Vars {
+		name := fmt.Sprint(v.Key)
+		globals = append(globals, [2]string{name, taskValue(normalizeYAML(v.Value), lineOfKey(lines, name, varsLine), r)})
+	}
+
+	if len(tf.Env) > 0 {
+		r.add(lineOf(lines, "env:", 1), "environment variables migrated as globals, they are not exported to child processes")
+	}
+	for _, v := range tf.Env {
+		name := fmt.Sprint(v.Key)
+		globals = append(globals, [2]string{name, taskValue(normalizeYAML(v.Value), 0, r)})
+	}
+
+	var (
+		tasksLine = lineOf(lines, "tasks:", 1)
+		commands  []*migratedCommand
+	)
+	for _, item := range tf.Tasks {
+
+		name := fmt.Sprint(item.Key)
+
+		c := &migratedCommand{
+			name: name,
+			line: lineOfKey(lines, name, tasksLine),
+		}
+
+		task, err := parseTaskDefinition(normalizeYAML(item.Value), c.line, r)
+		if err != nil {
+			return nil, nil, errors.New("task " + name + ": " + err.Error())
+		}
+
+		c.description = task.Desc
+		if c.description == "" {
+			c.description = strings.Split(strings.TrimSpace(task.Summary), "\n")[0]
+		}
+		if c.description == "" {
+			c.description = "imported from " + filepath.Base(path) + " task " + name
+		}
+
+		// dependencies
+		for _, d := range task.Deps {
+			if dep, ok := taskCall(d, c.line, r); ok {
+				c.dependencies = append(c.dependencies, dep)
+			}
+		}
+
+		// arguments
+		for _, v := range task.Requires.Vars {
+			if m, ok := v.(map[interface{}]interface{}); ok {
+				v = m["name"]
+			}
+			decl, _ := argumentDeclaration(fmt.Sprint(v), "", false)
+			c.arguments = append(c.arguments, decl)
+		}
+
+		var script []string
+		for _, v := range task.Vars {
+
+			name := fmt.Sprint(v.Key)
+
+			// dynamic variables are evaluated when the command runs
+			if m, ok := normalizeYAML(v.Value).(map[interface{}]interface{}); ok {
+				script = append(script, name+"=\""+taskValue(m, c.line, r)+"\"")
+				continue
+			}
+
+			value := taskValue(v.Value, c.line, r)
+			decl, ok := argumentDeclaration(name, value, true)
+			if !ok || strings.Contains(value, "$") {
+				r.add(c.line, "default value of variable "+name+" not converted: "+value)
+			}
+			c.arguments = append(c.arguments, decl)
+		}
+
+		for _, v := range task.Env {
+			script = append(script, "export "+fmt.Sprint(v.Key)+"=\""+taskValue(normalizeYAML(v.Value), c.line, r)+"\"")
+		}
+
+		if task.Dir != "" {
+			script = append(script, "cd \""+translateTaskTemplate(task.Dir, name, c.line, r)+"\"")
+		}
+
+		// commands
+		if task.Cmd != "" {
+			task.Cmds = append([]interface{}{task.Cmd}, task.Cmds...)
+		}
+		for _, cmd := range task.Cmds {
+			if line, ok := taskCommand(cmd, name, task.IgnoreError, c.line, r); ok {
+				script = append(script, line)
+			}
+		}
+
+		c.exec = strings.Join(script, "\n")
+		if c.exec == "" {
+			c.exec = "# only runs the dependencies"
+		}
+
+		// outputs
+		for _, o := range task.Generates {
+			if strings.ContainsAny(o, "*?[") {
+				r.add(c.line, "glob pattern in generates not converted: "+o)
+				continue
+			}
+			c.outputs = append(c.outputs, translateTaskTemplate(o, name, c.line, r))
+		}
+
+		commands = append(commands, c)
+	}
+
+	return commands, globals, nil
+}
+
+// convert ordered YAML maps into plain maps
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		m := make(map[interface{}]interface{}, len(v))
+		for _, item := range v {
+			m[item.Key] = normalizeYAML(item.Value)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeYAML(e)
+		}
+	}
+	return value
+}
+
+// parse a task, which can be a command, a list of commands or a task definition
+func parseTaskDefinition(value interface{}, line int, r *migrationReport) (*taskDefinition, error) {
+
+	task := &taskDefinition{}
+
+	switch v := value.(type) {
+	case string:
+		task.Cmds = []interface{}{v}
+		return task, nil
+	case []interface{}:
+		task.Cmds = v
+		return task, nil
+	case map[interface{}]interface{}:
+		var keys []string
+		for k := range v {
+			keys = append(keys, fmt.Sprint(k))
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !contains(taskKeys, k) {
+				r.add(line, k+" not converted")
+			}
+		}
+	}
+
+	b, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return task, yaml.Unmarshal(b, task)
+}
+
+// convert a variable value
+// variables with a sh key are evaluated by the shell
+func taskValue(value interface{}, line int, r *migrationReport) string {
+
+	if m, ok := value.(map[interface{}]interface{}); ok {
+		if sh, ok := m["sh"]; ok {
+			return "$(" + translateTaskTemplate(fmt.Sprint(sh), "", line, r) + ")"
+		}
+		r.add(line, "variable not converted: "+fmt.Sprint(m))
+		return ""
+	}
+
+	if value == nil {
+		return ""
+	}
+
+	return translateTaskTemplate(fmt.Sprint(value), "", line, r)
+}
+
+// convert a task call with its variables into a command chain element
+func taskCall(value interface{}, line int, r *migrationReport) (string, bool) {
+
+	m, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return fmt.Sprint(value), true
+	}
+
+	task, ok := m["task"]
+	if !ok {
+		r.add(line, "call not converted: "+fmt.Sprint(m))
+		return "", false
+	}
+
+	call := fmt.Sprint(task)
+	if vars, ok := m["vars"].(map[interface{}]interface{}); ok {
+
+		var names []string
+		for k := range vars {
+			names = append(names, fmt.Sprint(k))
+		}
+		sort.Strings(names)
+
+		for _, k := range names {
+			value := fmt.Sprint(vars[k])
+			if strings.ContainsAny(value, " \t") {
+				r.add(line, "argument for "+call+" not converted: "+k+"="+value)
+				continue
+			}
+			call += " " + k + "=" + value
+		}
+	}
+
+	return call, true
+}
+
+// convert an entry of the cmds list into a line of the script
+func taskCommand(value interface{}, task string, ignoreErrors bool, line int, r *migrationReport) (string, bool) {
+
+	var cmd string
+
+	switch v := value.(type) {
+	case string:
+		cmd = v
+	case map[interface{}]interface{}:
+		switch {
+		case v["cmd"] != nil:
+			cmd = fmt.Sprint(v["cmd"])
+			if b, ok := v["ignore_error"].(bool); ok {
+				ignoreErrors = b
+			}
+		case v["task"] != nil:
+			call, ok := taskCall(v, line, r)
+			if !ok {
+				return "", false
+			}
+			fields := strings.Fields(call)
+			fields[0] = migratedCommandName(fields[0])
+			return "zeus " + strings.Join(fields, " "), true
+		default:
+			r.add(line, "command not converted: "+fmt.Sprint(v))
+			return "", false
+		}
+	default:
+		r.add(line, "command not converted: "+fmt.Sprint(v))
+		return "", false
+	}
+
+	cmd = strings.TrimSpace(translateTaskTemplate(cmd, task, line, r))
+	if ignoreErrors {
+		cmd += " || true"
+	}
+
+	return cmd, true
+}
+
+// translate the template references of a Taskfile into the bash dialect
+func translateTaskTemplate(s, task string, line int, r *migrationReport) string {
+	return taskTemplate.ReplaceAllStringFunc(s, func(ref string) string {
+
+		expr := strings.TrimSpace(ref[2 : len(ref)-2])
+		match := taskVariable.FindStringSubmatch(expr)
+		if match == nil {
+			r.add(line, "template not converted: "+ref)
+			return ref
+		}
+
+		switch match[1] {
+		case "TASK":
+			if task != "" {
+				return task
+			}
+		case "ROOT_DIR", "USER_WORKING_DIR", "TASKFILE_DIR":
+			return "$PWD"
+		case "CLI_ARGS":
+			r.add(line, "template not converted: "+ref)
+			return ref
+		}
+
+		return "${" + match[1] + "}"
+	})
+}
+
+// print an overview of the foreign tasks in the current directory
+func printImportOverview() {
+
+	var found bool
+	for _, format := range importFormatNames() {
+
+		f := importFormats[format]
+
+		path := f.find()
+		if path == "" {
+			continue
+		}
+		found = true
+
+		commands, _, _, err := f.read(path)
+		if err != nil {
+			l.Println("failed to read "+path+":", err)
+			continue
+		}
+
+		l.Println("available " + f.kind + "s in " + path + ":")
+		for _, c := range commands {
+			line := c.name
+			if len(c.arguments) > 0 {
+				line += " (" + strings.Join(c.arguments, ", ") + ")"
+			}
+			if len(c.dependencies) > 0 {
+				line += ": " + strings.Join(c.dependencies, " ")
+			}
+			l.Println("~> " + line)
+		}
+		l.Println("")
+	}
+
+	if !found {
+		l.Println("no package.json, justfile or Taskfile.yml found in the current directory")
+	}
+}
+
+func printImportUsageErr() {
+	l.Println("usage: import [" + strings.Join(importFormatNames(), " | ") + "] [path]")
+}
+
+// handle import shell commands
+func handleImportCommand(args []string) error {
+
+	if len(args) < 2 {
+		printImportOverview()
+		return nil
+	}
+
+	f, ok := importFormats[args[1]]
+	if !ok {
+		l.Println(ErrUnknownImportFormat.Error() + ": " + args[1])
+		printImportUsageErr()
+		return ErrUnknownImportFormat
+	}
+
+	var path string
+	switch len(args) {
+	case 2:
+		path = f.find()
+		if path == "" {
+			l.Println(ErrNoTaskFile.Error() + ", expected one of: " + strings.Join(f.files, ", "))
+			return ErrNoTaskFile
+		}
+	case 3:
+		path = args[2]
+	default:
+		printImportUsageErr()
+		return ErrInvalidUsage
+	}
+
+	report, err := importTasks(args[1], path, commandsFilePath)
+	if report != nil {
+		report.print()
+	}
+	if err != nil {
+		l.Println(err)
+		return err
+	}
+
+	// parse commands
+	err = parseCommandsFile(commandsFilePath)
+	if err != nil {
+		l.Println(err)
+	}
+	return err
+}
@@ -0,0 +1,448 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	// background jobs of the interactive shell
+	jobs = newJobStore()
+
+	// ErrUnknownJob means there is no job with the requested ID
+	ErrUnknownJob = errors.New("unknown job")
+
+	// ErrNoJobs means there are no jobs
+	ErrNoJobs = errors.New("no jobs")
+)
+
+// suffix for running a command chain in the background
+const jobSuffix = "&"
+
+type jobState int
+
+const (
+	jobRunning jobState = iota
+	jobStopped
+	jobDone
+	jobFailed
+)
+
+func (s jobState) String() string {
+	switch s {
+	case jobRunning:
+		return "Running"
+	case jobStopped:
+		return "Stopped"
+	case jobDone:
+		return "Done"
+	case jobFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// job is a command chain running in the background
+// each job has its own status and therefore its own progress counters
+type job struct {
+	id   int
+	line string
+
+	// execution context of the job
+	ctx *execContext
+
+	state jobState
+	err   error
+	start time.Time
+	end   time.Time
+
+	// job has been put in the foreground with fg
+	foreground bool
+
+	// closed when the job has finished
+	done chan struct{}
+
+	sync.Mutex
+}
+
+// thread safe store for all jobs
+type jobStore struct {
+	items  map[int]*job
+	lastID int
+	sync.Mutex
+}
+
+func newJobStore() *jobStore {
+	return &jobStore{
+		items: make(map[int]*job, 0),
+	}
+}
+
+// check if all commands of the line are ZEUS commands
+func isCommandChain(line string) bool {
+	for _, part := range strings.Split(line, commandChainSeparator) {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			return false
+		}
+		if _, err := cmdMap.getCommand(fields[0]); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// start a command chain in the background
+func startJob(line string) (*job, error) {
+
+	fields := strings.Split(line, commandChainSeparator)
+
+	cmdChain, ok := validCommandChain(fields)
+	if !ok {
+		return nil, errors.New("only commands and command chains can be run in the background")
+	}
+
+	ctx := newBackgroundContext()
+
+	jobs.Lock()
+	jobs.lastID++
+	j := &job{
+		id:    jobs.lastID,
+		line:  line,
+		ctx:   ctx,
+		state: jobRunning,
+		start: time.Now(),
+		done:  make(chan struct{}),
+	}
+	jobs.items[j.id] = j
+	jobs.Unlock()
+
+	l.Println(cp.Text + "[" + strconv.Itoa(j.id) + "] " + cp.Prompt + j.line + cp.Reset)
+
+	go func() {
+		err := cmdChain.exec(ctx, fields)
+		j.finish(err)
+	}()
+
+	return j, nil
+}
+
+// mark the job as finished and notify the user
+func (j *job) finish(err error) {
+
+	j.Lock()
+	j.end = time.Now()
+	j.err = err
+	if err != nil {
+		j.state = jobFailed
+	} else {
+		j.state = jobDone
+	}
+	foreground := j.foreground
+	j.Unlock()
+
+	close(j.done)
+
+	// the fg builtin prints the result itself
+	if !foreground {
+		notify(j.String() + "\n")
+	}
+}
+
+// send a signal to all process groups of the job
+func (j *job) signal(sig syscall.Signal) {
+	for _, pid := range j.ctx.status.runningPIDs() {
+		err := syscall.Kill(-pid, sig)
+		if err != nil {
+			Log.WithError(err).Debug("failed to send "+sig.String()+" to process group: ", pid)
+		}
+	}
+}
+
+func (j *job) setState(state jobState) {
+	j.Lock()
+	j.state = state
+	j.Unlock()
+}
+
+func (j *job) finished() bool {
+	select {
+	case <-j.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns a status line for the job, for example: [1] Done  build -> test  (3.2s)
+func (j *job) String() string {
+
+	j.Lock()
+	defer j.Unlock()
+
+	var elapsed time.Duration
+	if j.end.IsZero() {
+		elapsed = time.Now().Sub(j.start)
+	} else {
+		elapsed = j.end.Sub(j.start)
+	}
+
+	out := cp.Text + pad("["+strconv.Itoa(j.id)+"]", 6) + cp.Prompt + pad(j.state.String(), 10) + cp.Text + j.line + " (" + elapsed.String() + ")"
+	if j.err != nil {
+		out += ": " + j.err.Error()
+	}
+	return out + cp.Reset
+}
+
+// get a job by its ID
+// if the ID string is empty, the most recent job will be returned
+func (js *jobStore) get(id string) (*job, error) {
+
+	js.Lock()
+	defer js.Unlock()
+
+	if len(js.items) == 0 {
+		return nil, ErrNoJobs
+	}
+
+	if id == "" {
+		var latest *job
+		for _, j := range js.items {
+			if latest == nil || j.id > latest.id {
+				latest = j
+			}
+		}
+		return latest, nil
+	}
+
+	i, err := strconv.Atoi(strings.TrimPrefix(id, "%"))
+	if err != nil {
+		return nil, errors.New("invalid job ID: " + id)
+	}
+
+	if j, ok := js.items[i]; ok {
+		return j, nil
+	}
+
+	return nil, ErrUnknownJob
+}
+
+func (js *jobStore) remove(id int) {
+	js.Lock()
+	delete(js.items, id)
+	js.Unlock()
+}
+
+// get all jobs sorted by their ID
+func (js *jobStore) list() (res []*job) {
+
+	js.Lock()
+	defer js.Unlock()
+
+	for _, j := range js.items {
+		res = append(res, j)
+	}
+
+	sort.Slice(res, func(i, k int) bool {
+		return res[i].id < res[k].id
+	})
+
+	return
+}
+
+// print all jobs and remove the finished ones
+func printJobs() {
+
+	list := jobs.list()
+	if len(list) == 0 {
+		l.Println("no jobs.")
+		return
+	}
+
+	for _, j := range list {
+		l.Println(j.String())
+		if j.finished() {
+			jobs.remove(j.id)
+		}
+	}
+}
+
+// get the job ID argument if there is one
+func jobIDArg(args []string) string {
+	if len(args) > 1 {
+		return args[1]
+	}
+	return ""
+}
+
+// handle fg shell command
+// wait for a job in the foreground, a stopped job will be continued
+// Ctrl-Z stops the job and returns to the prompt, Ctrl-C is passed to the job
+func handleFgCommand(args []string) {
+
+	j, err := jobs.get(jobIDArg(args))
+	if err != nil {
+		l.Println(err)
+		return
+	}
+
+	if j.finished() {
+		l.Println(j.String())
+		jobs.remove(j.id)
+		return
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGTSTP, os.Interrupt)
+	defer signal.Stop(sigChan)
+
+	j.Lock()
+	j.foreground = true
+	stopped := j.state == jobStopped
+	j.Unlock()
+
+	defer func() {
+		j.Lock()
+		j.foreground = false
+		j.Unlock()
+	}()
+
+	if stopped {
+		j.setState(jobRunning)
+		j.signal(syscall.SIGCONT)
+	}
+
+	l.Println(cp.Prompt + j.line + cp.Reset)
+
+	for {
+		select {
+		case <-j.done:
+			l.Println(j.String())
+			jobs.remove(j.id)
+			return
+		case sig := <-sigChan:
+			if sig == syscall.SIGTSTP {
+				j.signal(syscall.SIGSTOP)
+				j.setState(jobStopped)
+				l.Println()
+				l.Println(j.String())
+				return
+			}
+			j.signal(syscall.SIGINT)
+		}
+	}
+}
+
+// handle bg shell command
+// continue a stopped job in the background
+func handleBgCommand(args []string) {
+
+	j, err := jobs.get(jobIDArg(args))
+	if err != nil {
+		l.Println(err)
+		return
+	}
+
+	j.Lock()
+	state := j.state
+	j.Unlock()
+
+	if state != jobStopped {
+		l.Println("job " + strconv.Itoa(j.id) + " is not stopped")
+		return
+	}
+
+	j.setState(jobRunning)
+	j.signal(syscall.SIGCONT)
+
+	l.Println(cp.Text + "[" + strconv.Itoa(j.id) + "] " + cp.Prompt + j.line + " " + jobSuffix + cp.Reset)
+}
+
+// handle wait shell command
+// wait for a single job or all running jobs to finish
+// Ctrl-C stops waiting
+func handleWaitCommand(args []string) {
+
+	var waitFor []*job
+
+	if len(args) > 1 {
+		j, err := jobs.get(args[1])
+		if err != nil {
+			l.Println(err)
+			return
+		}
+		waitFor = append(waitFor, j)
+	} else {
+		for _, j := range jobs.list() {
+			j.Lock()
+			state := j.state
+			j.Unlock()
+
+			// waiting for a stopped job would block forever
+			if state == jobStopped {
+				l.Println("not waiting for stopped job: " + strconv.Itoa(j.id))
+				continue
+			}
+			waitFor = append(waitFor, j)
+		}
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt)
+	defer signal.Stop(sigChan)
+
+	for _, j := range waitFor {
+		select {
+		case <-j.done:
+		case <-sigChan:
+			l.Println()
+			return
+		}
+	}
+}
+
+// print a message in the interactive shell without messing up the current prompt
+func notify(msg string) {
+	readlineMutex.Lock()
+	defer readlineMutex.Unlock()
+
+	if rl != nil {
+		rl.Write([]byte(msg))
+		return
+	}
+	l.Print(msg)
+}
+
+// complete job IDs
+func jobIDCompleter(path string) (res []string) {
+	for _, j := range jobs.list() {
+		res = append(res, strconv.Itoa(j.id))
+	}
+	return
+}
@@ -87,19 +87,6 @@ func handleKeysCommand(args []string) {
 		return
 	}
 
-	var ok bool
-
-	// check if KeyComb exists
-	for _, s := range keyMap {
-		if s == args[2] {
-			ok = true
-		}
-	}
-	if !ok {
-		Log.Error(ErrInvalidKeyComb)
-		return
-	}
-
 	if args[1] == "set" {
 
 		if len(args) < 4 {
@@ -107,17 +94,20 @@ func handleKeysCommand(args []string) {
 			return
 		}
 
-		projectData.Lock()
-		projectData.fields.KeyBindings[args[2]] = strings.Join(args[3:], " ")
-		projectData.Unlock()
-		projectData.update()
+		err := setKeyBinding(args[2], strings.Join(args[3:], " "))
+		if err != nil {
+			Log.Error(err)
+			return
+		}
 
 		Log.Info("key binding added")
 	} else if args[1] == "remove" {
-		projectData.Lock()
-		delete(projectData.fields.KeyBindings, args[2])
-		projectData.Unlock()
-		projectData.update()
+
+		err := removeKeyBinding(args[2])
+		if err != nil {
+			Log.Error(err)
+			return
+		}
 
 		Log.Info("key binding removed")
 	} else {
@@ -125,6 +115,50 @@ func handleKeysCommand(args []string) {
 	}
 }
 
+// check if the key combination can be bound
+func validKeyComb(key string) bool {
+	for _, s := range keyMap {
+		if s == key {
+			return true
+		}
+	}
+	return false
+}
+
+// bind a command chain to a key combination
+func setKeyBinding(key, command string) error {
+
+	if !validKeyComb(key) {
+		return errors.New(ErrInvalidKeyComb.Error() + ": " + key)
+	}
+
+	if strings.TrimSpace(command) == "" {
+		return errors.New("no command for key binding: " + key)
+	}
+
+	projectData.Lock()
+	projectData.fields.KeyBindings[key] = strings.TrimSpace(command)
+	projectData.Unlock()
+	projectData.update()
+
+	return nil
+}
+
+// remove the binding of a key combination
+func removeKeyBinding(key string) error {
+
+	if !validKeyComb(key) {
+		return errors.New(ErrInvalidKeyComb.Error() + ": " + key)
+	}
+
+	projectData.Lock()
+	delete(projectData.fields.KeyBindings, key)
+	projectData.Unlock()
+	projectData.update()
+
+	return nil
+}
+
 func printKeybindingsCommmandUsageErr() {
 	l.Println(ErrInvalidUsage)
 	l.Println("usage: keys [set <KeyComb> <commandChain>] [remove <KeyComb>]")
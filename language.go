@@ -98,6 +98,12 @@ type Language struct {
 
 	CorrectErrLineNumber bool   `yaml:"correctErrLineNumber"`
 	ErrLineNumberSymbol  string `yaml:"errLineNumberSymbol"`
+
+	// formatter command, reads the code from stdin and writes the formatted code to stdout
+	Formatter string `yaml:"formatter"`
+
+	// command to check the syntax of a script without running it, the path of the script is appended
+	SyntaxCheck string `yaml:"syntaxCheck"`
 }
 
 func bashLanguage() *Language {
@@ -112,6 +118,8 @@ func bashLanguage() *Language {
 		FileExtension:        ".sh",
 		CorrectErrLineNumber: false,
 		ErrLineNumberSymbol:  "line",
+		Formatter:            "shfmt -ln bash",
+		SyntaxCheck:          "bash -n",
 	}
 }
 
@@ -127,6 +135,8 @@ func shellLanguage() *Language {
 		FileExtension:        ".sh",
 		CorrectErrLineNumber: false,
 		ErrLineNumberSymbol:  "line",
+		Formatter:            "shfmt -ln posix",
+		SyntaxCheck:          "sh -n",
 	}
 }
 
@@ -143,6 +153,8 @@ func pythonLanguage() *Language {
 		ExecOpSuffix:         "\")",
 		CorrectErrLineNumber: true,
 		ErrLineNumberSymbol:  "line",
+		Formatter:            "black -q -",
+		SyntaxCheck:          "python -m py_compile",
 	}
 }
 
@@ -160,6 +172,8 @@ func javaScriptLanguage() *Language {
 		ExecOpSuffix:         "\");",
 		CorrectErrLineNumber: false,
 		ErrLineNumberSymbol:  "line",
+		Formatter:            "prettier --stdin-filepath script.js",
+		SyntaxCheck:          "node --check",
 	}
 }
 
@@ -177,6 +191,7 @@ func rubyLanguage() *Language {
 		ExecOpSuffix:         "`",
 		CorrectErrLineNumber: true,
 		ErrLineNumberSymbol:  "-e:",
+		SyntaxCheck:          "ruby -c",
 	}
 }
 
@@ -194,5 +209,7 @@ func luaLanguage() *Language {
 		ExecOpSuffix:         "\")",
 		CorrectErrLineNumber: true,
 		ErrLineNumberSymbol:  "line",
+		Formatter:            "stylua -",
+		SyntaxCheck:          "luac -p",
 	}
 }
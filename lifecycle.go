@@ -0,0 +1,174 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// kinds of invocations
+const (
+	invocationChain      = "chain"
+	invocationCommand    = "command"
+	invocationDependency = "dependency"
+)
+
+// lifecycle hooks of the subsystems that observe invocations, in the order they are started
+// the observers are finished in reverse order
+var lifecycleHooks = []lifecycleHook{
+	traceHook,
+	historyHook,
+	streamHook,
+	activityHook,
+	commandStateHook,
+	metricsHook,
+	notificationHook,
+}
+
+// lifecycleHook is called when an invocation starts
+// it returns the context for the invocation and an observer for its events, or nil
+type lifecycleHook func(ctx *execContext, inv *invocation) (*execContext, *invocationObserver)
+
+// invocationObserver receives the events of an invocation, both functions are optional
+type invocationObserver struct {
+
+	// the state of the command changed: running, finished, failed, cancelled or skipped
+	state func(inv *invocation, state string)
+
+	// the invocation returned, the result is set
+	finished func(inv *invocation)
+}
+
+// invocation is a single execution of a command chain or a command
+// the final state and the result are computed once and shared by all observers
+type invocation struct {
+	kind string
+
+	// command name, or the names of the commands in a chain
+	name string
+
+	// command line including the arguments
+	line string
+	args []string
+
+	// command runs detached in a screen session
+	// the invocation finishes when its process exits
+	detach bool
+
+	start time.Time
+
+	// start of the command itself after its dependencies, zero if it did not start
+	execStart time.Time
+
+	// reason if the invocation was skipped
+	skipReason string
+
+	// result
+	err      error
+	state    string
+	status   string
+	exitCode int
+	message  string
+	duration time.Duration
+	stderr   string
+
+	observers []*invocationObserver
+}
+
+// startInvocation starts the lifecycle of an invocation
+// returns the context for the invocation, that carries the state of the hooks
+func (c *execContext) startInvocation(kind, name, line string, detach bool) (*execContext, *invocation) {
+
+	inv := &invocation{
+		kind:   kind,
+		name:   name,
+		line:   line,
+		args:   c.args,
+		detach: detach,
+		start:  time.Now(),
+	}
+
+	ctx := c
+	for _, hook := range lifecycleHooks {
+		var o *invocationObserver
+		ctx, o = hook(ctx, inv)
+		if o != nil {
+			inv.observers = append(inv.observers, o)
+		}
+	}
+
+	return ctx, inv
+}
+
+// start a command or dependency invocation
+func (c *execContext) startCommand(name string, dependency, detach bool) (*execContext, *invocation) {
+
+	kind := invocationCommand
+	if dependency {
+		kind = invocationDependency
+	}
+
+	return c.startInvocation(kind, name, strings.TrimSpace(name+" "+strings.Join(c.args, " ")), detach)
+}
+
+// the command starts to execute, after its dependencies
+func (inv *invocation) running() {
+	inv.execStart = time.Now()
+	inv.setState(stateRunning)
+}
+
+// the invocation will return without executing, because its outputs exist
+func (inv *invocation) skip(reason string) {
+	inv.skipReason = reason
+}
+
+func (inv *invocation) skipped() bool {
+	return inv.skipReason != ""
+}
+
+func (inv *invocation) setState(state string) {
+	for _, o := range inv.observers {
+		if o.state != nil {
+			o.state(inv, state)
+		}
+	}
+}
+
+// finish sets the result of the invocation and notifies the observers
+func (inv *invocation) finish(ctx *execContext, err error) {
+
+	inv.err = err
+	inv.duration = time.Since(inv.start)
+	inv.status, inv.exitCode, inv.message = runResult(err)
+	inv.state = finalRunState(ctx, err)
+
+	if inv.skipped() && err == nil {
+		inv.status = runSkipped
+		inv.state = stateSkipped
+	}
+
+	inv.setState(inv.state)
+
+	for i := len(inv.observers) - 1; i >= 0; i-- {
+		if f := inv.observers[i].finished; f != nil {
+			f(inv)
+		}
+	}
+}
@@ -19,266 +19,899 @@
 package main
 
 import (
-	"bytes"
+	"errors"
 	"io/ioutil"
 	"os"
+	"path"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+
+	"gopkg.in/yaml.v2"
 )
 
+// ErrNothingToMigrate means the Makefile does not contain any rules or variables that could be migrated
+var ErrNothingToMigrate = errors.New("nothing to migrate")
+
 // regular expressions to match various elements from a makefile
 var (
-	makefileTarget = regexp.MustCompile("^[^\\s]*([a-z]+):")
-	makeTargetBody = regexp.MustCompile("^[\\s\\p{Zs}]+")
-
-	global               = regexp.MustCompile("^[^\\s]*([A-Z]+)(\\s)*(=)")
-	makefileVar          = regexp.MustCompile("\\$\\([A-Z]+(_[A-Z]+)*\\)")
-	makefileShellCommand = regexp.MustCompile("\\$\\(shell(\\s*([\\w|\\S]*))*\\)")
+	makeAssignment   = regexp.MustCompile(`^([A-Za-z0-9_.-]+)\s*(\?=|::=|:=|\+=|!=|=)\s*(.*)$`)
+	makeVariableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	makeCommand      = regexp.MustCompile(`(^|[;&|(]\s*)make(\s|$)`)
+	invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
 
-	bashBuiltin = regexp.MustCompile("(@[a-z]*\\s+)")
-	makeCommand = regexp.MustCompile("make\\s+[a-z0-9]+")
+	// reference to a variable: $(NAME) or ${NAME}
+	makeReference = regexp.MustCompile(`\$\([A-Za-z0-9_.-]+\)|\$\{[A-Za-z0-9_.-]+\}`)
 )
 
-// print an overview of the available makefile commands to stdout
-func printMakefileCommandOverview() {
+// a variable assignment in a Makefile
+type makeVariable struct {
+	name  string
+	op    string
+	value string
+	line  int
+}
 
-	b, err := ioutil.ReadFile("Makefile")
-	if err != nil {
-		l.Println("failed to read Makefile:", err)
-		return
+// a rule in a Makefile
+type makeRule struct {
+	targets       []string
+	prerequisites []string
+	orderOnly     []string
+	recipe        []*makeRecipeLine
+
+	// comment in front of the rule
+	comment string
+
+	line int
+
+	// recipes of rules that can not be converted are skipped
+	skip bool
+}
+
+// a line of a recipe, including its continuation lines
+type makeRecipeLine struct {
+	text string
+	line int
+}
+
+// makefile is the parsed representation of a Makefile
+type makefile struct {
+	path      string
+	variables []*makeVariable
+	rules     []*makeRule
+	phony     map[string]bool
+	report    *migrationReport
+}
+
+// migrationReport lists all constructs of a Makefile that could not be converted
+type migrationReport struct {
+	path     string
+	issues   []*migrationIssue
+	commands []string
+	globals  []string
+}
+
+type migrationIssue struct {
+	line int
+	msg  string
+}
+
+func (r *migrationReport) add(line int, msg string) {
+	r.issues = append(r.issues, &migrationIssue{line: line, msg: msg})
+}
+
+// print the report with the file and line number of each issue
+func (r *migrationReport) print() {
+
+	l.Println(printPrompt() + "migrated " + strconv.Itoa(len(r.commands)) + " commands and " + strconv.Itoa(len(r.globals)) + " globals from " + r.path + cp.Reset)
+
+	for _, name := range r.commands {
+		l.Println(cp.Text + "~> " + cp.CmdName + name + cp.Reset)
 	}
 
-	l.Println("available GNUMake Commands:")
+	if len(r.issues) == 0 {
+		return
+	}
 
-	for _, line := range bytes.Split(b, []byte("\n")) {
+	sort.SliceStable(r.issues, func(i, j int) bool {
+		return r.issues[i].line < r.issues[j].line
+	})
 
-		if makefileTarget.Match(line) && !bytes.Contains(line, []byte("\t")) {
-			l.Println("~> " + strings.TrimSuffix(string(line), ":"))
+	l.Println(printPrompt() + strconv.Itoa(len(r.issues)) + " constructs could not be converted:" + cp.Reset)
+	for _, issue := range r.issues {
+		location := r.path
+		if issue.line > 0 {
+			location += ":" + strconv.Itoa(issue.line)
 		}
+		l.Println(cp.Prompt + location + ": " + cp.Text + issue.msg + cp.Reset)
 	}
-	l.Println("")
 }
 
-// migrate Makefile into a zeus command folder
-func migrateMakefile(zeusDirectory string) {
+// parse a Makefile
+// constructs that can not be converted are added to the report
+func parseMakefile(path string, contents []byte) *makefile {
 
 	var (
-		file            *os.File
-		cmdFile         *os.File
-		writeInProgress bool
-		err             error
-		perm            = os.FileMode(0700)
-		dir             = zeusDirectory
+		m = &makefile{
+			path:   path,
+			phony:  make(map[string]bool, 0),
+			report: &migrationReport{path: path},
+		}
+		lines   = strings.Split(string(contents), "\n")
+		rule    *makeRule
+		comment string
 	)
 
-	Log.WithField("dir", dir).Info("Makefile migration started.")
+	for i := 0; i < len(lines); i++ {
 
-	contents, err := ioutil.ReadFile("Makefile")
-	if err != nil {
-		if testingMode {
-			contents, err = ioutil.ReadFile("tests/Makefile")
-		} else {
-			Log.WithError(err).Error("unable to read Makefile")
-			return
+		lineNum := i + 1
+
+		// recipe lines start with a tab
+		if strings.HasPrefix(lines[i], "\t") && rule != nil {
+
+			text := strings.TrimPrefix(lines[i], "\t")
+
+			// continuation lines are passed to the shell together
+			for strings.HasSuffix(text, "\\") && i+1 < len(lines) {
+				i++
+				text += "\n" + strings.TrimPrefix(lines[i], "\t")
+			}
+
+			if !rule.skip {
+				rule.recipe = append(rule.recipe, &makeRecipeLine{text: text, line: lineNum})
+			}
+			continue
+		}
+
+		// join continuation lines
+		line := lines[i]
+		for strings.HasSuffix(line, "\\") && i+1 < len(lines) {
+			i++
+			line = strings.TrimRight(strings.TrimSuffix(line, "\\"), " \t") + " " + strings.TrimSpace(lines[i])
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			comment = strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+			continue
 		}
-	}
 
-	// bail out if a command with the same name exists
-	for _, line := range bytes.Split(contents, []byte("\n")) {
+		trimmed = strings.TrimSpace(stripMakeComment(trimmed))
+		if trimmed == "" {
+			comment = ""
+			continue
+		}
 
-		if makefileTarget.Match(line) && !bytes.Contains(line, []byte("\t")) {
+		rule = nil
+		ruleComment := comment
+		comment = ""
+
+		fields := strings.Fields(trimmed)
+		switch fields[0] {
+		case "include", "-include", "sinclude":
+			m.report.add(lineNum, "include not converted: "+trimmed)
+			continue
+		case "ifeq", "ifneq", "ifdef", "ifndef":
+			m.report.add(lineNum, "conditional not converted, the contents of all branches have been migrated: "+trimmed)
+			continue
+		case "else", "endif":
+			continue
+		case "define":
+			m.report.add(lineNum, "multi line variable not converted: "+strings.Join(fields[1:], " "))
+			for i+1 < len(lines) && strings.TrimSpace(lines[i]) != "endef" {
+				i++
+			}
+			continue
+		case "vpath", "unexport":
+			m.report.add(lineNum, fields[0]+" directive not converted: "+trimmed)
+			continue
+		case "override":
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "override"))
+		case "export":
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "export"))
+			if !makeAssignment.MatchString(trimmed) {
+				// all globals are visible to the commands
+				continue
+			}
+		}
 
-			targetName := strings.TrimSuffix(string(line), ":")
+		// variable assignments
+		if match := makeAssignment.FindStringSubmatch(trimmed); match != nil {
+			m.variables = append(m.variables, &makeVariable{
+				name:  match[1],
+				op:    match[2],
+				value: strings.TrimSpace(match[3]),
+				line:  lineNum,
+			})
+			continue
+		}
 
-			cmdMap.Lock()
-			if _, ok := cmdMap.items[targetName]; ok {
-				cmdMap.Unlock()
-				Log.Error("makefile target name '" + targetName + "' already taken. please choose a different name.")
-				return
+		// rules
+		if r := m.parseRule(trimmed, lineNum); r != nil {
+			r.comment = ruleComment
+			rule = r
+			if !r.skip {
+				m.rules = append(m.rules, r)
 			}
-			cmdMap.Unlock()
+			continue
 		}
+
+		m.report.add(lineNum, "unrecognized line: "+trimmed)
 	}
 
-	// create dir
-	err = os.MkdirAll(dir, perm)
-	if err != nil {
-		Log.WithError(err).Error("failed to create: ", dir)
-		return
+	return m
+}
+
+// parse a rule line
+// returns nil if the line is not a rule
+func (m *makefile) parseRule(line string, lineNum int) *makeRule {
+
+	i := strings.Index(line, ":")
+	if i <= 0 {
+		return nil
 	}
 
-	// make sure a commandsFile exists
-	_, err = os.Stat(commandsFilePath)
-	if err != nil {
-		cmdFile, err = os.Create(commandsFilePath)
-		if err != nil {
-			l.Println(err)
-			return
+	var (
+		r       = &makeRule{line: lineNum}
+		targets = strings.TrimSpace(line[:i])
+		rest    = line[i+1:]
+	)
+
+	// double colon rules are treated like normal rules
+	if strings.HasPrefix(rest, ":") {
+		m.report.add(lineNum, "double colon rule migrated as normal rule: "+targets)
+		rest = rest[1:]
+	}
+
+	// inline recipe
+	if j := strings.Index(rest, ";"); j >= 0 {
+		r.recipe = append(r.recipe, &makeRecipeLine{text: strings.TrimSpace(rest[j+1:]), line: lineNum})
+		rest = rest[:j]
+	}
+
+	r.targets = strings.Fields(targets)
+
+	switch {
+	case targets == ".PHONY":
+		for _, name := range strings.Fields(m.expand(rest)) {
+			m.phony[name] = true
 		}
-	} else {
-		cmdFile, err = os.OpenFile(commandsFilePath, os.O_APPEND|os.O_WRONLY, 0700)
-		if err != nil {
-			l.Println(err)
-			return
+		r.skip = true
+		return r
+	case strings.HasPrefix(targets, "."):
+		m.report.add(lineNum, "special target or suffix rule not converted: "+targets)
+		r.skip = true
+		return r
+	case strings.Contains(targets, "%"):
+		m.report.add(lineNum, "pattern rule not converted: "+strings.TrimSpace(line))
+		r.skip = true
+		return r
+	case strings.Contains(rest, ":"):
+		m.report.add(lineNum, "static pattern rule not converted: "+strings.TrimSpace(line))
+		r.skip = true
+		return r
+	case makeAssignment.MatchString(strings.TrimSpace(rest)):
+		m.report.add(lineNum, "target specific variable not converted: "+strings.TrimSpace(line))
+		r.skip = true
+		return r
+	}
+
+	prerequisites := rest
+	if j := strings.Index(rest, "|"); j >= 0 {
+		prerequisites = rest[:j]
+		r.orderOnly = strings.Fields(rest[j+1:])
+	}
+	r.prerequisites = strings.Fields(prerequisites)
+
+	return r
+}
+
+// remove a comment from a line, that is not part of a recipe
+func stripMakeComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] != '\\') {
+			return line[:i]
 		}
 	}
-	defer cmdFile.Close()
+	return line
+}
 
-	for _, line := range bytes.Split(contents, []byte("\n")) {
+// the values of all variables after evaluating all assignments in order
+func (m *makefile) values() map[string]string {
 
-		if writeInProgress {
+	values := make(map[string]string, len(m.variables))
+	for _, v := range m.variables {
+		switch v.op {
+		case "?=":
+			if _, ok := values[v.name]; !ok {
+				values[v.name] = v.value
+			}
+		case "+=":
+			values[v.name] = strings.TrimSpace(values[v.name] + " " + v.value)
+		default:
+			values[v.name] = v.value
+		}
+	}
 
-			// write empty lines to file
-			// they can can be used used for formatting
-			// which is perfectly valid in makefiles
-			if len(line) == 0 {
-				file.WriteString(string(line) + "\n")
-				continue
+	return values
+}
+
+// expand variable references in target and prerequisite lists
+// function calls can not be expanded and are kept
+func (m *makefile) expand(s string) string {
+
+	values := m.values()
+
+	// recursively expanded variables may reference each other
+	for depth := 0; depth < 10 && strings.Contains(s, "$"); depth++ {
+		expanded := makeReference.ReplaceAllStringFunc(s, func(ref string) string {
+			name := ref[2 : len(ref)-1]
+			if v, ok := values[name]; ok {
+				return v
 			}
+			return ref
+		})
+		if expanded == s {
+			break
+		}
+		s = expanded
+	}
 
-			// match everything preceded by whitespace
-			if makeTargetBody.Match(line) {
+	return s
+}
 
-				line = migrateLine(line)
+// translate references in a recipe line or variable value into the bash dialect
+// automatic variables are replaced with the values for the given rule
+func (m *makefile) translate(s string, target string, r *makeRule, lineNum int) string {
 
-				// write to file
-				file.WriteString(string(line) + "\n")
-			} else {
-				writeInProgress = false
+	var (
+		out     strings.Builder
+		prereqs []string
+	)
+
+	if r != nil {
+		prereqs = strings.Fields(m.expand(strings.Join(r.prerequisites, " ")))
+	}
+
+	automatic := func(c string) (string, bool) {
+		if r == nil {
+			return "", false
+		}
+		switch c {
+		case "@":
+			return target, true
+		case "<":
+			if len(prereqs) > 0 {
+				return prereqs[0], true
+			}
+			return "", true
+		case "^":
+			return strings.Join(unique(prereqs), " "), true
+		case "+":
+			return strings.Join(prereqs, " "), true
+		case "?":
+			m.report.add(lineNum, "$? migrated as all prerequisites, ZEUS does not track modification times of prerequisites")
+			return strings.Join(unique(prereqs), " "), true
+		case "|":
+			return strings.Join(r.orderOnly, " "), true
+		case "@D":
+			return path.Dir(target), true
+		case "@F":
+			return path.Base(target), true
+		case "<D", "<F":
+			if len(prereqs) == 0 {
+				return "", true
+			}
+			if c == "<D" {
+				return path.Dir(prereqs[0]), true
 			}
+			return path.Base(prereqs[0]), true
 		}
+		return "", false
+	}
+
+	for i := 0; i < len(s); i++ {
 
-		if makefileTarget.Match(line) && !makeTargetBody.Match(line) {
+		if s[i] != '$' || i == len(s)-1 {
+			out.WriteByte(s[i])
+			continue
+		}
 
-			l.Println("migrating target ~> " + strings.TrimSuffix(string(line), ":"))
+		next := s[i+1]
+		switch next {
+		case '$':
+			out.WriteByte('$')
+			i++
+		case '(', '{':
+			closing := byte(')')
+			if next == '{' {
+				closing = '}'
+			}
 
+			// find the matching closing bracket
 			var (
-				args       = strings.Split(string(line), " ")
-				targetName = strings.TrimSuffix(args[0], ":")
-				filename   = dir + "/" + targetName + ".sh"
+				depth = 0
+				end   = -1
 			)
+			for j := i + 1; j < len(s); j++ {
+				if s[j] == next {
+					depth++
+				} else if s[j] == closing {
+					depth--
+					if depth == 0 {
+						end = j
+						break
+					}
+				}
+			}
+			if end < 0 {
+				out.WriteString(s[i:])
+				return out.String()
+			}
 
-			file, err = os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
-			if err != nil {
-				Log.WithError(err).Error("failed to create file: ", filename, "permMode: ", perm)
+			inner := s[i+2 : end]
+			switch {
+			case inner == "MAKE":
+				out.WriteString("make")
+			case strings.HasPrefix(inner, "shell "):
+				out.WriteString("$(" + m.translate(strings.TrimPrefix(inner, "shell "), target, r, lineNum) + ")")
+			case makeVariableName.MatchString(inner):
+				out.WriteString("${" + inner + "}")
+			default:
+				if v, ok := automatic(inner); ok {
+					out.WriteString(v)
+				} else {
+					m.report.add(lineNum, "function or substitution reference not converted: $("+inner+")")
+					out.WriteString(s[i : end+1])
+				}
+			}
+			i = end
+		default:
+			if v, ok := automatic(string(next)); ok {
+				out.WriteString(v)
+			} else if next == '*' {
+				m.report.add(lineNum, "automatic variable not converted: $*")
+				out.WriteString("$*")
+			} else {
+				out.WriteString("${" + string(next) + "}")
 			}
-			defer file.Close()
+			i++
+		}
+	}
+
+	return out.String()
+}
 
-			file.WriteString("#!/bin/bash" + "\n\n")
+// translate the recipe of a rule into a bash script
+func (m *makefile) translateRecipe(target string, r *makeRule) string {
 
-			var depString string
-			if len(args[1:]) > 0 {
-				depString = "\n            - " + strings.Join(args[1:], "\n            - ")
+	var lines []string
+	for n, rl := range r.recipe {
+
+		var (
+			text         = strings.TrimSpace(rl.text)
+			ignoreErrors bool
+		)
+
+		// strip the prefixes that control echoing and error handling
+		for len(text) > 0 && strings.ContainsAny(text[:1], "@-+") {
+			if text[0] == '-' {
+				ignoreErrors = true
 			}
+			text = strings.TrimSpace(text[1:])
+		}
+
+		if text == "" {
+			continue
+		}
+
+		text = m.translate(text, target, r, rl.line)
+
+		// the flags of make and the directory of the sub make can not be mapped to zeus
+		if makeCommand.MatchString(text) {
+			m.report.add(rl.line, "recursive make not converted: "+text)
+		}
 
-			blockWriteEvent()
+		if ignoreErrors {
+			text += " || true"
+		}
 
-			// add entry to cmdFile
-			cmdFile.WriteString("\n" + `    ` + targetName + `:
-        description: description for command ` + targetName + `
-        help: help text for command ` + targetName + `
-        arguments:
-        dependencies:` + depString + `
-        outputs:
-`)
-			writeInProgress = true
+		// make runs every line in a separate shell
+		if n < len(r.recipe)-1 && (text == "cd" || strings.HasPrefix(text, "cd ")) && !strings.Contains(text, "&&") {
+			m.report.add(rl.line, "recipe line changes the directory for all following lines, make runs each line in a new shell")
 		}
+
+		lines = append(lines, text)
 	}
 
-	// handle globals
-	migrateGlobals(contents)
+	return strings.Join(lines, "\n")
+}
+
+// a command created from a Makefile rule or an imported task
+type migratedCommand struct {
+	name         string
+	description  string
+	language     string
+	arguments    []string
+	dependencies []string
+	outputs      []string
+	exec         string
+
+	// line of the declaration in the migrated file
+	line int
+}
+
+// name for the command of a target
+func migratedCommandName(target string) string {
+	return strings.Trim(invalidNameChars.ReplaceAllString(target, "-"), "-")
+}
+
+// convert the parsed Makefile into commands and globals
+// names that already exist are skipped
+func (m *makefile) migrate(existing map[string]bool) (commands []*migratedCommand, globals [][2]string) {
+
+	var (
+		targets = make(map[string]*makeRule, 0)
+		order   []string
+		merged  = make(map[string][]string, 0)
+		values  = m.values()
+	)
+
+	// collect all rules for each target
+	for _, r := range m.rules {
+		for _, t := range strings.Fields(m.expand(strings.Join(r.targets, " "))) {
 
-	// parse commands
-	err = parseCommandsFile(commandsFilePath)
+			if strings.Contains(t, "$") {
+				m.report.add(r.line, "target with function call not converted: "+t)
+				continue
+			}
+
+			if first, ok := targets[t]; ok {
+				if len(first.recipe) > 0 && len(r.recipe) > 0 {
+					m.report.add(r.line, "overriding recipe for target "+t+" ignored, first declared in line "+strconv.Itoa(first.line))
+				} else if len(r.recipe) > 0 {
+					targets[t] = r
+				}
+			} else {
+				targets[t] = r
+				order = append(order, t)
+			}
+
+			// prerequisites of all rules for a target are merged
+			merged[t] = append(merged[t], strings.Fields(m.expand(strings.Join(append(append([]string{}, r.prerequisites...), r.orderOnly...), " ")))...)
+		}
+	}
+
+	for _, t := range order {
+
+		r := targets[t]
+
+		name := migratedCommandName(t)
+		if name != t {
+			m.report.add(r.line, "target "+t+" migrated as command "+name)
+		}
+
+		if !m.report.available(r.line, "target", name, existing) {
+			continue
+		}
+
+		c := &migratedCommand{
+			name:        name,
+			line:        r.line,
+			description: r.comment,
+		}
+		if c.description == "" {
+			c.description = "migrated from " + filepath.Base(m.path) + " target " + t
+		}
+
+		var files []string
+		for _, p := range unique(merged[t]) {
+			if _, ok := targets[p]; ok || existing[p] {
+				c.dependencies = append(c.dependencies, migratedCommandName(p))
+			} else {
+				files = append(files, p)
+			}
+		}
+		if len(files) > 0 {
+			m.report.add(r.line, "prerequisites without a rule not converted for target "+t+": "+strings.Join(files, " "))
+		}
+
+		// file targets produce outputs
+		if !m.phony[t] {
+			c.outputs = []string{t}
+		}
+
+		c.exec = m.translateRecipe(t, r)
+		if c.exec == "" {
+			c.exec = "# only runs the dependencies"
+		}
+
+		commands = append(commands, c)
+	}
+
+	// variables are migrated to globals
+	seen := make(map[string]bool, 0)
+	for _, v := range m.variables {
+
+		if seen[v.name] {
+			continue
+		}
+
+		if !makeVariableName.MatchString(v.name) {
+			m.report.add(v.line, "variable name is not a valid identifier: "+v.name)
+			continue
+		}
+		if existing[v.name] {
+			m.report.add(v.line, "variable not migrated, the global "+v.name+" already exists")
+			continue
+		}
+
+		seen[v.name] = true
+
+		switch v.op {
+		case "?=":
+			m.report.add(v.line, "conditional assignment migrated as normal assignment: "+v.name)
+		case "!=":
+			values[v.name] = "$(shell " + v.value + ")"
+		}
+
+		globals = append(globals, [2]string{v.name, trimQuotes(m.translate(values[v.name], "", nil, v.line))})
+	}
+
+	return commands, globals
+}
+
+// YAML for a scalar value
+func yamlScalar(s string) string {
+	b, err := yaml.Marshal(s)
 	if err != nil {
-		l.Println(err)
+		return strconv.Quote(s)
 	}
+	return strings.TrimSpace(string(b))
 }
 
-func migrateLine(line []byte) []byte {
+// YAML for a migrated command, indented for the commands section
+func (c *migratedCommand) yaml() string {
+
+	var b strings.Builder
+
+	b.WriteString("\n    " + c.name + ":\n")
+	b.WriteString("        description: " + yamlScalar(c.description) + "\n")
+
+	if c.language != "" {
+		b.WriteString("        language: " + yamlScalar(c.language) + "\n")
+	}
+
+	if len(c.arguments) > 0 {
+		b.WriteString("        arguments:\n")
+		for _, a := range c.arguments {
+			b.WriteString("            - " + yamlScalar(a) + "\n")
+		}
+	}
+
+	if len(c.dependencies) > 0 {
+		b.WriteString("        dependencies:\n")
+		for _, d := range c.dependencies {
+			b.WriteString("            - " + yamlScalar(d) + "\n")
+		}
+	}
 
-	// trim whitespace
-	line = bytes.TrimSpace(line)
+	if len(c.outputs) > 0 {
+		b.WriteString("        outputs:\n")
+		for _, o := range c.outputs {
+			b.WriteString("            - " + yamlScalar(o) + "\n")
+		}
+	}
 
-	// replace '@builtin' with 'builtin'
-	line = bashBuiltin.ReplaceAll(line, bytes.TrimPrefix(bashBuiltin.Find(line), []byte("@")))
+	b.WriteString("        exec: |\n")
+	for _, line := range strings.Split(c.exec, "\n") {
+		if line == "" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString("            " + line + "\n")
+	}
 
-	// replace $(VAR) with $VAR
-	var step1 = bytes.TrimSuffix(bytes.TrimPrefix(makefileVar.Find(line), []byte("$(")), []byte(")")) // VAR
+	return b.String()
+}
 
-	// escaping the $ with another $ is important here,
-	// otherwise the regex engine treats it as an expansion!
-	var replace = append([]byte("$$"), step1...) // $VAR
+// migrate a Makefile into the CommandsFile at path
+// the migrated entries are inserted into the globals and commands sections
+func migrateMakefile(makefilePath, path string) (*migrationReport, error) {
 
-	line = makefileVar.ReplaceAll(line, replace)
+	contents, err := ioutil.ReadFile(makefilePath)
+	if err != nil {
+		return nil, err
+	}
 
-	// replace $(shell ...) commands with $(...)
-	// replace $(shell command > test) with $(command > test)
-	step1 = bytes.TrimPrefix(makefileShellCommand.Find(line), []byte("$(shell ")) // command > test)
+	m := parseMakefile(makefilePath, contents)
 
-	replace = append([]byte("$("), step1...) // $(command > test)
-	line = makefileShellCommand.ReplaceAll(line, replace)
+	existing, data, err := takenNames(path)
+	if err != nil {
+		return nil, err
+	}
 
-	// replace all make <command> with zeus <command>
-	if makeCommand.Match(line) {
-		line = bytes.Replace(line, []byte("make"), []byte("zeus"), -1)
+	commands, globals := m.migrate(existing)
+	if len(commands) == 0 && len(globals) == 0 {
+		return m.report, ErrNothingToMigrate
 	}
 
-	// convert if statements
-	if bytes.HasSuffix(line, []byte("\\")) {
+	return m.report, writeMigration(path, data, commands, globals, m.report)
+}
+
+// collect the names of the commands, globals and scripts that are already taken
+// also returns the contents of the CommandsFile at path, if it exists
+func takenNames(path string) (map[string]bool, []byte, error) {
+
+	var (
+		existing     = make(map[string]bool, 0)
+		commandsFile = newCommandsFile()
+	)
 
-		if bytes.HasPrefix(line, []byte("if")) {
-			line = bytes.TrimSuffix(line, []byte("\\"))
-			line = append(line, []byte("then")...)
-		} else if bytes.HasPrefix(line, []byte("then")) {
-			// delete line with then
-			line = []byte{}
-		} else {
-			line = bytes.TrimSuffix(line, []byte("\\"))
-			line = bytes.TrimSpace(line)
-			line = bytes.TrimSuffix(line, []byte(";"))
-			line = append([]byte("\t"), line...)
+	data, err := ioutil.ReadFile(path)
+	if err == nil {
+		if err := yaml.Unmarshal(data, commandsFile); err != nil {
+			return nil, nil, errors.New(path + ": " + err.Error())
 		}
-	} else if bytes.HasPrefix(line, []byte("fi;")) {
-		line = []byte("fi")
+	} else if !os.IsNotExist(err) {
+		return nil, nil, err
 	}
 
-	return line
+	for name := range commandsFile.Commands {
+		existing[name] = true
+	}
+	for name := range commandsFile.Globals {
+		existing[name] = true
+	}
+	if files, err := ioutil.ReadDir(scriptDir); err == nil {
+		for _, f := range files {
+			existing[strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))] = true
+		}
+	}
+
+	return existing, data, nil
+}
+
+// check if a name can be used for a migrated command
+// kind describes the migrated element in the report
+func (r *migrationReport) available(line int, kind, name string, existing map[string]bool) bool {
+
+	if existing[name] {
+		r.add(line, kind+" not migrated, the name "+name+" is already taken")
+		return false
+	}
+	if _, ok := builtins[name]; ok {
+		r.add(line, kind+" not migrated, the name "+name+" conflicts with a builtin")
+		return false
+	}
+
+	return true
+}
+
+// add the migrated commands and globals to the CommandsFile at path
+// data are the current contents of the file
+func writeMigration(path string, data []byte, commands []*migratedCommand, globals [][2]string, report *migrationReport) error {
+
+	var globalsYAML, commandsYAML strings.Builder
+	for _, g := range globals {
+		globalsYAML.WriteString("    " + g[0] + ": " + yamlScalar(g[1]) + "\n")
+		report.globals = append(report.globals, g[0])
+	}
+	for _, c := range commands {
+		commandsYAML.WriteString(c.yaml())
+		report.commands = append(report.commands, c.name)
+	}
+
+	out := string(data)
+	if len(data) == 0 {
+		out = "language: bash\n"
+	}
+	out = insertIntoSection(out, "globals", globalsYAML.String())
+	out = insertIntoSection(out, "commands", commandsYAML.String())
+
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	blockWriteEvent()
+
+	return ioutil.WriteFile(path, []byte(out), 0644)
 }
 
-func migrateGlobals(contents []byte) {
-	var globals string
-	for _, line := range bytes.Split(contents, []byte("\n")) {
-		if global.Match(line) {
-			globals += string(line) + "\n"
+// insert lines after a top level key of a YAML document
+// the section is appended if it does not exist
+func insertIntoSection(doc, key, lines string) string {
+
+	if lines == "" {
+		return doc
+	}
+
+	docLines := strings.Split(doc, "\n")
+	for i, line := range docLines {
+		if strings.TrimRight(line, " ") == key+":" {
+			rest := strings.Join(docLines[i+1:], "\n")
+			return strings.Join(docLines[:i+1], "\n") + "\n" + strings.TrimPrefix(lines, "\n") + rest
 		}
 	}
 
-	if len(globals) > 0 {
-		f, err := os.Create(zeusDir + "/globals.sh")
-		if err != nil {
-			Log.WithError(err).Error("failed to create globals file")
-			return
+	if !strings.HasSuffix(doc, "\n") {
+		doc += "\n"
+	}
+
+	return doc + "\n" + key + ":\n" + strings.TrimPrefix(lines, "\n")
+}
+
+// print an overview of the available makefile commands to stdout
+func printMakefileCommandOverview() {
+
+	b, err := ioutil.ReadFile("Makefile")
+	if err != nil {
+		l.Println("failed to read Makefile:", err)
+		return
+	}
+
+	m := parseMakefile("Makefile", b)
+
+	l.Println("available GNUMake Commands:")
+
+	for _, r := range m.rules {
+		line := strings.Join(r.targets, " ")
+		if len(r.prerequisites) > 0 {
+			line += ": " + strings.Join(r.prerequisites, " ")
 		}
-		defer f.Close()
-		f.WriteString("#!/bin/bash\n\n")
-		f.WriteString(globals + "\n")
-		l.Println("created " + zeusDir + "/globals.sh")
+		l.Println("~> " + line)
 	}
+	l.Println("")
 }
 
 // handle makefile shell commands
-func handleMakefileCommand(args []string) {
+func handleMakefileCommand(args []string) error {
 
 	if len(args) < 2 {
 		printMakefileCommandOverview()
-		return
+		return nil
 	}
 
 	if args[1] == "migrate" {
-		migrateMakefile(scriptDir)
-		return
+
+		makefilePath := "Makefile"
+		if len(args) > 2 {
+			makefilePath = args[2]
+		}
+
+		report, err := migrateMakefile(makefilePath, commandsFilePath)
+		if report != nil {
+			report.print()
+		}
+		if err != nil {
+			l.Println(err)
+			return err
+		}
+
+		// parse commands
+		err = parseCommandsFile(commandsFilePath)
+		if err != nil {
+			l.Println(err)
+		}
+		return err
 	}
 
-	Log.Error("unknown sub command: " + args[1])
+	l.Println("unknown sub command: " + args[1])
+	l.Println("usage: makefile [migrate [path]]")
+	return ErrInvalidUsage
+}
+
+// remove duplicates from a list and keep the order
+func unique(list []string) (out []string) {
+	seen := make(map[string]bool, len(list))
+	for _, e := range list {
+		if !seen[e] {
+			seen[e] = true
+			out = append(out, e)
+		}
+	}
+	return
 }
@@ -0,0 +1,294 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// counters for the metrics endpoint
+	metrics = newMetricsRegistry()
+
+	// upper bounds of the command duration histogram in seconds
+	durationBuckets = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1800, 3600}
+)
+
+const (
+	metricsPath = "/metrics"
+
+	// Prometheus text exposition format
+	metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// commandMetrics contains the counters of a command
+type commandMetrics struct {
+
+	// runs by result
+	results map[string]uint64
+
+	// cumulative bucket counts, sum and count of the successful runs
+	buckets []uint64
+	sum     float64
+	count   uint64
+}
+
+// eventKey identifies an event by path and operation
+type eventKey struct {
+	path string
+	op   string
+}
+
+// metricsRegistry collects the counters since zeus was started
+// gauges are read when the metrics are written
+type metricsRegistry struct {
+	commands map[string]*commandMetrics
+	events   map[eventKey]uint64
+	sync.Mutex
+}
+
+func newMetricsRegistry() *metricsRegistry {
+	return &metricsRegistry{
+		commands: make(map[string]*commandMetrics, 0),
+		events:   make(map[eventKey]uint64, 0),
+	}
+}
+
+// metrics of a command, registry must be locked by the caller
+func (m *metricsRegistry) command(name string) *commandMetrics {
+
+	c, ok := m.commands[name]
+	if !ok {
+		c = &commandMetrics{
+			results: make(map[string]uint64, 0),
+			buckets: make([]uint64, len(durationBuckets)),
+		}
+		m.commands[name] = c
+	}
+
+	return c
+}
+
+// count a finished command, the duration is observed for successful runs
+// detached commands are still running, so their duration is not observed
+func (m *metricsRegistry) commandFinished(name string, err error, d time.Duration, detach bool) {
+
+	status, _, _ := runResult(err)
+
+	m.Lock()
+	defer m.Unlock()
+
+	c := m.command(name)
+	c.results[status]++
+
+	if status != runOK || detach {
+		return
+	}
+
+	seconds := d.Seconds()
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			c.buckets[i]++
+		}
+	}
+	c.sum += seconds
+	c.count++
+}
+
+// count a command that was skipped because all named outputs exist
+func (m *metricsRegistry) commandSkipped(name string) {
+	m.Lock()
+	m.command(name).results[runSkipped]++
+	m.Unlock()
+}
+
+// metricsHook counts the commands that were skipped or started
+// the duration does not include the dependencies of the command
+func metricsHook(ctx *execContext, inv *invocation) (*execContext, *invocationObserver) {
+
+	if inv.kind == invocationChain {
+		return ctx, nil
+	}
+
+	return ctx, &invocationObserver{
+		finished: func(inv *invocation) {
+			switch {
+			case inv.skipped():
+				metrics.commandSkipped(inv.name)
+			case !inv.execStart.IsZero():
+				metrics.commandFinished(inv.name, inv.err, time.Since(inv.execStart), inv.detach)
+			}
+		},
+	}
+}
+
+// count a fired event
+func (m *metricsRegistry) eventFired(path, op string) {
+	m.Lock()
+	m.events[eventKey{path: path, op: op}]++
+	m.Unlock()
+}
+
+// write all metrics in the text exposition format
+func (m *metricsRegistry) write(w io.Writer) error {
+
+	b := bufio.NewWriter(w)
+
+	m.Lock()
+
+	var names []string
+	for name := range m.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writeMetricHeader(b, "zeus_command_runs_total", "counter", "Number of command runs by result.")
+	for _, name := range names {
+
+		c := m.commands[name]
+
+		var results []string
+		for result := range c.results {
+			results = append(results, result)
+		}
+		sort.Strings(results)
+
+		for _, result := range results {
+			writeMetric(b, "zeus_command_runs_total", metricLabels("command", name, "result", result), float64(c.results[result]))
+		}
+	}
+
+	writeMetricHeader(b, "zeus_command_duration_seconds", "histogram", "Duration of successful command runs.")
+	for _, name := range names {
+
+		c := m.commands[name]
+		if c.count == 0 {
+			continue
+		}
+
+		for i, bound := range durationBuckets {
+			writeMetric(b, "zeus_command_duration_seconds_bucket", metricLabels("command", name, "le", formatMetricValue(bound)), float64(c.buckets[i]))
+		}
+		writeMetric(b, "zeus_command_duration_seconds_bucket", metricLabels("command", name, "le", "+Inf"), float64(c.count))
+		writeMetric(b, "zeus_command_duration_seconds_sum", metricLabels("command", name), c.sum)
+		writeMetric(b, "zeus_command_duration_seconds_count", metricLabels("command", name), float64(c.count))
+	}
+
+	var events []eventKey
+	for key := range m.events {
+		events = append(events, key)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].path == events[j].path {
+			return events[i].op < events[j].op
+		}
+		return events[i].path < events[j].path
+	})
+
+	writeMetricHeader(b, "zeus_event_triggers_total", "counter", "Number of fired events by path and operation.")
+	for _, key := range events {
+		writeMetric(b, "zeus_event_triggers_total", metricLabels("path", key.path, "op", key.op), float64(m.events[key]))
+	}
+
+	m.Unlock()
+
+	// running processes by command
+	processMapMutex.Lock()
+	processes := make(map[string]int, 0)
+	for _, p := range processMap {
+		processes[p.Name]++
+	}
+	processMapMutex.Unlock()
+
+	names = names[:0]
+	for name := range processes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writeMetricHeader(b, "zeus_running_processes", "gauge", "Number of running processes by command.")
+	for _, name := range names {
+		writeMetric(b, "zeus_running_processes", metricLabels("command", name), float64(processes[name]))
+	}
+
+	// watchers of zeus and of the events
+	var internal, custom int
+	projectData.Lock()
+	for _, e := range projectData.fields.Events {
+		if e.Command == "internal" {
+			internal++
+		} else {
+			custom++
+		}
+	}
+	projectData.Unlock()
+
+	writeMetricHeader(b, "zeus_watchers", "gauge", "Number of file watchers by type.")
+	writeMetric(b, "zeus_watchers", metricLabels("type", "internal"), float64(internal))
+	writeMetric(b, "zeus_watchers", metricLabels("type", "event"), float64(custom))
+
+	writeMetricHeader(b, "zeus_build_info", "gauge", "ZEUS version.")
+	writeMetric(b, "zeus_build_info", metricLabels("version", version), 1)
+
+	return b.Flush()
+}
+
+func writeMetricHeader(b *bufio.Writer, name, kind, help string) {
+	b.WriteString("# HELP " + name + " " + help + "\n")
+	b.WriteString("# TYPE " + name + " " + kind + "\n")
+}
+
+func writeMetric(b *bufio.Writer, name, labels string, value float64) {
+	b.WriteString(name + labels + " " + formatMetricValue(value) + "\n")
+}
+
+// format label pairs, values are escaped
+func metricLabels(pairs ...string) string {
+
+	var labels []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels = append(labels, pairs[i]+"=\""+metricsEscaper.Replace(pairs[i+1])+"\"")
+	}
+
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+var metricsEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n")
+
+func formatMetricValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// serve the metrics for Prometheus
+var metricsHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+	w.Header().Set("Content-Type", metricsContentType)
+
+	err := metrics.write(w)
+	if err != nil {
+		Log.WithError(err).Error("failed to write metrics")
+	}
+})
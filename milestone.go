@@ -19,11 +19,23 @@
 package main
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 	"time"
 )
 
+var (
+	// ErrUnknownMilestone means there is no milestone with the name
+	ErrUnknownMilestone = errors.New("unknown milestone")
+
+	// ErrMilestoneExists means there is already a milestone with the name
+	ErrMilestoneExists = errors.New("milestone exists")
+
+	// ErrInvalidPercentage means the value is not a number between 0 and 100
+	ErrInvalidPercentage = errors.New("invalid percentage, valid values are 0-100")
+)
+
 // milestone represents a project milestone
 type milestone struct {
 	Name            string
@@ -62,14 +74,22 @@ func handleMilestonesCommand(args []string) {
 			printMilestoneUsageErr()
 			return
 		}
-		removeMilestone(args[2])
+		err := removeMilestone(args[2])
+		if err != nil {
+			Log.WithError(err).Error("failed to remove milestone")
+			return
+		}
+		Log.Info("removed milestone ", args[2])
 		return
 	case "set":
 		if len(args) < 4 {
 			printMilestoneUsageErr()
 			return
 		}
-		setMilestone(args[2], args[3])
+		err := setMilestone(args[2], args[3])
+		if err != nil {
+			Log.WithError(err).Error("failed to set milestone")
+		}
 		return
 	case "add":
 		if len(args) < 3 {
@@ -106,43 +126,53 @@ func addMilestone(args []string) {
 		return
 	}
 
+	err := createMilestone(args[0], args[1], strings.Join(args[2:], " "))
+	if err != nil {
+		Log.WithError(err).Error("failed to add milestone")
+		return
+	}
+
+	Log.Info("added milestone ", args[0])
+}
+
+// validate and add a milestone, the date is parsed with the configured date format
+func createMilestone(name, date, description string) error {
+
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return errors.New("invalid milestone name: " + name)
+	}
+
 	conf.Lock()
 	format := conf.fields.DateFormat
 	conf.Unlock()
 
 	// check if date is valid
-	t, err := time.Parse(format, args[1])
+	t, err := time.Parse(format, date)
 	if err != nil {
-		Log.WithError(err).Error("failed to parse date")
-		return
-	}
-
-	// create milestone
-	var m *milestone
-
-	// check if theres a description
-	if len(args) >= 3 {
-		m = newMilestone(args[0], t, args[2:])
-	} else {
-		m = newMilestone(args[0], t, []string{})
+		return errors.New("failed to parse date: " + err.Error())
 	}
 
 	projectData.Lock()
-	projectData.fields.Milestones = append(projectData.fields.Milestones, m)
+	for _, m := range projectData.fields.Milestones {
+		if m.Name == name {
+			projectData.Unlock()
+			return errors.New(ErrMilestoneExists.Error() + ": " + name)
+		}
+	}
+	projectData.fields.Milestones = append(projectData.fields.Milestones, newMilestone(name, t, strings.Fields(description)))
 	projectData.Unlock()
 	projectData.update()
 
-	Log.Info("added milestone ", args[0])
+	return nil
 }
 
 // update a milestones status
 // valid values are 0-100
-func setMilestone(name, percent string) {
+func setMilestone(name, percent string) error {
 
 	p, err := strconv.ParseInt(percent, 10, 0)
 	if err != nil || p > 100 || p < 0 {
-		printMilestoneUsageErr()
-		return
+		return ErrInvalidPercentage
 	}
 
 	var ok bool
@@ -157,20 +187,16 @@ func setMilestone(name, percent string) {
 	projectData.Unlock()
 
 	if !ok {
-		Log.Info("unknown milestone: ", name)
-		return
+		return errors.New(ErrUnknownMilestone.Error() + ": " + name)
 	}
 
 	projectData.update()
+
+	return nil
 }
 
 // remove a milestone from project data
-func removeMilestone(name string) {
-
-	if name == "" {
-		Log.Error("no name supplied")
-		return
-	}
+func removeMilestone(name string) error {
 
 	projectData.Lock()
 	for i, m := range projectData.fields.Milestones {
@@ -178,11 +204,12 @@ func removeMilestone(name string) {
 			projectData.fields.Milestones = append(projectData.fields.Milestones[:i], projectData.fields.Milestones[i+1:]...)
 			projectData.Unlock()
 			projectData.update()
-			Log.Info("remove milestone ", name)
-			return
+			return nil
 		}
 	}
 	projectData.Unlock()
+
+	return errors.New(ErrUnknownMilestone.Error() + ": " + name)
 }
 
 // print all milestones to stdout
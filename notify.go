@@ -0,0 +1,428 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	gosxnotifier "github.com/deckarep/gosx-notifier"
+)
+
+var (
+	// ErrInvalidNotification means a notification sink in the config is invalid
+	ErrInvalidNotification = errors.New("invalid notification")
+
+	// ErrNotifySendMissing means notify-send is not installed
+	ErrNotifySendMissing = errors.New("notify-send not found, install libnotify for desktop notifications")
+
+	// webhooks and desktop notifications that are still being delivered
+	pendingNotifications sync.WaitGroup
+)
+
+// notification sink types
+const (
+	notifyWebhook = "webhook"
+	notifyDesktop = "desktop"
+	notifyBell    = "bell"
+)
+
+const (
+	// number of stderr lines in the notification
+	stderrTailLines = 20
+
+	// maximum size of the stderr tail
+	stderrTailSize = 4096
+
+	// number of stderr lines in desktop notifications
+	desktopStderrLines = 3
+
+	// timeout for delivering a webhook
+	webhookTimeout = 10 * time.Second
+)
+
+// notificationSink is a receiver for notifications about finished commands, configured in the config
+// commands and results filter the notifications, empty filters match everything
+type notificationSink struct {
+	Type        string            `yaml:"type"`
+	URL         string            `yaml:"url,omitempty"`
+	Body        string            `yaml:"body,omitempty"`
+	Headers     map[string]string `yaml:"headers,omitempty"`
+	Commands    []string          `yaml:"commands,omitempty"`
+	Results     []string          `yaml:"results,omitempty"`
+	MinDuration string            `yaml:"minDuration,omitempty"`
+}
+
+// notification is the payload for the sinks
+type notification struct {
+	Command  string    `json:"command"`
+	Args     []string  `json:"args"`
+	Result   string    `json:"result"`
+	ExitCode int       `json:"exitCode"`
+	Duration string    `json:"duration"`
+	Seconds  float64   `json:"seconds"`
+	Error    string    `json:"error,omitempty"`
+	Stderr   string    `json:"stderr"`
+	Project  string    `json:"project"`
+	Host     string    `json:"host"`
+	Time     time.Time `json:"time"`
+
+	duration time.Duration
+}
+
+// create the notification for a finished command
+func newNotification(name string, args []string, err error, d time.Duration, stderr string) *notification {
+
+	status, exitCode, message := runResult(err)
+	host, _ := os.Hostname()
+
+	if args == nil {
+		args = []string{}
+	}
+
+	return &notification{
+		Command:  name,
+		Args:     args,
+		Result:   status,
+		ExitCode: exitCode,
+		Duration: formatDuration(d),
+		Seconds:  d.Seconds(),
+		Error:    message,
+		Stderr:   stderrTail(stderr),
+		Project:  filepath.Base(workingDir),
+		Host:     host,
+		Time:     time.Now(),
+		duration: d,
+	}
+}
+
+// last lines of the stderr output
+func stderrTail(stderr string) string {
+
+	lines := strings.Split(strings.TrimRight(stderr, "\n"), "\n")
+	if len(lines) > stderrTailLines {
+		lines = lines[len(lines)-stderrTailLines:]
+	}
+
+	tail := strings.Join(lines, "\n")
+	if len(tail) > stderrTailSize {
+		tail = tail[len(tail)-stderrTailSize:]
+		for len(tail) > 0 && !utf8RuneStart(tail[0]) {
+			tail = tail[1:]
+		}
+	}
+
+	return tail
+}
+
+// check the sink for configuration errors
+func (s *notificationSink) check() error {
+
+	switch s.Type {
+	case notifyWebhook:
+		if s.URL == "" {
+			return errors.New(ErrInvalidNotification.Error() + ": webhook without url")
+		}
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New(ErrInvalidNotification.Error() + ": invalid webhook url: " + s.URL)
+		}
+		if _, err := s.template(); err != nil {
+			return errors.New(ErrInvalidNotification.Error() + ": invalid webhook body: " + err.Error())
+		}
+	case notifyDesktop, notifyBell:
+	default:
+		return errors.New(ErrInvalidNotification.Error() + ": unknown type " + strconv.Quote(s.Type) + ", use webhook, desktop or bell")
+	}
+
+	for _, r := range s.Results {
+		switch r {
+		case runOK, runFailed, runCancelled:
+		default:
+			return errors.New(ErrInvalidNotification.Error() + ": unknown result " + strconv.Quote(r) + ", use ok, failed or cancelled")
+		}
+	}
+
+	if s.MinDuration != "" {
+		if _, err := time.ParseDuration(s.MinDuration); err != nil {
+			return errors.New(ErrInvalidNotification.Error() + ": invalid minDuration: " + s.MinDuration)
+		}
+	}
+
+	return nil
+}
+
+// check if the notification passes the filters of the sink
+func (s *notificationSink) match(n *notification) bool {
+
+	if len(s.Commands) > 0 && !contains(s.Commands, n.Command) {
+		return false
+	}
+
+	if len(s.Results) > 0 && !contains(s.Results, n.Result) {
+		return false
+	}
+
+	if s.MinDuration != "" {
+		if d, err := time.ParseDuration(s.MinDuration); err == nil && n.duration < d {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parse the body template of a webhook, nil if the payload is sent as JSON
+func (s *notificationSink) template() (*template.Template, error) {
+
+	if s.Body == "" {
+		return nil, nil
+	}
+
+	return template.New("body").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(s.Body)
+}
+
+// render the webhook body
+func (s *notificationSink) body(n *notification) ([]byte, error) {
+
+	t, err := s.template()
+	if err != nil {
+		return nil, err
+	}
+
+	if t == nil {
+		return json.Marshal(n)
+	}
+
+	var b bytes.Buffer
+	err = t.Execute(&b, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// post the notification to the webhook
+func (s *notificationSink) post(n *notification) error {
+
+	body, err := s.body(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "zeus/"+version)
+	for key, value := range s.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{Timeout: webhookTimeout}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return errors.New("webhook responded with " + resp.Status)
+	}
+
+	return nil
+}
+
+// title and text for a desktop notification
+func (n *notification) desktopText() (string, string) {
+
+	title := n.Command + " finished"
+	text := "after " + n.Duration
+
+	switch n.Result {
+	case runFailed:
+		title = n.Command + " failed"
+		text = "exit code " + strconv.Itoa(n.ExitCode) + " after " + n.Duration
+	case runCancelled:
+		title = n.Command + " cancelled"
+	}
+
+	if n.Stderr != "" && n.Result != runOK {
+		lines := strings.Split(n.Stderr, "\n")
+		if len(lines) > desktopStderrLines {
+			lines = lines[len(lines)-desktopStderrLines:]
+		}
+		text += "\n" + strings.Join(lines, "\n")
+	}
+
+	return title, text
+}
+
+// show a desktop notification
+// macOS uses the notification center, other systems use notify-send if it is installed
+func desktopNotify(subtitle, text, link string, urgent bool) error {
+
+	if runtime.GOOS == "darwin" {
+
+		note := gosxnotifier.NewNotification(text)
+		note.Title = "ZEUS"
+		note.Subtitle = subtitle
+
+		// only one notification is shown, it replaces the previous one
+		note.Group = "com.zeus"
+		note.Sender = "com.apple.Terminal"
+
+		// opened when the notification is clicked
+		note.Link = link
+
+		return note.Push()
+	}
+
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return ErrNotifySendMissing
+	}
+
+	urgency := "normal"
+	if urgent {
+		urgency = "critical"
+	}
+
+	return exec.Command(path, "--app-name=ZEUS", "--urgency="+urgency, "ZEUS: "+subtitle, text).Run()
+}
+
+// send the notification to all matching sinks of the config
+// the bell is written to the output of the command,
+// webhooks and desktop notifications are delivered in the background, so a slow sink does not block the next command
+func notifySinks(n *notification, bell io.Writer) {
+
+	conf.Lock()
+	sinks := make([]*notificationSink, len(conf.fields.Notifications))
+	copy(sinks, conf.fields.Notifications)
+	conf.Unlock()
+
+	for _, s := range sinks {
+
+		if s == nil || !s.match(n) {
+			continue
+		}
+
+		switch s.Type {
+		case notifyBell:
+			bell.Write([]byte{'\a'})
+
+		case notifyWebhook:
+			pendingNotifications.Add(1)
+			go func(s *notificationSink) {
+				defer pendingNotifications.Done()
+				err := s.post(n)
+				if err != nil {
+					Log.WithError(err).Error("failed to deliver webhook to " + s.URL)
+				}
+			}(s)
+
+		case notifyDesktop:
+			pendingNotifications.Add(1)
+			go func() {
+				defer pendingNotifications.Done()
+				title, text := n.desktopText()
+				err := desktopNotify(title, text, "", n.Result != runOK)
+				if err == ErrNotifySendMissing {
+					Log.Debug(err)
+				} else if err != nil {
+					Log.WithError(err).Error("failed to show desktop notification")
+				}
+			}()
+
+		default:
+			Log.Error(s.check())
+		}
+	}
+}
+
+// wait until the pending notifications are delivered, at most for the webhook timeout
+// called before zeus exits
+func waitNotifications() {
+
+	done := make(chan struct{})
+	go func() {
+		pendingNotifications.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(webhookTimeout):
+		Log.Warn("exiting before all notifications were delivered")
+	}
+}
+
+// notificationHook sends a notification for each finished command, if any sinks are configured
+// dependencies are reported by the command that invoked them
+// detached commands are not reported
+func notificationHook(ctx *execContext, inv *invocation) (*execContext, *invocationObserver) {
+
+	if inv.kind != invocationCommand || inv.detach {
+		return ctx, nil
+	}
+
+	return ctx, &invocationObserver{
+		finished: func(inv *invocation) {
+
+			if inv.skipped() {
+				return
+			}
+
+			conf.Lock()
+			enabled := len(conf.fields.Notifications) > 0
+			conf.Unlock()
+
+			if !enabled {
+				return
+			}
+
+			notifySinks(newNotification(inv.name, inv.args, inv.err, inv.duration, inv.stderr), ctx.stdout)
+		},
+	}
+}
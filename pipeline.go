@@ -0,0 +1,459 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+var (
+	// ErrEmptyPipelineStage means there is nothing between two pipe symbols
+	ErrEmptyPipelineStage = errors.New("empty pipeline stage")
+
+	// ErrMissingRedirectTarget means there is no filename after a redirection
+	ErrMissingRedirectTarget = errors.New("missing redirection target")
+
+	// ErrRedirectNotLast means the output of a stage in the middle of a pipeline was redirected into a file
+	ErrRedirectNotLast = errors.New("output can only be redirected into a file for the last command of a pipeline")
+)
+
+// operators for pipelines and redirections
+const (
+	pipeOperator           = '|'
+	redirectOperator       = '>'
+	stderrToStdoutOperator = "2>&1"
+)
+
+// a pipeline connects the output of each stage with the input of the next one
+// stages can be ZEUS commands, command chains or shell commands
+// example: build -> test | grep FAIL > failures.txt
+type pipeline struct {
+	stages []*pipelineStage
+
+	// output of the last stage is written into this file
+	outFile   string
+	appendOut bool
+}
+
+type pipelineStage struct {
+
+	// command line without the redirections
+	line string
+
+	// stderr is redirected into stdout
+	stderrToStdout bool
+
+	// redirections found in the stage
+	outFile   string
+	appendOut bool
+}
+
+// pipelineError is returned when a stage of a pipeline failed
+type pipelineError struct {
+	stage string
+	err   error
+}
+
+func (e *pipelineError) Error() string {
+	return e.stage + ": " + e.err.Error()
+}
+
+// exit code for a failed pipeline, like the shell
+// the exit status of the failed stage, 128 + signal for killed processes and 1 for all other errors
+func pipelineExitCode(err error) int {
+
+	if err == nil {
+		return 0
+	}
+
+	if e, ok := err.(*pipelineError); ok {
+		err = e.err
+	}
+
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return 128 + int(ws.Signal())
+		}
+		if code := exitErr.ExitCode(); code > 0 {
+			return code
+		}
+	}
+
+	return 1
+}
+
+// check if the commandline arguments are a pipeline
+// like command chains, a pipeline must be supplied as a single quoted argument
+// so arguments that contain pipe or redirection operators are never split
+func isPipelineArgs(args []string) bool {
+	return len(args) == 1 && !strings.HasPrefix(args[0], "-") && isPipeline(args[0])
+}
+
+// check if a line contains pipe or redirection operators outside of quotes
+// the command chain separator -> is not a redirection
+// lines starting with a builtin are never treated as pipeline
+// because builtins like alias or events take command lines as arguments
+func isPipeline(line string) bool {
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+
+	if _, ok := builtins[fields[0]]; ok {
+		return false
+	}
+
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == pipeOperator:
+			return true
+		case c == redirectOperator:
+			if i == 0 || line[i-1] != '-' {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// parse a pipeline from a command line
+func parsePipeline(line string) (*pipeline, error) {
+
+	var (
+		p   = &pipeline{}
+		raw = splitPipeline(line)
+	)
+
+	for i, r := range raw {
+
+		stage, err := parsePipelineStage(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if stage.outFile != "" {
+			if i != len(raw)-1 {
+				return nil, ErrRedirectNotLast
+			}
+			p.outFile = stage.outFile
+			p.appendOut = stage.appendOut
+		}
+
+		p.stages = append(p.stages, stage)
+	}
+
+	return p, nil
+}
+
+// split the line at all pipe operators outside of quotes
+// a double pipe is the logical OR of the shell and will not be split
+func splitPipeline(line string) (stages []string) {
+
+	var (
+		quote rune
+		start int
+		runes = []rune(line)
+	)
+
+	for i, c := range runes {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == pipeOperator:
+			if (i > 0 && runes[i-1] == pipeOperator) || (i < len(runes)-1 && runes[i+1] == pipeOperator) {
+				continue
+			}
+			stages = append(stages, string(runes[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(stages, string(runes[start:]))
+}
+
+// parse the redirections of a single stage
+func parsePipelineStage(raw string) (*pipelineStage, error) {
+
+	var (
+		stage = &pipelineStage{}
+		line  []rune
+		quote rune
+		runes = []rune(raw)
+	)
+
+	for i := 0; i < len(runes); i++ {
+
+		c := runes[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(string(runes[i:]), stderrToStdoutOperator):
+			stage.stderrToStdout = true
+			i += len(stderrToStdoutOperator) - 1
+			continue
+		case c == redirectOperator && (i == 0 || runes[i-1] != '-'):
+
+			stage.appendOut = false
+			if i < len(runes)-1 && runes[i+1] == redirectOperator {
+				stage.appendOut = true
+				i++
+			}
+
+			// skip whitespace and read the target
+			i++
+			for i < len(runes) && (runes[i] == ' ' || runes[i] == '\t') {
+				i++
+			}
+
+			var target []rune
+			for i < len(runes) && runes[i] != ' ' && runes[i] != '\t' {
+				target = append(target, runes[i])
+				i++
+			}
+
+			stage.outFile = strings.Trim(string(target), "\"'")
+			if stage.outFile == "" {
+				return nil, ErrMissingRedirectTarget
+			}
+			continue
+		}
+
+		line = append(line, c)
+	}
+
+	stage.line = strings.TrimSpace(string(line))
+	if stage.line == "" {
+		return nil, ErrEmptyPipelineStage
+	}
+
+	return stage, nil
+}
+
+// parse and run a pipeline
+// the returned error is the error of the last stage that failed
+func runPipeline(line string) error {
+
+	p, err := parsePipeline(line)
+	if err != nil {
+		return err
+	}
+
+	return p.run()
+}
+
+// run all stages of the pipeline concurrently
+func (p *pipeline) run() error {
+
+	// pure shell pipelines are passed to the shell as a whole
+	if !p.containsZeusCommand() {
+		return passCommandToShell(p.String(), []string{})
+	}
+
+	var (
+		wg     sync.WaitGroup
+		errs   = make([]error, len(p.stages))
+		stdin  = make([]*os.File, len(p.stages))
+		stdout = make([]*os.File, len(p.stages))
+	)
+
+	stdin[0] = os.Stdin
+	stdout[len(p.stages)-1] = os.Stdout
+
+	// open the output file
+	if p.outFile != "" {
+
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if p.appendOut {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+
+		f, err := os.OpenFile(p.outFile, flags, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		stdout[len(p.stages)-1] = f
+	}
+
+	// connect each stage with the next one
+	for i := 0; i < len(p.stages)-1; i++ {
+		r, w, err := os.Pipe()
+		if err != nil {
+			for k := 0; k < i; k++ {
+				stdout[k].Close()
+				stdin[k+1].Close()
+			}
+			return err
+		}
+		stdout[i] = w
+		stdin[i+1] = r
+	}
+
+	for i, stage := range p.stages {
+
+		ctx := newExecContext()
+		ctx.stdin = stdin[i]
+		ctx.stdout = stdout[i]
+		ctx.stderr = os.Stderr
+		if stage.stderrToStdout {
+			ctx.stderr = stdout[i]
+		}
+
+		wg.Add(1)
+		go func(i int, stage *pipelineStage, ctx *execContext) {
+			defer wg.Done()
+
+			errs[i] = stage.run(ctx)
+
+			// signal EOF to the next stage
+			if i < len(p.stages)-1 {
+				stdout[i].Close()
+			}
+
+			// a writing stage in front of this one must not block
+			if i > 0 {
+				stdin[i].Close()
+			}
+		}(i, stage, ctx)
+	}
+
+	wg.Wait()
+
+	// like the pipefail option of the bash:
+	// the pipeline fails if any of its stages failed
+	// stages that were terminated because the next stage stopped reading are not treated as failed
+	for i := len(errs) - 1; i >= 0; i-- {
+		if errs[i] != nil && !(i < len(errs)-1 && isBrokenPipe(errs[i])) {
+			return &pipelineError{stage: p.stages[i].line, err: errs[i]}
+		}
+	}
+
+	return nil
+}
+
+// check if the process was killed by SIGPIPE
+func isBrokenPipe(err error) bool {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return ws.Signaled() && ws.Signal() == syscall.SIGPIPE
+		}
+	}
+	return false
+}
+
+// run a single stage, ZEUS commands and aliases are executed directly
+// everything else is passed to the shell
+func (stage *pipelineStage) run(ctx *execContext) error {
+
+	line, err := expandAliasLine(stage.line)
+	if err != nil {
+		return err
+	}
+
+	if isZeusCommandLine(line) {
+
+		fields := strings.Split(line, commandChainSeparator)
+
+		cmdChain, ok := validCommandChain(fields)
+		if !ok {
+			return errors.New("invalid command chain")
+		}
+
+		return cmdChain.exec(ctx, fields)
+	}
+
+	cmd := exec.Command("/bin/bash", "-e", "-c", line)
+	cmd.Stdin = ctx.stdin
+	cmd.Stdout = ctx.stdout
+	cmd.Stderr = ctx.stderr
+	cmd.Env = ctx.env
+
+	return cmd.Run()
+}
+
+// check if any of the stages is a ZEUS command
+func (p *pipeline) containsZeusCommand() bool {
+	for _, stage := range p.stages {
+		line, err := expandAliasLine(stage.line)
+		if err == nil && isZeusCommandLine(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the pipeline including its redirections
+func (p *pipeline) String() string {
+
+	var stages []string
+	for _, stage := range p.stages {
+		s := stage.line
+		if stage.stderrToStdout {
+			s += " " + stderrToStdoutOperator
+		}
+		stages = append(stages, s)
+	}
+
+	out := strings.Join(stages, " | ")
+	if p.outFile != "" {
+		if p.appendOut {
+			out += " >> " + p.outFile
+		} else {
+			out += " > " + p.outFile
+		}
+	}
+
+	return out
+}
+
+// check if the first field of a line is a ZEUS command
+func isZeusCommandLine(line string) bool {
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+
+	_, err := cmdMap.getCommand(fields[0])
+	return err == nil
+}
@@ -83,6 +83,13 @@ func deleteProcessByPID(pid int) {
 	processMapMutex.Unlock()
 }
 
+// exit after delivering the notifications and writing the execution trace
+func exit(code int) {
+	waitNotifications()
+	flushTrace()
+	os.Exit(code)
+}
+
 // cleanup before we leave
 // used only on unrecoverable errors
 func cleanup() {
@@ -90,6 +97,12 @@ func cleanup() {
 	// kill all spawned processes
 	clearProcessMap()
 
+	// deliver the notifications for the finished commands
+	waitNotifications()
+
+	// write the execution trace
+	flushTrace()
+
 	// close readline
 	if rl != nil {
 		err := rl.Close()
@@ -160,17 +173,16 @@ func handleProcsCommand(args []string) {
 	switch args[1] {
 	// detach any command async
 	case "detach":
-		if cmd, ok := cmdMap.items[args[2]]; ok {
-			cmd.async = true
-			err := cmd.Run(args[3:], true)
-			if err != nil {
-				Log.WithError(err).Error("failed to run command. args: ", args[3:])
-			}
-			time.Sleep(100 * time.Millisecond)
-			cmd.async = false
-		} else {
-			l.Println("invalid command:", args[2])
+		cmd, err := cmdMap.getCommand(args[2])
+		if err != nil {
+			l.Println(err)
+			return
+		}
+		err = cmd.Run(newExecContext().withArgs(args[3:]), true)
+		if err != nil {
+			Log.WithError(err).Error("failed to run command. args: ", args[3:])
 		}
+		time.Sleep(100 * time.Millisecond)
 	// attach to a runnning async process with screen -r
 	case "attach":
 		cmd := exec.Command("screen", "-r", args[2])
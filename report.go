@@ -18,8 +18,429 @@
 
 package main
 
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/russross/blackfriday"
+)
+
 var (
 	// format for TimeStamp in report
 	timestampFormat = "[Mon Jan 2 15:04:05 2006]"
-	// reportFileHandle *os.File
+
+	// ErrInvalidReportFormat means the report format is not markdown or html
+	ErrInvalidReportFormat = errors.New("invalid report format, use markdown or html")
+)
+
+// report formats
+const (
+	reportMarkdown = "markdown"
+	reportHTML     = "html"
 )
+
+const (
+	// number of git commits in the report
+	reportCommits = 10
+
+	// number of runs in the report
+	reportRuns = 10
+
+	// width of the milestone progress bars
+	reportBarWidth = 20
+)
+
+// data for the HTML report template
+type reportPageData struct {
+	Title   string
+	Content template.HTML
+}
+
+func printReportUsageErr() {
+	l.Println(ErrInvalidUsage)
+	l.Println("usage: report [markdown|html] [file]")
+}
+
+// handle report shell command
+// without a file the report is printed, the format of a file is detected by its extension
+func handleReportCommand(args []string) error {
+
+	var format, path string
+	for _, arg := range args[1:] {
+		switch {
+		case arg == reportMarkdown || arg == reportHTML:
+			if format != "" {
+				printReportUsageErr()
+				return ErrInvalidUsage
+			}
+			format = arg
+		case path == "":
+			path = arg
+		default:
+			printReportUsageErr()
+			return ErrInvalidUsage
+		}
+	}
+
+	if format == "" {
+		format = reportMarkdown
+		if ext := filepath.Ext(path); ext == ".html" || ext == ".htm" {
+			format = reportHTML
+		}
+	}
+
+	out, err := generateReport(format)
+	if err != nil {
+		l.Println(err)
+		return err
+	}
+
+	if path == "" {
+		l.Println(string(out))
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		l.Println(err)
+		return err
+	}
+
+	err = ioutil.WriteFile(path, out, 0644)
+	if err != nil {
+		l.Println(err)
+		return err
+	}
+
+	Log.Info("wrote " + format + " report to " + path)
+
+	return nil
+}
+
+// generate the project report in the format
+func generateReport(format string) ([]byte, error) {
+
+	md := reportMarkdownSource(time.Now())
+
+	switch format {
+	case reportMarkdown:
+		return []byte(md), nil
+	case reportHTML:
+		return renderReportHTML(md)
+	default:
+		return nil, errors.New(ErrInvalidReportFormat.Error() + ": " + format)
+	}
+}
+
+// render the markdown report with the HTML template from the asset box
+func renderReportHTML(md string) ([]byte, error) {
+
+	tpl, err := assetBox.String("report.html")
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := template.New("report").Parse(tpl)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	err = t.Execute(&b, &reportPageData{
+		Title:   filepath.Base(workingDir) + " Report",
+		Content: template.HTML(blackfriday.Run([]byte(md))),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// assemble the markdown for the report
+func reportMarkdownSource(now time.Time) string {
+
+	var b strings.Builder
+
+	b.WriteString("# " + filepath.Base(workingDir) + " Report\n\n")
+	b.WriteString("Generated " + now.Format(timestampFormat) + " by ZEUS v" + version + "\n\n")
+
+	writeReportOverview(&b, now)
+	writeReportMilestones(&b)
+	writeReportCommits(&b)
+	writeReportCommands(&b)
+	writeReportHistory(&b)
+
+	return b.String()
+}
+
+// project data, deadline and open TODOs
+func writeReportOverview(b *strings.Builder, now time.Time) {
+
+	conf.Lock()
+	var (
+		dateFormat = conf.fields.DateFormat
+		todoFile   = conf.fields.TodoFilePath
+	)
+	conf.Unlock()
+
+	projectData.Lock()
+	var (
+		buildNumber = projectData.fields.BuildNumber
+		author      = projectData.fields.Author
+		deadline    = projectData.fields.Deadline
+	)
+	projectData.Unlock()
+
+	b.WriteString("## Overview\n\n")
+	b.WriteString("| | |\n| --- | --- |\n")
+	b.WriteString("| Build Number | " + strconv.Itoa(buildNumber) + " |\n")
+
+	if author != "" {
+		b.WriteString("| Author | " + markdownCell(author) + " |\n")
+	}
+
+	if deadline != "" {
+		if t, err := time.Parse(dateFormat, deadline); err == nil {
+			b.WriteString("| Deadline | " + deadline + " (" + deadlineCountdown(t, now) + ") |\n")
+		} else {
+			b.WriteString("| Deadline | " + markdownCell(deadline) + " |\n")
+		}
+	}
+
+	if todoFile != "" {
+		if n, err := countTodos(todoFile); err == nil {
+			b.WriteString("| Open TODOs | " + strconv.Itoa(n) + " |\n")
+		}
+	}
+
+	b.WriteString("\n")
+}
+
+// time left until the deadline, counted in days
+func deadlineCountdown(deadline, now time.Time) string {
+
+	var (
+		y, m, d = now.Date()
+		today   = time.Date(y, m, d, 0, 0, 0, 0, deadline.Location())
+		days    = int(deadline.Sub(today).Hours() / 24)
+	)
+
+	switch {
+	case days == 0:
+		return "due today"
+	case days == 1:
+		return "1 day left"
+	case days > 1:
+		return strconv.Itoa(days) + " days left"
+	case days == -1:
+		return "overdue by 1 day"
+	default:
+		return "overdue by " + strconv.Itoa(-days) + " days"
+	}
+}
+
+// milestones with progress bars
+func writeReportMilestones(b *strings.Builder) {
+
+	conf.Lock()
+	dateFormat := conf.fields.DateFormat
+	conf.Unlock()
+
+	projectData.Lock()
+	defer projectData.Unlock()
+
+	if len(projectData.fields.Milestones) == 0 {
+		return
+	}
+
+	b.WriteString("## Milestones\n\n")
+	b.WriteString("| Milestone | Date | Progress | Description |\n")
+	b.WriteString("| --------- | ---- | -------- | ----------- |\n")
+
+	for _, m := range projectData.fields.Milestones {
+		b.WriteString("| " + markdownCell(m.Name) + " | " + m.Date.Format(dateFormat) + " | " + progressBar(m.PercentComplete) + " | " + markdownCell(m.Description) + " |\n")
+	}
+
+	b.WriteString("\n")
+}
+
+// progress bar for a percentage
+func progressBar(p int) string {
+
+	if p < 0 {
+		p = 0
+	}
+	if p > 100 {
+		p = 100
+	}
+
+	done := p * reportBarWidth / 100
+
+	return "`" + strings.Repeat("█", done) + strings.Repeat("░", reportBarWidth-done) + "` " + strconv.Itoa(p) + "%"
+}
+
+// count the tasks in the todo file
+func countTodos(path string) (int, error) {
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, line := range strings.Split(string(contents), "\n") {
+		if strings.HasPrefix(line, "- ") {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// latest git commits, the section is omitted outside of a git repository
+func writeReportCommits(b *strings.Builder) {
+
+	out, err := exec.Command("git", "log", "-n", strconv.Itoa(reportCommits), "--pretty=format:%h%x1f%ci%x1f%an%x1f%s").Output()
+	if err != nil || len(bytes.TrimSpace(out)) == 0 {
+		return
+	}
+
+	b.WriteString("## Recent Commits\n\n")
+	b.WriteString("| Commit | Date | Author | Subject |\n")
+	b.WriteString("| ------ | ---- | ------ | ------- |\n")
+
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		b.WriteString("| " + fields[0] + " | " + fields[1] + " | " + markdownCell(fields[2]) + " | " + markdownCell(fields[3]) + " |\n")
+	}
+
+	b.WriteString("\n")
+}
+
+// all commands with their arguments
+func writeReportCommands(b *strings.Builder) {
+
+	cmdMap.Lock()
+	defer cmdMap.Unlock()
+
+	if len(cmdMap.items) == 0 {
+		return
+	}
+
+	var names []string
+	for name := range cmdMap.items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b.WriteString("## Commands\n\n")
+	b.WriteString("| Command | Arguments | Description |\n")
+	b.WriteString("| ------- | --------- | ----------- |\n")
+
+	for _, name := range names {
+		c := cmdMap.items[name]
+		b.WriteString("| " + c.name + " | " + markdownCell(plainArgumentString(c.args)) + " | " + markdownCell(c.description) + " |\n")
+	}
+
+	b.WriteString("\n")
+}
+
+// format arguments without colors, required arguments first
+func plainArgumentString(args map[string]*commandArg) string {
+
+	var required, optional []string
+	for _, arg := range args {
+		t := arg.name + ":" + strings.Title(arg.argType.String())
+		if arg.optional {
+			t += "?"
+			if v := strings.TrimSpace(arg.defaultValue); v != "" {
+				t += " = " + v
+			}
+			optional = append(optional, t)
+		} else {
+			required = append(required, t)
+		}
+	}
+
+	sort.Strings(required)
+	sort.Strings(optional)
+
+	return strings.Join(append(required, optional...), ", ")
+}
+
+// latest runs and the command statistics from the history
+func writeReportHistory(b *strings.Builder) {
+
+	runs, err := loadHistory()
+	if err != nil || len(runs) == 0 {
+		return
+	}
+
+	conf.Lock()
+	dateFormat := conf.fields.DateFormat + " 15:04:05"
+	conf.Unlock()
+
+	var failed int
+	for _, run := range runs {
+		if run.Status == runFailed {
+			failed++
+		}
+	}
+
+	b.WriteString("## Recent Runs\n\n")
+	b.WriteString(strconv.Itoa(len(runs)) + " runs recorded, " + strconv.Itoa(failed) + " failed.\n\n")
+	b.WriteString("| Date | Chain | Duration | Status | Error |\n")
+	b.WriteString("| ---- | ----- | -------- | ------ | ----- |\n")
+
+	// newest first
+	for i := len(runs) - 1; i >= 0 && i >= len(runs)-reportRuns; i-- {
+		run := runs[i]
+		status := run.Status
+		if status != runOK {
+			status = "**" + status + "**"
+		}
+		b.WriteString("| " + run.Start.Format(dateFormat) + " | " + markdownCell(run.Chain) + " | " + formatDuration(run.Duration) + " | " + status + " | " + markdownCell(run.Error) + " |\n")
+	}
+	b.WriteString("\n")
+
+	stats := collectStats(runs)
+
+	var names []string
+	for name, s := range stats {
+		if s.runs > 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	b.WriteString("| Command | Runs | Failures | Failure Rate | Median | P95 |\n")
+	b.WriteString("| ------- | ---- | -------- | ------------ | ------ | --- |\n")
+
+	for _, name := range names {
+		s := stats[name]
+		b.WriteString("| " + name + " | " + strconv.Itoa(s.runs) + " | " + strconv.Itoa(s.failures) + " | " + strconv.FormatFloat(s.failureRate()*100, 'f', 1, 64) + "% | " + formatDuration(s.percentile(50)) + " | " + formatDuration(s.percentile(95)) + " |\n")
+	}
+
+	b.WriteString("\n")
+}
+
+// escape text for a markdown table cell
+func markdownCell(s string) string {
+	return strings.Replace(strings.Join(strings.Fields(s), " "), "|", "\\|", -1)
+}
@@ -15,8 +15,8 @@ func init() {
 	}
 	file4 := &embedded.EmbeddedFile{
 		Filename:    "css/index.css",
-		FileModTime: time.Unix(1508259734, 0),
-		Content:     string("*{box-sizing:border-box}i{color:#fff;font-size:15px!important}.buttons{position:absolute;top:180px;right:10px}#main-spinner{display:none;position:absolute;right:20px;top:20px}#main-spinner .loader{border-radius:50%;width:5em;height:5em}html{background-color:gray}.logo{opacity:.7;width:250px;height:185px;margin-left:11px;margin-top:5px;margin-bottom:-5px}.navbar{position:absolute;top:-60px;width:100%}footer{float:right;color:#fff;margin:10px}.screen{margin:10px;background-color:#000;color:#00b100;opacity:.8;height:580px;border-radius:15px;padding:20px;width:98%}.inspect-button{cursor:pointer;background-color:#000;border:2px gray solid;border-radius:5px;color:#fff;width:100px;height:50px}.inspect-button.hvr-glow:hover,.inspect-button.hvr-glow:focus{box-shadow:0 0 10px green}.inspect-button .fa{font-size:16px}.inspect-button:hover,.inspect-button:focus,.inspect-button.highlight{border-color:0;color:#fff}.loader{margin:0 auto;margin:60px auto;font-size:10px;text-indent:-9999em;border-top:1.1em solid rgba(255,255,255,.2);border-right:1.1em solid rgba(255,255,255,.2);border-bottom:1.1em solid rgba(255,255,255,.2);border-left:1.1em solid #fff;-webkit-transform:translateZ(0);-ms-transform:translateZ(0);transform:translateZ(0);-webkit-animation:loading-spin 1.1s infinite linear;animation:loading-spin 1.1s infinite linear}h1{color:#fff;text-align:center}h1 b{top:-35px;position:relative}.loader,.loader:after{border-radius:50%;width:10em;height:10em}@-webkit-keyframes loading-spin{0%{-webkit-transform:rotate(0);transform:rotate(0)}100%{-webkit-transform:rotate(360deg);transform:rotate(360deg)}}@keyframes loading-spin{0%{-webkit-transform:rotate(0);transform:rotate(0)}100%{-webkit-transform:rotate(360deg);transform:rotate(360deg)}}"),
+		FileModTime: time.Unix(1792328576, 0),
+		Content:     string("*{box-sizing:border-box}i{color:#fff;font-size:15px!important}.buttons{position:absolute;top:180px;right:10px}#main-spinner{display:none;position:absolute;right:20px;top:20px}#main-spinner .loader{border-radius:50%;width:5em;height:5em}html{background-color:gray}.logo{opacity:.7;width:250px;height:185px;margin-left:11px;margin-top:5px;margin-bottom:-5px}.navbar{position:absolute;top:-60px;width:100%}footer{float:right;color:#fff;margin:10px}.screen{margin:10px;background-color:#000;color:#00b100;opacity:.8;height:580px;border-radius:15px;padding:20px;width:98%}.inspect-button{cursor:pointer;background-color:#000;border:2px gray solid;border-radius:5px;color:#fff;width:100px;height:50px}.inspect-button.hvr-glow:hover,.inspect-button.hvr-glow:focus{box-shadow:0 0 10px green}.inspect-button .fa{font-size:16px}.inspect-button:hover,.inspect-button:focus,.inspect-button.highlight{border-color:0;color:#fff}.loader{margin:0 auto;margin:60px auto;font-size:10px;text-indent:-9999em;border-top:1.1em solid rgba(255,255,255,.2);border-right:1.1em solid rgba(255,255,255,.2);border-bottom:1.1em solid rgba(255,255,255,.2);border-left:1.1em solid #fff;-webkit-transform:translateZ(0);-ms-transform:translateZ(0);transform:translateZ(0);-webkit-animation:loading-spin 1.1s infinite linear;animation:loading-spin 1.1s infinite linear}h1{color:#fff;text-align:center}h1 b{top:-35px;position:relative}.loader,.loader:after{border-radius:50%;width:10em;height:10em}@-webkit-keyframes loading-spin{0%{-webkit-transform:rotate(0);transform:rotate(0)}100%{-webkit-transform:rotate(360deg);transform:rotate(360deg)}}@keyframes loading-spin{0%{-webkit-transform:rotate(0);transform:rotate(0)}100%{-webkit-transform:rotate(360deg);transform:rotate(360deg)}}.graph{display:none;position:absolute;top:240px;left:10px;right:10px;overflow:auto;background-color:#222;border-radius:15px}.graph .graph-edge{stroke:#aaa;stroke-width:1.5px}.graph marker path{fill:#aaa}.graph .graph-node rect{fill:#fff;stroke:#444;stroke-width:1.5px}.graph .graph-node text{text-anchor:middle;font-family:Helvetica,sans-serif;font-size:13px}.graph .graph-node.async rect{stroke-dasharray:5 5}.graph .graph-node.outputs rect{stroke-width:4px}.graph .graph-node.running rect{fill:gold}.graph .graph-node.finished rect{fill:#98fb98}.graph .graph-node.failed rect{fill:salmon}.graph .graph-node.skipped rect{fill:#d3d3d3}.runs{display:none;position:absolute;top:240px;left:10px;right:10px;bottom:10px;padding:10px;background-color:#222;border-radius:15px}.runs .run-form input{width:40%;padding:5px}.runs .run-state{color:#ddd;margin-left:10px}.runs .run-tab{margin:5px 5px 0 0;border:none;border-radius:5px;background-color:#d3d3d3}.runs .run-tab.selected{outline:2px solid white}.runs .run-tab.running{background-color:#ffd700}.runs .run-tab.finished{background-color:#98fb98}.runs .run-tab.failed{background-color:#fa8072}.runs .console{position:absolute;top:90px;left:10px;right:10px;bottom:10px;margin:0;overflow:auto;color:#e5e5e5;background-color:#000;font-size:13px}.ansi-bold{font-weight:bold}.ansi-faint{opacity:0.7}.ansi-italic{font-style:italic}.ansi-underline{text-decoration:underline}.ansi-fg-0{color:#000000}.ansi-bg-0{background-color:#000000}.ansi-fg-1{color:#cd0000}.ansi-bg-1{background-color:#cd0000}.ansi-fg-2{color:#00cd00}.ansi-bg-2{background-color:#00cd00}.ansi-fg-3{color:#cdcd00}.ansi-bg-3{background-color:#cdcd00}.ansi-fg-4{color:#0000ee}.ansi-bg-4{background-color:#0000ee}.ansi-fg-5{color:#cd00cd}.ansi-bg-5{background-color:#cd00cd}.ansi-fg-6{color:#00cdcd}.ansi-bg-6{background-color:#00cdcd}.ansi-fg-7{color:#e5e5e5}.ansi-bg-7{background-color:#e5e5e5}.ansi-fg-8{color:#7f7f7f}.ansi-bg-8{background-color:#7f7f7f}.ansi-fg-9{color:#ff0000}.ansi-bg-9{background-color:#ff0000}.ansi-fg-10{color:#00ff00}.ansi-bg-10{background-color:#00ff00}.ansi-fg-11{color:#ffff00}.ansi-bg-11{background-color:#ffff00}.ansi-fg-12{color:#5c5cff}.ansi-bg-12{background-color:#5c5cff}.ansi-fg-13{color:#ff00ff}.ansi-bg-13{background-color:#ff00ff}.ansi-fg-14{color:#00ffff}.ansi-bg-14{background-color:#00ffff}.ansi-fg-15{color:#ffffff}.ansi-bg-15{background-color:#ffffff}.settings-panel{display:none;position:absolute;top:240px;left:10px;right:10px;bottom:10px;padding:10px 20px;overflow:auto;color:#ddd;background-color:#222;border-radius:15px}.settings-panel h3{margin:20px 0 5px 0}.settings-panel form{margin:5px 0}.settings-panel td{padding:2px 10px 2px 0}.settings-panel .settings-error{color:#fa8072}\n"),
 	}
 	file5 := &embedded.EmbeddedFile{
 		Filename:    "css/pure-min.css",
@@ -55,8 +55,8 @@ func init() {
 	}
 	filee := &embedded.EmbeddedFile{
 		Filename:    "html/index.html",
-		FileModTime: time.Unix(1487968253, 0),
-		Content:     string("<!DOCTYPE html>\n<html>\n    <head>\n        <meta charset=\"utf8\" />\n        <meta name=\"viewport\" content=\"width=device-width, initial-scale=1, maximum-scale=1, user-scalable=0\"/>\n    \n        <!-- Page Title -->\n        <title>ZEUS beta</title>\n\n        <!-- Styles -->\n        <link rel=\"stylesheet\" type=\"text/css\" href=\"files/css/index.css\"/>\n        <link rel=\"stylesheet\" type=\"text/css\" href=\"files/css/font-awesome.min.css\"/>\n        <link rel=\"stylesheet\" type=\"text/css\" href=\"files/css/pure-min.css\"/>\n\n        <!-- normal script imports etc  -->\n        <script src=\"files/js/jquery.js\"></script>\n        <script src=\"files/js/highlight.js\"></script>\n        <script src=\"files/js/glue.js\"></script>\n        <script src=\"files/js/index.js\"></script>\n    </head>\n\n    <header>\n        <!--<img class=\"logo\" src=\"/files/images/logo.png\"></img>-->\n\n        <div class=\"navbar\">\n\n            <div class=\"settings\">\n            </div>\n\n            <div class=\"wrap\" id=\"main-spinner\">\n                <div class=\"loader\"></div>\n            </div>\n            \n            <div class=\"buttons\">\n                <button class=\"zeus-button\" id=\"btn-add\"><i class=\"fa fa-plus-circle\"></i></button>\n                <button class=\"zeus-button\" id=\"btn-wiki\">WIKI</button>\n                <button class=\"zeus-button\" id=\"btn-db\">COMMANDS</button>\n                <button class=\"zeus-button\" id=\"btn-reports\">BUILTINS</button>\n                <button class=\"zeus-button\" id=\"btn-config\">CONFIG</button>\n                <button class=\"zeus-button\" id=\"btn-quit\">QUIT</button>\n            </div>\n        </div>\n    </header>\n\n    <body class=\"main\"> \n    </body>\n\n</html>"),
+		FileModTime: time.Unix(1792328576, 0),
+		Content:     string("<!DOCTYPE html>\n<html>\n    <head>\n        <meta charset=\"utf8\" />\n        <meta name=\"viewport\" content=\"width=device-width, initial-scale=1, maximum-scale=1, user-scalable=0\"/>\n    \n        <!-- Page Title -->\n        <title>ZEUS beta</title>\n\n        <!-- Styles -->\n        <link rel=\"stylesheet\" type=\"text/css\" href=\"files/css/index.css\"/>\n        <link rel=\"stylesheet\" type=\"text/css\" href=\"files/css/font-awesome.min.css\"/>\n        <link rel=\"stylesheet\" type=\"text/css\" href=\"files/css/pure-min.css\"/>\n\n        <!-- normal script imports etc  -->\n        <script src=\"files/js/jquery.js\"></script>\n        <script src=\"files/js/highlight.js\"></script>\n        <script src=\"files/js/glue.js\"></script>\n        <script src=\"files/js/index.js\"></script>\n    </head>\n\n    <header>\n        <!--<img class=\"logo\" src=\"/files/images/logo.png\"></img>-->\n\n        <div class=\"navbar\">\n\n            <div class=\"settings\">\n            </div>\n\n            <div class=\"wrap\" id=\"main-spinner\">\n                <div class=\"loader\"></div>\n            </div>\n            \n            <div class=\"buttons\">\n                <button class=\"zeus-button\" id=\"btn-add\"><i class=\"fa fa-plus-circle\"></i></button>\n                <button class=\"zeus-button\" id=\"btn-wiki\">WIKI</button>\n                <button class=\"zeus-button\" id=\"btn-db\">COMMANDS</button>\n                <button class=\"zeus-button\" id=\"btn-reports\">BUILTINS</button>\n                <button class=\"zeus-button\" id=\"btn-graph\">GRAPH</button>\n                <button class=\"zeus-button\" id=\"btn-runs\">RUNS</button>\n                <button class=\"zeus-button\" id=\"btn-config\">CONFIG</button>\n                <button class=\"zeus-button\" id=\"btn-quit\">QUIT</button>\n            </div>\n        </div>\n    </header>\n\n    <body class=\"main\"> \n        <div class=\"graph\" id=\"graph\"></div>\n        <div class=\"runs\" id=\"runs\">\n            <form class=\"run-form\" id=\"run-form\">\n                <input type=\"text\" id=\"run-chain\" placeholder=\"command chain, e.g. clean -> build\">\n                <button class=\"zeus-button\" type=\"submit\">RUN</button>\n                <button class=\"zeus-button\" type=\"button\" id=\"run-cancel\">CANCEL</button>\n                <span class=\"run-state\" id=\"run-state\"></span>\n            </form>\n            <div class=\"run-tabs\" id=\"run-tabs\"></div>\n            <pre class=\"console\" id=\"console\"></pre>\n        </div>\n        <div class=\"settings-panel\" id=\"settings\">\n            <div class=\"settings-error\" id=\"settings-error\"></div>\n\n            <h3>Project</h3>\n            <p>Build number: <span id=\"settings-build\"></span></p>\n            <form id=\"settings-author-form\">\n                <input type=\"text\" id=\"settings-author\" placeholder=\"author\">\n                <button class=\"zeus-button\" type=\"submit\">SET</button>\n                <button class=\"zeus-button\" type=\"button\" id=\"settings-author-remove\">REMOVE</button>\n            </form>\n            <form id=\"settings-deadline-form\">\n                <input type=\"text\" id=\"settings-deadline\" placeholder=\"deadline\">\n                <button class=\"zeus-button\" type=\"submit\">SET</button>\n                <button class=\"zeus-button\" type=\"button\" id=\"settings-deadline-remove\">REMOVE</button>\n            </form>\n\n            <h3>Milestones</h3>\n            <table id=\"settings-milestones\"></table>\n            <form id=\"settings-milestone-form\">\n                <input type=\"text\" id=\"settings-milestone-name\" placeholder=\"name\">\n                <input type=\"text\" id=\"settings-milestone-date\" placeholder=\"date\">\n                <input type=\"text\" id=\"settings-milestone-description\" placeholder=\"description\">\n                <button class=\"zeus-button\" type=\"submit\">ADD</button>\n            </form>\n\n            <h3>Aliases</h3>\n            <table id=\"settings-aliases\"></table>\n            <form id=\"settings-alias-form\">\n                <input type=\"text\" id=\"settings-alias-name\" placeholder=\"name\">\n                <input type=\"text\" id=\"settings-alias-command\" placeholder=\"command\">\n                <button class=\"zeus-button\" type=\"submit\">SET</button>\n            </form>\n\n            <h3>Key Bindings</h3>\n            <table id=\"settings-keys\"></table>\n            <form id=\"settings-key-form\">\n                <select id=\"settings-key\"></select>\n                <input type=\"text\" id=\"settings-key-command\" placeholder=\"command chain\">\n                <button class=\"zeus-button\" type=\"submit\">SET</button>\n            </form>\n\n            <h3>Events</h3>\n            <table id=\"settings-events\"></table>\n            <form id=\"settings-event-form\">\n                <select id=\"settings-event-op\">\n                    <option>WRITE</option>\n                    <option>REMOVE</option>\n                    <option>RENAME</option>\n                    <option>CHMOD</option>\n                </select>\n                <input type=\"text\" id=\"settings-event-path\" placeholder=\"path\">\n                <input type=\"text\" id=\"settings-event-filetype\" placeholder=\"file type, e.g. .go\">\n                <input type=\"text\" id=\"settings-event-command\" placeholder=\"command chain\">\n                <button class=\"zeus-button\" type=\"submit\">ADD</button>\n            </form>\n\n            <h3>Config</h3>\n            <table id=\"settings-config\"></table>\n        </div>\n    </body>\n\n</html>"),
 	}
 	fileg := &embedded.EmbeddedFile{
 		Filename:    "js/glue.js",
@@ -70,8 +70,8 @@ func init() {
 	}
 	filei := &embedded.EmbeddedFile{
 		Filename:    "js/index.js",
-		FileModTime: time.Unix(1508259734, 0),
-		Content:     string("$(document).ready(function(){$('#btn-wiki').click(function(){window.open(\"/wiki\",\"_blank\");});$('#btn-scripts').click(function(){window.open(\"/scripts\",\"_blank\");});$('#btn-config').click(function(){console.log(\"toggle config panel\");});$('#btn-quit').click(function(){window.location=\"/quit\";setTimeout(function(){window.close();},1000);});var socket=glue();socket.onMessage(function(data){console.log(\"onMessage: \"+data);});socket.on(\"connected\",function(){console.log(\"connected\");});socket.on(\"connecting\",function(){console.log(\"connecting\");});socket.on(\"disconnected\",function(){console.log(\"disconnected\");});socket.on(\"reconnecting\",function(){console.log(\"reconnecting\");});socket.on(\"error\",function(e,msg){console.log(\"error: \"+msg);});socket.on(\"connect_timeout\",function(){console.log(\"connect_timeout\");});socket.on(\"timeout\",function(){console.log(\"timeout\");});socket.on(\"discard_send_buffer\",function(){console.log(\"some data could not be send and was discarded.\");});});function spinnerON(){$('#main-spinner').toggle(true);}\nfunction spinnerOFF(){$('#main-spinner').toggle(false);}"),
+		FileModTime: time.Unix(1792328576, 0),
+		Content:     string("$(document).ready(function(){$.ajaxSetup({headers:{\"X-CSRF-Token\":getCookie(\"zeus-csrf\")}});$('#btn-wiki').click(function(){window.open(\"/wiki\",\"_blank\");});$('#btn-scripts').click(function(){window.open(\"/scripts\",\"_blank\");});$('#btn-config').click(function(){$('#settings').toggle();if($('#settings').is(':visible')){loadSettings();}});initSettings();$('#btn-graph').click(function(){$('#graph').toggle();if($('#graph').is(':visible')){loadGraph();}});$('#btn-runs').click(function(){$('#runs').toggle();});$('#btn-quit').click(function(){$.post(\"/quit\");setTimeout(function(){window.close();},1000);});var socket=glue();socket.onMessage(function(data){console.log(\"onMessage: \"+data);var msg;try{msg=JSON.parse(data);}catch(e){return;}if(msg.type===\"state\"){setNodeState(msg.command,msg.state);}if(msg.type===\"runs\"){$.each(msg.runs,function(i,run){updateRun(run);});}if(msg.type===\"run\"){updateRun(msg);}if(msg.type===\"settings\"&&$('#settings').is(':visible')){loadSettings();}});$('#run-tabs').on(\"click\",\".run-tab\",function(){selectRun(socket,$(this).attr(\"data-id\"));});$('#run-form').submit(function(e){e.preventDefault();var chain=$.trim($('#run-chain').val());if(chain===\"\"){return;}$.ajax({url:\"/api/run\",method:\"POST\",contentType:\"application/json\",data:JSON.stringify({chain:chain})}).done(function(status){$('#run-chain').val(\"\");updateRun({id:status.id,chain:status.chain,state:status.state});selectRun(socket,status.id);}).fail(function(xhr){showRunError(xhr);});});$('#run-cancel').click(function(){if(selectedRun===null){return;}$.ajax({url:\"/api/runs/\"+selectedRun,method:\"DELETE\"}).fail(function(xhr){showRunError(xhr);});});socket.on(\"connected\",function(){console.log(\"connected\");});socket.on(\"connecting\",function(){console.log(\"connecting\");});socket.on(\"disconnected\",function(){console.log(\"disconnected\");});socket.on(\"reconnecting\",function(){console.log(\"reconnecting\");});socket.on(\"error\",function(e,msg){console.log(\"error: \"+msg);});socket.on(\"connect_timeout\",function(){console.log(\"connect_timeout\");});socket.on(\"timeout\",function(){console.log(\"timeout\");});socket.on(\"discard_send_buffer\",function(){console.log(\"some data could not be send and was discarded.\");});});\nvar selectedRun=null;function updateRun(run){var tab=$('#run-tabs .run-tab').filter(function(){return $(this).attr(\"data-id\")===run.id;});if(tab.length===0){tab=$('<button class=\"run-tab\"/>').attr(\"data-id\",run.id).text(run.chain);$('#run-tabs').prepend(tab);}$.each(graphStates.concat([\"cancelled\"]),function(i,s){tab.removeClass(s);});tab.addClass(run.state);if(run.id===selectedRun){$('#run-state').text(run.state);}}\nfunction selectRun(socket,id){if(selectedRun===id){return;}if(selectedRun!==null){socket.send(JSON.stringify({type:\"unsubscribe\",id:selectedRun}));socket.channel(\"run:\"+selectedRun).onMessage(function(){});}selectedRun=id;$('#run-tabs .run-tab').removeClass(\"selected\").filter(function(){return $(this).attr(\"data-id\")===id;}).addClass(\"selected\");$('#console').html(\"\");$('#run-state').text(\"\");socket.channel(\"run:\"+id).onMessage(function(data){var msg;try{msg=JSON.parse(data);}catch(e){return;}switch(msg.type){case\"replay\":$('#console').html(msg.html);$('#run-state').text(msg.state);scrollConsole();break;case\"output\":$('#console').append(msg.html);scrollConsole();break;case\"state\":$('#run-state').text(msg.command+\": \"+msg.state);break;case\"unknown\":$('#console').text(\"the output of this run is no longer available\");break;}});socket.send(JSON.stringify({type:\"subscribe\",id:id}));}\nfunction scrollConsole(){var c=$('#console');c.scrollTop(c.prop(\"scrollHeight\"));}\nfunction showRunError(xhr){var msg=xhr.statusText;if(xhr.responseJSON&&xhr.responseJSON.error){msg=xhr.responseJSON.error;}$('#run-state').text(\"error: \"+msg);}\nfunction loadSettings(){$.getJSON(\"/api/settings\",function(settings){renderSettings(settings);}).fail(function(xhr){showSettingsError(xhr);});}\nfunction saveSettings(method,url,data){$.ajax({url:url,method:method,contentType:\"application/json\",data:data===undefined?undefined:JSON.stringify(data)}).done(function(settings){$('#settings-error').text(\"\");renderSettings(settings);}).fail(function(xhr){showSettingsError(xhr);});}\nfunction showSettingsError(xhr){var msg=xhr.statusText;if(xhr.responseJSON&&xhr.responseJSON.error){msg=xhr.responseJSON.error;}$('#settings-error').text(msg);}\nfunction removeButton(url){return $('<button class=\"zeus-button\">REMOVE</button>').click(function(){saveSettings(\"DELETE\",url);});}\nfunction settingsRow(cells,elements){var row=$('<tr/>');$.each(cells,function(i,c){row.append($('<td/>').text(c));});$.each(elements,function(i,e){row.append($('<td/>').append(e));});return row;}\nfunction renderSettings(s){var config=$('#settings-config').empty();$.each(s.config,function(i,f){var input;if(f.type===\"bool\"){input=$('<input type=\"checkbox\"/>').prop(\"checked\",f.value);}else{input=$('<input/>').attr(\"type\",f.type===\"int\"?\"number\":\"text\").val(f.value);}input.change(function(){var value=f.type===\"bool\"?$(this).is(':checked'):$(this).val();saveSettings(\"PUT\",\"/api/config/\"+encodeURIComponent(f.name),{value:value});});config.append(settingsRow([f.name],[input]));});$('#settings-build').text(s.buildNumber);$('#settings-author').val(s.author);$('#settings-deadline').val(s.deadline).attr(\"placeholder\",s.dateFormat);$('#settings-milestone-date').attr(\"placeholder\",s.dateFormat);var aliases=$('#settings-aliases').empty();$.each(Object.keys(s.aliases).sort(),function(i,name){aliases.append(settingsRow([name,s.aliases[name]],[removeButton(\"/api/aliases/\"+encodeURIComponent(name))]));});var milestones=$('#settings-milestones').empty();$.each(s.milestones,function(i,m){var percent=$('<input type=\"number\" min=\"0\" max=\"100\"/>').val(m.percentComplete).change(function(){saveSettings(\"PUT\",\"/api/milestones/\"+encodeURIComponent(m.name),{percent:$(this).val()});});milestones.append(settingsRow([m.name,m.date,m.description],[percent,removeButton(\"/api/milestones/\"+encodeURIComponent(m.name))]));});var keys=$('#settings-keys').empty(),keySelect=$('#settings-key').empty();$.each(s.keys,function(i,key){keySelect.append($('<option/>').val(key).text(key));if(s.keyBindings[key]!==undefined){keys.append(settingsRow([key,s.keyBindings[key]],[removeButton(\"/api/keys/\"+encodeURIComponent(key))]));}});var events=$('#settings-events').empty();$.each(s.events,function(i,e){events.append(settingsRow([e.op,e.path,e.fileExtension,e.command],[removeButton(\"/api/events/\"+encodeURIComponent(e.id))]));});}\nfunction initSettings(){$('#settings-author-form').submit(function(e){e.preventDefault();saveSettings(\"PUT\",\"/api/author\",{name:$('#settings-author').val()});});$('#settings-author-remove').click(function(){saveSettings(\"DELETE\",\"/api/author\");});$('#settings-deadline-form').submit(function(e){e.preventDefault();saveSettings(\"PUT\",\"/api/deadline\",{date:$('#settings-deadline').val()});});$('#settings-deadline-remove').click(function(){saveSettings(\"DELETE\",\"/api/deadline\");});$('#settings-alias-form').submit(function(e){e.preventDefault();saveSettings(\"POST\",\"/api/aliases\",{name:$('#settings-alias-name').val(),command:$('#settings-alias-command').val()});});$('#settings-milestone-form').submit(function(e){e.preventDefault();saveSettings(\"POST\",\"/api/milestones\",{name:$('#settings-milestone-name').val(),date:$('#settings-milestone-date').val(),description:$('#settings-milestone-description').val()});});$('#settings-key-form').submit(function(e){e.preventDefault();saveSettings(\"PUT\",\"/api/keys/\"+encodeURIComponent($('#settings-key').val()),{command:$('#settings-key-command').val()});});$('#settings-event-form').submit(function(e){e.preventDefault();saveSettings(\"POST\",\"/api/events\",{op:$('#settings-event-op').val(),path:$('#settings-event-path').val(),fileExtension:$('#settings-event-filetype').val(),command:$('#settings-event-command').val()});});}\nvar graphStates=[\"running\",\"finished\",\"failed\",\"skipped\"];function loadGraph(){spinnerON();$.getJSON(\"/api/graph\",function(graph){renderGraph(graph);}).always(function(){spinnerOFF();});}\nfunction renderGraph(graph){var nodeWidth=150,nodeHeight=36,columnWidth=200,rowHeight=56,layers={},rows={},positions={},i,j;for(i=0;i<graph.nodes.length;i++){layers[graph.nodes[i].name]=0;}for(i=0;i<graph.nodes.length;i++){for(j=0;j<(graph.edges||[]).length;j++){var e=graph.edges[j];if(layers[e.to]<layers[e.from]+1){layers[e.to]=layers[e.from]+1;}}}var width=0,height=0;for(i=0;i<graph.nodes.length;i++){var name=graph.nodes[i].name,layer=layers[name],row=rows[layer]||0;rows[layer]=row+1;positions[name]={x:20+layer*columnWidth,y:20+row*rowHeight};width=Math.max(width,positions[name].x+nodeWidth+20);height=Math.max(height,positions[name].y+nodeHeight+20);}var svg='<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"'+width+'\" height=\"'+height+'\">'+'<defs><marker id=\"arrow\" viewBox=\"0 0 10 10\" refX=\"10\" refY=\"5\" markerWidth=\"6\" markerHeight=\"6\" orient=\"auto\">'+'<path d=\"M 0 0 L 10 5 L 0 10 z\"/></marker></defs>';for(i=0;i<(graph.edges||[]).length;i++){var from=positions[graph.edges[i].from],to=positions[graph.edges[i].to];svg+='<line class=\"graph-edge\" marker-end=\"url(#arrow)\" x1=\"'+(from.x+nodeWidth)+'\" y1=\"'+(from.y+nodeHeight/2)+'\" x2=\"'+to.x+'\" y2=\"'+(to.y+nodeHeight/2)+'\"/>';}for(i=0;i<graph.nodes.length;i++){var n=graph.nodes[i],p=positions[n.name],classes=\"graph-node\";if(n.async){classes+=\" async\";}if(n.outputs){classes+=\" outputs\";}if(n.state){classes+=\" \"+n.state;}svg+='<g class=\"'+classes+'\" data-command=\"'+escapeHTML(n.name)+'\">'+'<title>'+escapeHTML(n.description||n.name)+'</title>'+'<rect rx=\"6\" ry=\"6\" x=\"'+p.x+'\" y=\"'+p.y+'\" width=\"'+nodeWidth+'\" height=\"'+nodeHeight+'\"/>'+'<text x=\"'+(p.x+nodeWidth/2)+'\" y=\"'+(p.y+nodeHeight/2+5)+'\">'+escapeHTML(n.name)+'</text>'+'</g>';}$('#graph').html(svg+'</svg>');}\nfunction setNodeState(command,state){$('#graph .graph-node').each(function(){if($(this).attr(\"data-command\")===command){var node=$(this);$.each(graphStates,function(i,s){node.removeClass(s);});node.addClass(state);}});}\nfunction getCookie(name){var cookies=document.cookie.split(\";\");for(var i=0;i<cookies.length;i++){var c=$.trim(cookies[i]);if(c.indexOf(name+\"=\")===0){return c.substring(name.length+1);}}return\"\";}\nfunction escapeHTML(text){return $('<div/>').text(text).html().split('\"').join(\"&quot;\");}\nfunction spinnerON(){$('#main-spinner').toggle(true);}\nfunction spinnerOFF(){$('#main-spinner').toggle(false);}"),
 	}
 	filej := &embedded.EmbeddedFile{
 		Filename:    "js/jquery.js",
@@ -189,10 +189,15 @@ func init() {
 		FileModTime: time.Unix(1499859985, 0),
 		Content:     string("\x1b[38;5;93m \x1b[0m\x1b[38;5;93m_\x1b[0m\x1b[38;5;63m_\x1b[0m\x1b[38;5;63m_\x1b[0m\x1b[38;5;63m_\x1b[0m\x1b[38;5;63m_\x1b[0m\x1b[38;5;33m_\x1b[0m\x1b[38;5;33m_\x1b[0m\x1b[38;5;33m_\x1b[0m\x1b[38;5;39m \x1b[0m\x1b[38;5;39m_\x1b[0m\x1b[38;5;39m_\x1b[0m\x1b[38;5;38m_\x1b[0m\x1b[38;5;44m_\x1b[0m\x1b[38;5;44m \x1b[0m\x1b[38;5;44m \x1b[0m\x1b[38;5;43m_\x1b[0m\x1b[38;5;49m_\x1b[0m\x1b[38;5;49m \x1b[0m\x1b[38;5;49m_\x1b[0m\x1b[38;5;48m_\x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;83m_\x1b[0m\x1b[38;5;83m_\x1b[0m\x1b[38;5;83m_\x1b[0m\x1b[38;5;83m_\x1b[0m\x1b[38;5;118m_\x1b[0m\x1b[38;5;118m_\x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;148m \x1b[0m\n\x1b[38;5;63m \x1b[0m\x1b[38;5;63m\\\x1b[0m\x1b[38;5;63m_\x1b[0m\x1b[38;5;33m_\x1b[0m\x1b[38;5;33m_\x1b[0m\x1b[38;5;33m \x1b[0m\x1b[38;5;39m \x1b[0m\x1b[38;5;39m \x1b[0m\x1b[38;5;39m/\x1b[0m\x1b[38;5;38m/\x1b[0m\x1b[38;5;44m \x1b[0m\x1b[38;5;44m_\x1b[0m\x1b[38;5;44m_\x1b[0m\x1b[38;5;43m \x1b[0m\x1b[38;5;49m\\\x1b[0m\x1b[38;5;49m|\x1b[0m\x1b[38;5;49m \x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;48m|\x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m\\\x1b[0m\x1b[38;5;83m/\x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;118m_\x1b[0m\x1b[38;5;118m_\x1b[0m\x1b[38;5;154m_\x1b[0m\x1b[38;5;154m/\x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;148m \x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;184m \x1b[0m\n\x1b[38;5;33m \x1b[0m\x1b[38;5;33m \x1b[0m\x1b[38;5;33m/\x1b[0m\x1b[38;5;39m \x1b[0m\x1b[38;5;39m \x1b[0m\x1b[38;5;39m \x1b[0m\x1b[38;5;38m \x1b[0m\x1b[38;5;44m/\x1b[0m\x1b[38;5;44m\\\x1b[0m\x1b[38;5;44m \x1b[0m\x1b[38;5;43m \x1b[0m\x1b[38;5;49m_\x1b[0m\x1b[38;5;49m_\x1b[0m\x1b[38;5;49m_\x1b[0m\x1b[38;5;48m/\x1b[0m\x1b[38;5;48m|\x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m|\x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;118m/\x1b[0m\x1b[38;5;118m\\\x1b[0m\x1b[38;5;118m_\x1b[0m\x1b[38;5;154m_\x1b[0m\x1b[38;5;154m_\x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;148m\\\x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;178m \x1b[0m\n\x1b[38;5;39m \x1b[0m\x1b[38;5;39m/\x1b[0m\x1b[38;5;39m_\x1b[0m\x1b[38;5;38m_\x1b[0m\x1b[38;5;44m_\x1b[0m\x1b[38;5;44m_\x1b[0m\x1b[38;5;44m_\x1b[0m\x1b[38;5;43m \x1b[0m\x1b[38;5;49m\\\x1b[0m\x1b[38;5;49m\\\x1b[0m\x1b[38;5;49m_\x1b[0m\x1b[38;5;48m_\x1b[0m\x1b[38;5;48m_\x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m>\x1b[0m\x1b[38;5;83m_\x1b[0m\x1b[38;5;83m_\x1b[0m\x1b[38;5;118m_\x1b[0m\x1b[38;5;118m_\x1b[0m\x1b[38;5;118m/\x1b[0m\x1b[38;5;154m/\x1b[0m\x1b[38;5;154m_\x1b[0m\x1b[38;5;154m_\x1b[0m\x1b[38;5;148m_\x1b[0m\x1b[38;5;184m_\x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;178m>\x1b[0m\x1b[38;5;214m \x1b[0m\x1b[38;5;214m \x1b[0m\n\x1b[38;5;38m \x1b[0m\x1b[38;5;44m \x1b[0m\x1b[38;5;44m \x1b[0m\x1b[38;5;44m \x1b[0m\x1b[38;5;43m \x1b[0m\x1b[38;5;49m \x1b[0m\x1b[38;5;49m \x1b[0m\x1b[38;5;49m\\\x1b[0m\x1b[38;5;48m/\x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m\\\x1b[0m\x1b[38;5;83m/\x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;148m \x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;178m \x1b[0m\x1b[38;5;214m\\\x1b[0m\x1b[38;5;214m/\x1b[0m\x1b[38;5;214m \x1b[0m\x1b[38;5;208m \x1b[0m\n\x1b[38;5;44m \x1b[0m\x1b[38;5;43m \x1b[0m\x1b[38;5;49m \x1b[0m\x1b[38;5;49m \x1b[0m\x1b[38;5;49m \x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;148m \x1b[0m\x1b[38;5;184mB\x1b[0m\x1b[38;5;184mu\x1b[0m\x1b[38;5;184mi\x1b[0m\x1b[38;5;178ml\x1b[0m\x1b[38;5;214md\x1b[0m\x1b[38;5;214m \x1b[0m\x1b[38;5;214mS\x1b[0m\x1b[38;5;208my\x1b[0m\x1b[38;5;208ms\x1b[0m\x1b[38;5;208mt\x1b[0m\x1b[38;5;203me\x1b[0m\x1b[38;5;203mm\x1b[0m\n\x1b[38;5;49m \x1b[0m\x1b[38;5;49m \x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;48m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;83m \x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;118m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;154m \x1b[0m\x1b[38;5;148m \x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;184m \x1b[0m\x1b[38;5;184m       "),
 	}
+	filep := &embedded.EmbeddedFile{
+		Filename:    "report.html",
+		FileModTime: time.Unix(1792329042, 0),
+		Content:     string("<!DOCTYPE html>\n\n<html>\n    <head>\n        <title>{{ .Title }}</title>\n        <meta charset=\"utf-8\">\n\n\t\t<style>\n            body {\n                font-family: -apple-system, \"Helvetica Neue\", Helvetica, Arial, sans-serif;\n                color: #333;\n            }\n            .report {\n                margin: 40px 15%;\n            }\n            h1 {\n                border-bottom: 2px solid #6b4cc8;\n                padding-bottom: 10px;\n            }\n            h2 {\n                color: #6b4cc8;\n                margin-top: 40px;\n            }\n            table {\n                border-collapse: collapse;\n                width: 100%;\n            }\n            th, td {\n                border: 1px solid #ddd;\n                padding: 6px 10px;\n                text-align: left;\n            }\n            th {\n                background-color: #f4f4f4;\n            }\n            tr:nth-child(even) td {\n                background-color: #fafafa;\n            }\n            code {\n                color: #6b4cc8;\n                letter-spacing: -1px;\n            }\n            strong {\n                color: #c0392b;\n            }\n\t\t</style>\n    </head>\n\n    <body>\n\n\t\t<!-- Report -->\n\t\t<div class=\"report\">\n\t\t\t{{ .Content }}\n\t\t</div>\n\n\t</body>\n</html>\n"),
+	}
 	fileo := &embedded.EmbeddedFile{
 		Filename:    "wiki_index.html",
-		FileModTime: time.Unix(1492266106, 0),
-		Content:     string("<!DOCTYPE html>\n\n<html>\n    <head>\n        <title>ZEUS WIKI</title>\n        <meta charset=\"utf-8\">\n\n\t\t<!-- Fonts -->\n\t\t<style>\n\t\t\t@font-face {\n                font-family: 'ralewaylight';\n                src: url(data:application/font-woff2;charset=utf-8;base64,d09GMgABAAAAAFtwABMAAAAA0swAAFsCAAIAQgAAAAAAAAAAAAAAAAAAAAAAAAAAP0ZGVE0cGiYbvhQcbAZgAINiCDoJhGURCAqCsliCmDoBNgIkA4ckC4NUAAQgBZJEB4VjDIJdP3dlYmYGG4zCF9DbdlJwt6qEIprJFEWwcQAJgo3ORkRuB1S7zS3O/v8/JUENGfpgPSBEo9Z1IryIsKl0shRmdKHUnGYq3dJUDR0etjtNq0ONOAkPHeGsmqkel+5zrYnGKfIyZKIyVbpSsISL8JLNiI1hg2UuPe7XvV8P4YILHnZZDJvHFB7+s2P8tZdT+MNrv00VhhTClI38yOADL4kLu5h/vwiL5WfSBl+NQ2X+FX6rj/m2iUul3PLTvfXZD/4K4xZeaCdHnjzUr5G9/t09s8sqBJY0kPCJjgJiYyJMlIswfAhy6+TVKbwet/xdSJDdBI6K6lXMX/KQtqlwVDX3ZmbC/w41/SoaqfkAzK1GqpgIAkKPERtjjEGPwTJZN6wYISByA5GMiYENioFBGIVgYJzV71lx57WX78F/tJ/vBOhRsgGg+0uwJRZOTtGpCnOEzJCbfT+EEYPBhgBBQxKIELNdfyr33YumvLL5tqyZ93qma+93lIBxAGHY2gCY9bP83/vK72utfCV9U3rZlL4ppW76pu/dJcsiIqYsIiIiIiIiIsMwDDIwDIOIiAz+X135a6ygN/t+T8Vds3NcUNWxjlYHfPf4pB/zBvFGTP/j9ReugoMGD5BgiGyyWWI7yPjqenWPJ9/jfeOzO5ir4HSc4iS4IjOgWNx1huKCjpQJBnQbEsr81jDe9ut2jZQ2DXY6JzmtAP7z3x/gZ6p+YEAFEOKhZiu1OM47P9L4pdXtpvnG8YYxyySIT9uXGt/+Ax4tEM8/0oERgijvftLuVTlyEjnIx1y5iyT4yFSrtHoGkHogC+mcke7e+SDiipTeuyD8IOF0zww4BiDGgNTMgJJAUAYAZQBQ+gNI/Q8MSQCkVuBanpfOOEsAlOUZmnN8K741NrxLgg8VKrwwvP3Ih/mH4dv3usp0u+Z0BEFmkiF0kC2GuebNs7xve98By79kYNX3VFkl0ywcoNYUXzmIACPnU2Oa4zFSlDpzmhn+/X223GQtHXjAOBsba//0uOynPYGugUctdlDLacRI0Y59n29MCxCwrc8vevdC/NqvS2EYGpFGRKQQKeT972/Wb6iPsedRH2qIBrgJxIhQ9u8dtlmXiq3w6lYMVtFCkKJJJhPhrCMC8O7kc47udPuZOQC+3JpZZ221BuwGwIhsdEfk3/WeJy0tuHZe0gWUx42zyY7mWyNeKM71i4FrYMRo+EwK7j6E+il90d1Ygq0iNEBRETdH5mgfI2N/+sYX5v0KLEmV1ZZ62r56rzZ0Q4/0sT7TN/vzNDFHzq75b/pj4Qu7WxQs7l8iLl1bZi5PL/+tenle5hO+8tP9dXumI4MFwXXBwyHP8imhj2FDeFPEFrg4sidqWZEe7RldEwvERsR0eRocFFwZfD8EHlIR8nQldWX3yr9D00MLQ3WhRaENoVvCosPiw8hhJ8Lh4Znh7PDe8KcR1Ah2RGOQ2SMR1yKeRcJ5ax1gCYCHwBLe01RrnWmybLLDWruopxMyIoYSmdgKM70NhVLcSkEBGVH5RorhA1pseoipbRG2B457bpU8p1tBfdY6FEaCOLZEyyPx7Se913WMn6Jk2nKFPU4f7x9B4qe9olaGtsV1b9jKH3WjDbCDBWZ0pT1uX0n8dB/lIWzl4FpRSQArhBaiBx0XMAPsECdSbpinCgNRlzjhrXvAEAuhqH/jOEoiITGug24nG3Uf9JDSCpDS1HVDDdysmh3gRHMF8s7uAIHroaLn2WEYEgGKf/UQv9xGuU/poWorZykiIqEyX9FVOVV8w0k0OZEl4bLbilClaICublpvu+douXTP6hbgPdXHB3Aw5ILZCWqiO48oBOTC+4gKDZ5prcV7QQv+LVzgmRZIu6oBEWpGriQukYB0ks1q8X8kkEWRxJfZikVlzytQldhmanbophnCJnFZENoIpEMLdLvKC36P1NcbCkIgUnJs/+FMgJGC+P824CGxlfYWPKFDnlABTayjpv021YHvPRfyNFuw7/kVn/C4KBCOmT4rfgDkfbixFsXUsFDbgMNu7gZ5rvikK9GcwIxGnZL6UZvl8NGeJz6XRMG0QjdoOmzTHW4uv5bnns+44pFwE/aVzH+xBWIxVaIF2bbA6Ac+/UoyBx0LjOFk0WJqlOAKMDD8opjnpCRok0xooInYYkeVxyFMwsZMWBZnE6+pICl9oBq0D6MdT5sMI/d+IX4iYI6RVX8Tk++eja2GCO6p/ziw9yC1Ez1qp+ICYIQeMw37MNiEMkvB1D5vc0rq2SrmoFvvek5mK36AnHHFEF9JQ0rrAfBtAB8iiKUgJaTiet2sgjUmryMGqykxLbNotuOOfp5nia/RDujggbgLnal6OdiXDvjDmCykRpSEPOFN3ZxtP2/hwlJ+TazA1OrM2l8Cfo9rrYHcFjUZVy0HRFyXbIHDt3MBj96SBaoueqEAFQQYKrDsjWI4QUcqgsE0mx1VlStBNBRxZVPuHUiTuDJvicBsolNZJCS6GRlwh7Xcv81uOKEnnJAXKyFZb+s6TdVZcOHQSFF2WOzDHdmR4fjdF/K+4JUxKhqlpWydTKW7ouD3alVkwM1N4hl8A1gBfOTAQLBplaWeAGbQX4PvVNgDDZilOcE0Hg3ntSSGzFPcLIuQHnfA+plTa58YG6vf02R1bApoXfGF5z29vq5nMGHBxHOYU3Jj8RSqqJ9/R9wn9lClldtr3qMgD/iP/b/qJTgz9zwwD/ZoPtEdHMhDSeRIej49JcVWlwFeaOBy8UBqsUbmi5gUh/DKcDqobrLduVuSC40LGKmkLMab+oIICxdokLxeIaar2MstixfN94sm4L8qRIm2G6QHGJQm7kdp1wkqGAXpbWolABTKSazGUSaRrcC1AsdZwE7l/KRwoA4Kj4EAX/ugQgv/oPQ11gKwv7wmNDQzM3yI5abtHcdbtrrPNzJgpjM73BqAQ0lojmHKsHET9tRbs605X1t4oz4kclXqrUyTrp3OvbIfDumoNOU88MIed6XGyhK6sBMbj+BPh3PlIEkx/UngWZ5G8e0KTXpZmKM5c3gXFGq5Wsa1dD+YClviiG4F9EELi0dMejAqqzAoii+KA5IoxB9OgG56a4gBeNgCwZ1De1AjdKWr0A5GM46DiHeAEdqLrtPA+RvZ7HpmEKa43SKMoccuymYHDYSYG30kP5aeyS6S4AEmvGyREFbyxWKpWrEzFb4hJBxRg8weATAFtmEMYyoRPWdKFKodOjzkRdwYS1LSEqcoyb4jg0L1USzLYgomVFHH2nENwUHRYclpeTNlXZbtrT0MdP/R7vNvt+NCXUOzTPeOQWr6hS+GvdelX/ihl4N9nw2Q4boKTYg0PjYvMWWkNmHObDUx/CnnZXN7N3KngBaGot45eON/6P8Z+N9NOU4Qa7HPdNZrHThA4IIMGVJkIAlOOLL2A3oo0Me8IBcHvaA7Xsy6x1lwmx2/XxWFEk/mKraVKRVBVbxNAzqeTB3fpgZ7Mb4rhEto3OlfeIIGroB7p9k6LGQgwn5gMwVidDewp/nrXuK3Me7k97E9tNfKpjQg6wn/VuuRnoeYByWtubZhy1zyQgawK/Gwa/6XrhwHjpo58vVfHYYD1AET2NHwmlESHHU+iif3SoGXRTEYJSBKAVFSWRqMcfJAMeSHtEhlQlcHSFgE5VNCkYvql/Xu5z/JVFnEPj773Z1ia02ymjOFt17QFEmS/llZVzGvrFWhV7nbbu4w6w5rluj+MrSbiH74ANRQg4JNxci+a4FgFx6NbsekxJORLv6qCXnmgfgE382n1JeY3atvJLex3bU0Z45Lh/4gcSqRuB4wEfFfJOCGoCNHYz25wHvJPi86L1k6sMrW3Qk00xkdCiCSIo3Ts9llwFVdxZMYhL+1Oa6WwkcKBOX32O5YVOF7RGeI0fBdVOV1bqVa9IwLofsHHImm+5+WGVcOj0utGtRQGdKmwjFv8R5OLp5cM66lwDtEjBQxonHxlFigSzy9a2b8nFnOmCECdpMyrXFp0cDZ6oCH9ywGe0j7NnJRa4X7RHygL4ioCGNZksNG8FS6AOMRf+naVtvGauSUnXBaGtq+sHanJjdUFD8c4CdmITAHVTCDpDAruTLE2aFTgGSRNtNUnLlZR7ngiVvmXm6GkK0sGaETdgSW/e1wv8NcS6+BGtyQ6f1YtuATpDhshx2Bf8MELrdXhxNBEnw7tpAS0RJJmRnbkzv2KgRbvXWIEsOfcxSeoXnC8v7WtLUPt+Z2ddLt9NY+2Zr4RO/U3pQdtBXbiC8ax0fcvm1n4tnaqW1jAmb5Xtj67q8f8h3Ilp/MCj26sk0e05POIx8XlUrh1BvI/R1LcdbPOsZZT1mqWDury9vCR6UuZZo5HY6spEWWrdxrHQphv7yVGn79k/aswJK61rUN2DTLUtmQM6a0OwS81Smq3iZxaY4DVvQO/eoUytAuVq319qGewF7D7wW0H/NVkyhCCNqIWeB7wp8s2VNPJXI+Owme4FPxDJ9btkgSFfUqFWXqIbsLRSoSUdZIPzd3rveSBUkPlCQHMQWz2NOjW3fz7s+DnGc9cZN3pu/ykY3cJJJiNo6QyMszcg6NhqeBsUXXbweIVv3rfonyu/rlCr0Ojwe4Hjt1qL+LD6Vu0jZbCbgzkoDuZk8urF6y37K+zdmVztq9R0CplI5ZpklyTWOT/2ACWn7bYl+G2cT2yuaYxDJ3ubTbUwYJQI5PyQKRAfOT8HZ7isNtYtbQcLYTDHWqx1Yw2XzxmpsruUoFyPwz/KiNpWetxjd2gGZpWqa1OVSp0IBbj2DXu5wmTxkDptRPNcs0gQqy2fCgxSHAQDHuiVptcxFAXDJh/Yq5ZlUFEggbVxIJyoEpuVchHdd0JY8S5GGZO1rJBRjB4OZOFaHKUzQGOJ8Hhnx0dInxPLRctjEn67imqfQYirlmYxeqV0wBjXJDYYw+HagRWhYqWm5wrCwPqMyRVhL0yOozWL1oyBYf5yR8+st8QgCzqJYaI0t9s5ckYCYtv9z5ZV8FAQpkYxD20Wv7IiSLFKPLwiHcqEXlSfAS8rU9Ve/Mk/00bmM+CarRDeBhQdsBEUJsCU6noGm18OpIcKx40s+dtXIxApaZwIELGZJHQ5IHGKHr6qs3XAaht1rbStVLXtCPBMcPqWs/DThz3dPypItQnR6S475j7jYNtHCYVmZCW8m9WS9yXWRjSuJefqCwl29pMUhkc5E0nPpEKc8QUNp45hc/7R5IStIdNs7oTx6dL6vDI0GW+SctKRF8+YaTEKoICU/PectyBTb8D8IWwVpPr2NHUY7j552FXAaMw6ZBi5CnAWlYZiGvnhrC4X6JBuREqtVi8xNrx3YUdhw/7yzaZcg4tmm0RWxPQ9LYMuvniVUfgEiV6UMQLR1ksJHiOZhrXsBEFzr5kKGtwbA75DYxBZ6z+jYEyPrSqoQ7fTCGkZ4yue+fC2G/mxK/jTj101yF2reM+/rhdR3N+tvOU+x7l0TXCO1fqD0b1oIHBAcOxmrJ46tnwouHY9C9XiqyGPFAVtBvDDAnwyqMRDMLOi0+Zrx05TG7V5468mJbNAq0JJTGF9u6GNaN/10rxYIIBZUCCKFAPDyWdByF0HiUsLTMrRZkKgmyVAbZXEGO7mChDZ+da6ttePZ8doExhwkd/+xS9z0k8+SzK33nOZWXQY03Qa13F6T7dvjXv3HV4CbNNESvRUNaCBXtPS/zsLxjKbq3PIX8Jn7fF/KA5vGqAQHlQ+vHOg0g9vDvTH5mGAAwliGeiAmIvwF4ewpwhvxBu/M0xBHA6t+wXvnPAJhfxs8A4DUAta/HAOzlBVSnnTwAfuK1arWTt28AHGRcZD9+K7AKr2CbVFw23XS5/HTtio5gd0zHOPbGJObgUNylxnv9/WMBgOWwFzbbES3ITw8YwK6Ytjkb2Pdj6htkxjl2tFf/Prj4/NzzM0474aiDRtTLfHbu8Xuf18FNjThcskH+WMn0wwyln/6vB1QsVpvd4XS5PV6fPxAMhSPR2Aj7UZwM0iwvympYj8aTpp3O5otuuVpvtju1+8PxdL5cb27v7h8eMVgcnkAkkSlUGp3BZLE5hVweXyAUiSVSmVyhVKlND+sam1t71w/t2L5zeNfInr2j+/aPHTh46MjE+OTRqZMnTp0GlFxo2nhl0zP2otv1TdC0AeAEqDwCAABseQ67j7u0VgAAbH1xdV61wX1uxg8uXgqjT6Y1eAlu3L0Hld9FsLatvr2ls6u7o38A0Ldp80bAeX0nAMB1odfTViBj4xGROAUwkdHQMTBubpFZkWJ2TYBmQAENgZWqANGDBIIgXEsfmyS9n6rbeF7YlWyazNfTH9ocHW8fKpzRNUFM4MND0xJkErUtspzrqi1gFuO7IhU1SbWn9QRZRLEWKldnyifKFbGCWVL+e+MHscJVsmqSXk/V9/YWnuqEVXhlA2aWTeyLs2bP2eXyQYMUgjKSdrGv5+7mx/YOEQpidjJBToFzKpAv8lUhmXtVcyRL7xoCTXnjr8YfMcnPUlRVVwzD10Iq01yBJQJKQCVLugXerEufh/chSXZtkESs5BXDJ6DEUSOKE4zi9cp3T6CBlcapDDfQ4FUpvGSdHvt808iOQ4+jt3i86vNSmH83RFUVvNt6DPgCJ6uRoD0sVc1z0i843q24JUdZ26qoxHRp1ObMam5wNKq7Mjy30CdLJ0GBc4CuBA+eJI1CoBjLPn5WN6wRpKuHRQ0MY9kxa1kziht2wiaZp5y1LhkRB6AIi0pKWO1RB0ZCTHIa7RJatEg+Agl4idIFxp/0GxS1yP0AoXR9yX4DGZxL5UiKR6f5saOCLDYnWLRJAINIAQUyG7PSTbXIOoDJAoDTKYDTa4Dv9r2rliM7oUXjgNqhCQ8nVkoJbh5M2amErDKSiEroyrhW2euXJQUh8UZFUptkmYKEQgqRWkYZd0l7shuFtT5c+9OcTUHjJBUyZhdP4SpOpBxCsNe7NOaY0iU/SwpbxgIcFC5sD3qektxynSDwFzthrsYBboNgRCEkSmBboIW/HFLTt6zW1if5XEJfYosHgbKiyLPWNCGeZJxCmrN162g9KXQtokZH5X0PQOk0URBonWm1TVPiOZoXsCghrMWbCYSv+j6z2rEiBCcnPAal1xKzNazWC8GBHwSE+FiY0ifr5UgLqSmAyGXCd7OldMznrCKXSCgVssh3XV4GAfwAQjbZUKZSJjDqIxoPGXStXetAAUQuBd8dL+VDIB53AnKZxB66r7gJIl7yUz2FUR/RHqhhX2fDKtgGghVRy8wIl4pXzrrIlVqwXM7s63KBDKusu85MG4wMxSQnlCabvhK5JQPNEufVQQYDHgJUikpzZksZBJYeyp1YjRISGXZSIrS1AHaC5xcJILzV7AW5nsL33XqE1RDDVtgBriN0qu3Jcg8cWLR3Ly7n34Dr0f+9t+q/kGr+P747nUlOmNKA/C8UDzpbNEURsqebyHZlgiOy8odfshzv2SBYNiFEfK83SPNVh0q2jIQP3sVaxS8p2c1cEHZ0uR7fa2qkZbOQw4W10BaLpYNmnGMmGDGKW/WXERk8n0qYPZovAys+6EyZgFYdCfvEbNGWUyZk0EyeG6ocSt3veS7/p539OpU8d5znd8uCJac8jxNhQtFPLez3ezUzQyiQqDjBQIc9tD2bLFwTOXF0tk/W55yFzMrPg7/rVC8/8gSiWD1LLkDUxWTuICm6fSELfrt/c3/NPaPz6C1BvZAvbHflGb6MfkEpRobmwEM5VQlBQsHOXUc7C9sXb4GFe3ajCwrG3Lmf/OH7QLXOmfA5aIqbmycN3HZvBhfTkdpTv30XdvnnFGpveFTigYUjihyIeU2LXILU8i6yrDL85+it8NJaLpgw4fP/338e8aXvd8OKXSpm8k52M6ssCovFPNqebfGcDIZEGjI3hc2gDwQS+GAC1cLwEiuzGwTYpnVm7myDVQ4aNPaYp+XgVxoa/cEmVOaVZNxAUyP0jdquDWs5nV3SG7Q2DEn5JwBxnTVK8s+KE4r3urS/HIsmtejs/pdD6YPUnAqhdO8eqlJsJCm7co+HyPNqfJ8MTW9Naf4BYSmPzZeeIZutcp6T2ESbd6ekDG6yCqwNB/uoJUKjRpBBK8CkzSHl0RIyLimn46FGh2DgsaDt1g/SsWjLal7l8MpNYX3zxl160xbeu++qHUiQrZHOqBqK6zvYCizFQC7D8yySrAhPihOyN2/kPjul2BKlZgky0RD24ncUNI/hVI1q/yEqUudrxESk/8oJ/jdiQ+nZpmFOx8sOtXldrXcUiyRkz9twnd2qX2oU9Yy/x//HzkFD8FUztMh5MhfKIIexmtQZJ157+FYAFhgJSjbtMHJKAQ1Ml8vqay8hwJBGulbJERqnOaVehsry0/GrP12wpsaIROoAl9nmJiImFbtewPWf+1ofxPH5NMDGvGz2g7fMMsVb8H2jS6OnRPPECqoKL/W+Ei35bm03cIZcbxRV9q0/YmT8uXac5lgUyfrHDPj0Sy1VlhsGlv43HGre9QXy5Mc5xkAJ2Zhua5365JdX4/8LMVnSWbtP1C6XOieYpOYQl3wOp8iFEudXcatXvoum/gMNb9xElQH9FG1JtNG+T7VaG4961Pfs61EkGVUGc3AwCw7WX3/6adSrnzMDaZ4/9tMAtKtee+2JHJJtHa5kvRpN+CxEnZDrn4LmPfnmqwwf+JezXiEqVd8n7Plc//3tt5G9d4h1Lar98cYTYCU50Wx9SDZVPLQTIsEvz/EJCEN4DSt5f7mD/BbpSvhJHszK4ScVklTtoWiBVeOQ32ibPmqXjvdtJE4CjJhiA020CbnQID7oV+I6562/NhJ9avsNrDmT5TI8u29TA78KCx46FgSjXh9Ih+w9vtFZ64yU7gX/ho6ryKD9FhaN39NUj/9/qPWEFSqhphLMFNhlOMamX+arLkCyHv4e8bP1hBJugHITFI+2gzkQnjUlzDVr3qakaWCNLVDRiMVNW2zUUJgFfARWXon0+g98yPWP2+7rUjdgb+NUvz0ytna/GUlZoGnB57bpi33RqvDs19wpNwZvoQ8mqCnnv7X6At/9dWjY8u/XNWL7OlCSRUGJS+Qdrv9jqupt0wRySSeNdIb7buzjJJWQoTDyxYDrWP2Lan4vOU+8/HHb/kGhsB79/TWyxmAg66e++OUXJYMmSNxQwSbIHysVIjgMzg7c8uR1R0C4zownuURVI0m+R/zxRbvpW8+0qnrN6ivtb5fjMt8f+4mx4xl9REUvmWmXrmZLhL+iomTiYHh9p1J9vjF9v5Y2XP/87ceRvWe++Xwzv/1GtU/eOAcZRb4qufcOv7wV3pdc/5t2dV9Yb6/fhnimvMXUTcI6Qe/68YyghhadZ6E2t9F7Sz9LkfDp9UXcZZVNOSid+OTiiSRT3OshZPAudZB14/SpDH3VRtY4ymMfXogCcErsR4LX1KT7EpNM2jc1/m3RFukNwNVkUTZtsMpNolLOd8ySLPLmhaEtSOaPWcqr2VTSrG2blQeryhgCT9WIAlKlHxosos0rDLD5HDON/M8H8XvgtEJSGk+HbqzBSKoGSuB5UCBlG0+bXU1Ama58BLLp8SfDVtNTpsRm09OkKpSYVNYl8yIZrvotCt+b18oleJr2N34Vo293HQt79HfNDndhcm7LJOTh3Rk6J1S2TFlqdYuEmTIUceTM1Pklcnl3mlP8KdyMyD1B4iLUv3/+wku2mC7/C23+M5gkp4nAEqjKoZmkvJuK91Dl4K06XVlgq0aVIK3evx3aIlEl5WAOyqfIamfiVXI9WDSDzZfk4qkk88etLV/layp8qrwn4U7dd0vEEUk1CUSlUdV+qRIv55CFm01XCYxN/tMboiU3sxhWgNflEPw5Vtz8kQq875fkLGNIpVQWltnb7xzrXC6XtJXUaoE8k5O8zZgNUM5QQDbsvUgN8NckAOBYfWlWU4iZSaaZiVP3y0vKqb7/9e8NMb589+ZU7bfv3mPrks6V0gmTaLr/TjqSn0MkyVFkQWaLrMiA1/kwQzDDmAltheZctNGkT9UX0yiep2IM0y1T72oEsK1fW7+vkf2NrBD5LomzApgjACbrGKb/WUDSPg0BtL5v/b8vjwS6NP3Y0P6RO/G8AgUuHj/Pg2vi/Pl+BYoUHuDDj1YrfYIb/HYQduKW8Zbh9FfjV/D89DuvZK/wbMk8zmHev8bxJexY/U6JcefeeIkf2J7G8UAe2HhYYj68l/1StnD3msf3/ydo1zK5XOEPtv4FsrKQg5mV3LwKiQLXXWUayzZ73UB/2+h1m8vnZHBa1//uw6sImc5tEBPrVSpyR6PyFNzucRZ9DrHUaxcYCxFL8luhykDizLwDQ6E6zZkHrR14hFJHGkzXBf2HNu9tKJiJxbJAUSiuwGLBTCgkrIjFMD90Bszs8VtO3nTqpU7UOfgcoGEkBcg6wIpJSmbFdgA5SSnY1xjACQa1uyDcWbeHF0PzlTxaP1NmbibkpcRwTjGAybl7Z6+0b4v+h2UohjOpt1DTqoRFpVIMtJ2lQ3CCqHEFmQ4V35VKI1ZChBS4AYlKt0jllWIcZPIKjJN60ixaXfAsVzbPv+n4DFf9qqxJtjO9Vktp1/GpaKuJVgfClp8x4YXX3+7RchlSiNH8Ouw1hEgVf4eDFrMYDAgnRVDv0GfJQSRUPKd8XvqXack0WDljglxhzGpnkGpWIhSjmKRehd9Vb2utqj5GgzZMDzQEeOunWInZav0MYxZyxXSuv6F+mgGtPtq6eqsKfo9xVToVMJ8fmNQO9+mltQXa4GGtUGEVnl9TO6bzq1COfQK/GFWMX3xrC2vCl9iCRiRHchipqfDW/yuYCmDbmGQMjEIOjK1CTo4FVQqbaK+adx1X2O1auHEX41VTE+Nl4/AJqe35hyhtHqa91DeWI8+aW9fTJJJ+WlFr7tny8txzRS39dPtYt55ubkGew/q8MhnTnJlqAi4/w5zmrehWxB75k5WtRBiALDsyoIPU3thcljPfleWkMXW4pFguCVOUSKI6ErlZzbcIxFYmoSi7IbFQ0o7QiBFlWB5tlVPgytMq42vENEO0NeeJgsjPpOUlqk86W3ac2u12z4wdSZVkjBwcWIOHG/mUijji6qN2iBKf3VgodmAagFTZYIZDnX/+sbAgzkbhm5DvWoN2X3XLsP2VRbtzjRvf14sglRJY2hnyiQnFxBrDGo5cskLD9adAss3X69YUdmaaDHmtgsLcRoO6OYNtOibNFWgi/AVBFWGdS0jA3QlXj6uK7fm5mH1EjWY/zlVNHjVYmQeaasexOmZ7YiVNahwpy33DYLc+PWcu73vjTq8nZiXaZhpXq4ak1sMFHiNBGH6xndEIoZqm4hY0MiYMJtZ4c9MpelHJaXbzesZjK5SH6XXY+vN5vN58m+32Ojweps9m7cfweAMYq9V+6Zt8QVTVPdiKsfIkPjPA0ktOrxYqrbgejAyFZySqs0WsxlrpFhibvRVWK2U1iHIgKgJLmQd86yRy1fBKooLjbrVMZevs49h6B9Ft5DNJq0q4HVACsR1m4+Mr6UxilZPbDiWSW2FWPqEaocQWqNSESiASWQ3SEPK1V0vnapW4GhAWWwWSk3J0LtbW8Dsdp1tLTnJa3YynLbX/Ks2Yy5DDT9G1+toFrdaB4n6MzYru4xWiAdDBvnxrgaZnTDTms7Y8PpXsCl/GvJ1tT+7ra3llKMLPqIx6T9vMoZmDjqDeUaaeqZtbFCZZFjtmD84esn3ynwtUkPeuY2NSjR3nAmLp7alWJaZbLcgsF/JtSErMKAQbVMSWsFc4oVxMbvMePUJIzCliUxFWLsUSk1N+sipFs/C3tsuSyyzz/f01ITcvLbH1DS4su3K9NGJvhI8ywBJvxQiVWbjUZ6DFpMyiKUFRx6715XScsSivMRQn6QAmMUNc2VIDgs7uRXz+TILtQCqt31Bwlf791DAZyEjE88RproDePLfR99j0H46utj9Mp0/aPDb2Gb2Pnf3D1iNwx9PiDazEfYmCwcU+b3FZ8NzSFBDtF254aoY+VD1b/nh1pGBtG6Q4qyiAA0XQ/jv2ih8eE8RPjpVceTbV/j16lV6H+gt6DuAzNkyvRvNNsAa83XpUA6kB9VyuY6OazYaubHa+vABlgtO40KKcnEQLhauF5zBFgUgNnMRKLMpCxptwLDksJ0anfL5W6nXo2n/etln/w5hWPhxEM+or8+vD0W13HX3j1JeldeL3G4/cNa/GNke78DYLiCKEgDg1tZv4ByA895f68du6hWkH8j30ar+Xc98sf557RLlw/Mx/G7ZUy1d3PVb27aO9a2phvBvY/VDesTob1W5Kwcx7JXklUymo231H5s8c7FXJ1Qm0+mGXzu/w1V8re+wHYI1cbSmQwEXQOn9xbay7busdZ7yuqhO/HTp011i97lO1oz6MyhuZlb249THQw6Pvn9rxm4YFpVQp0/qB9WadMh8bOFawo+83qW9nTCIumgvshIhWtfIpO4M+D74wvRgJX6Y8O1608VgyYumSDK/SKmlNws7UCtLPifBzGSX1epKtU0DXdtzrZR+03NSH2Dv3sML4QLSmWAUXAF97692vzp/48IGhvt9j8pu8+ldlz+BfrsM31fOa+ox+U5f/MDfkz9rxa5oFptY13LeDh28XfbP2tm39JOf1qjX8txuO3DavWXvX1s8UPOGHC4FsI8Phyt/OEeXuLK4doMn+jVv3hVyCZGkhLiIZ8Y1QU5aN/YD19Ce90e6vMW6fKXI28wnX6eUV/kkZ9hOIufuuW6sHOFLhD2v8MtxPqpBtYlhdBStkpw6IK2rp1EXm9Col6EHcuq/EEhTHO4cnZqvLkLifpAy6rY+0VOnnGDYXbkLO1Dopo+nOkLKQw5nVhsgGaGjrG40n00s7/scV8zM47UOe985tbyHC5cb87TBbUEXISXMEkW5I62pWnoGX8370nGQrehSBsMvmYIW5o7efmJ5UpbwuOTxt6embsTnHYa/L79fdk+/XefldxZ7972s6ruf6ZHn8PmYBTiYBB5Ls8QNjipjtQg7g72nJs/+VDQ+X1T7Ddv3UWeC9NGf8mzFVwSf0J3U8YD9Y9U+/L7Qq53nitYpPro+uKv9Q4j3Kx5xRs3HRXnGlsa9a4kxgOFKA7v/7399VHVbE+xvwO9hhDsA3/4bYCvDpsIoyvLQvZCXLg+Q+D4b4RsxDWb7cslKLEa1MwyjUDw0P/ROCFEGhvoQH5bUHCNFxhHQi2lnB3Q7TCA5GEkLNg/sO9bMPoIWoRML11qlzz56dX5CWJOtZviBuBiyiKF/q11FdCKUwvVJLzUiUU3Da+JxVcyI4NRF8elP2rm2z9Y1bAB4YBmi2ay1NJZPKmiHsBaOdcaG5/4S46JuH9Xn6ewu0EprRRquFZlEW7L+CdggkyGGza4Ahwa+CaRkM/QiXH1LCuNTeO8139jypzy7VRsOEB7VmkRvvqC4YVqlwex01brIgyxIlzcARp7RhmVyVk9AcRy05K2wf5Myl8f3tlLl692FxEbEm0YgViG8PGAE+5E788PjPT66MXY0xP6pdZT8pbR6kXTQaZZidZbWDZEnWN1Ari6aXKE2FxiJaNTRHNsAqduUM65vyKNaF1v7vJ1vJaWFbP/OizS/Se7ePlDzkcPZieFmWSGk6iUYXBzcLVWWEViw6suaMbrKPnLTJ7ugbdX9jOBzYzWyvOyKee9uF7Y87kE4QE0S5yA1LNjl2bdmRwksRGUQCob+VNFc/OCm2tl3vJhvfyVFWzs6W4sksnawPaC8QSn3xsHzLI+3qwm6oRZhfL/6A0T+oLbcflTYOUs7r9S1ZO74WYeQQ9IXKdrbRRKmEoFTrOcb6rGELdX467cfUvO1dJ6pFc6vjD1h1hsJLjbN12Fq4RcyuXE7oI2wBG8C++rBThlMwn8hMHJQTAE4gR6wH0ZINBg0eYeOzi6BZWHtMcIU2Xs0tb3mBjdpVpw9erXBszf7nbSF45zhArEkb5o3EEZDBH0YDOMl6/ziB0Zi8Baz4inPd6axvGt6y1LUxysfzUP+X5xis42rN2Tp8OVwlJFYGUnoMhd9Wn67DlsM0QvKt0afJ03KRWldBbI4nj3X+8jr1zhtlJ/c0wO1i/voIfSS+fwlrrt19SmiznxZ2uJlzJXbmpTb3WaGdarwBsSFzRrMCM+yodhMFgvVEey1uWKXAjdhr1pMFPDfZWYvZix/C2f6f7B6ge1yr7cflG0ybM9vxl1zucaG15W43xrghqJWj1zMqIQWyAYbZlbNLIMMMl9RuoomyamE21tFFXNb7HmLNNbuPi4vXPGzI0z5QAnyoadslPf5+KU9TkjjVd9esth+SugYJWwWlUHtZMb4UqiGzdFOyakpRMacWni/byHK4ULs7/BCn8s10TLUbFwR6LFZ1e93bDmnfmILpRBD4jKMUe9tomTkVguXDakHcQHFAQyI/B8IpYOdVWOjt8ew1Z7kZaDd9NE+FNAUz0qHc3JT32MSiSIq/NmQT1IjOlBC6no2eWP2c/bMX9x7PbTVY/+b97YfkIDMKZJCBSE6pfoK8NCmJFuwCElJiVd0zBqOoZRHeBbNyuRUhxG2GjQK1zsPLFiRpwDtIrp87OwRiz36wNaR0NmR8Vhd2Hlwcc3td3cq1x54tnByV9Cp7lJLx0VzmSu4xY/hdsOBHxGeEKNnjN7CTf3RRuBYoGQoa8i/lTob62S13bvaP9wwEW0K1F0LGL+hB66oiKVoY2S9wu3w52WtlqQ1Ezl4HMcciqSm4rjdswdxor67PbFjZ8cLAuZ84C5Fg/MLtYkdW4HLKokXLqQEMh2j7sr9iC+Ni0KoDCwBRMqQHS9XMT7Pl/vSFi/wpgVkl4h3zdWCkXyFgMLhQG42OK4z9sGyHofV3XxuMwMjMSmbEM6DUeGgC84unN8E7E47jpOen8kEMGBEUG+v9/W+enOMeU2jbt+yTO/2fgHT1UhNuunrgoLp43k/5P23yulCfaVVHI6TbxWqvviRjmDSNQDxhCAvByJUVGFdUzsv818Z5B7S16wknTWpe5qC2uonFXNAm9GnmKl3wIatlO+9aeyofwg+XppGza5TyukyK9WQpUvMVLtyqF2VIEwnEeEVWSrgQyk8X2kw8wsRiRlJGsDrDwLfm4RhLScmUhYTF+EVxYPHy8ZUcG9yWzFrCWMpeDE8pWfopmkqj6avzTCHytOSlnNf/NZo1CT/64KhX675+e4L5AmPMiKXH7jLtiqHH5KC0r5hTZYcXkGQkn9kvh18mEb46HZNQYxO3wsh9w0YEjQMK/EoLZS+MyyiK5uQjpLlYCI+Vbg7BeFA8uqMdFGKpABlnuK7N2yyiNZQJ2yE4YlmEOo2FBuDyGZTNRHmGGcjPz1AgC9JYHIh+mQfXqzNxOv7DUM7OitFYnhes2aAkI/gcuCmgeKF2CeMjSJhSkM0XphcFppZwgM0R3P9iOz9qQjJxKlhWiuX+Fvvii+f97dt2BNjnzjsWb9lm0bmL+R+FHdv87Rcv2hfnDvUY/zo29Y+xr+cf9bEJ85fBPuM/x479re/v+0d/bMr418duXsaovXmUX153xQq1qKNVhAIqkLUwSlRP4FshzXgZut2kqkEgwfwgFuRrkDr8SD5TQ3DB6KLd6Ppq1sE6X9we1WhlM9Lh/xItsxVrrDVcVqe8uW1rf2tOXKyoZKkv+OXyQARmA1aSJgRickEcRTpWy6MmS4MpieejSl6ZFmfoapj18p9RvxvkW+O3xsqFKtSvBvlQ/NChCDBaoTBdlhVPyQ+bFcGt/bI7dvgLtwQ9Pm9rn57568TfG3v0f6D/bURg7M1bXG0cWYfUIYicRFNGWpQ8h8ZB+LyzKJ+pl2j2Z8jktSJZcaUgebfvPRWdRAfPM5KxUikGL4wlbhQeK5GjMQl7Ty4NDCw4udc3bkvrNkfrGLW3U9QgWhsv8k9QJQSIAg4PbWLGKDO6DM1X5j18oY3mwNKxCKxZZc/gBqOBcz8ChNdYMCeITi4GkdAx3NSCXIEwWws6QAnhF0CxS3DhfxN53q8kH8ObCVBlaJapi0+kD/PbUjApa7tJ5DjyFW5QiqCm4DwIspWkwtqBnFwYJ6XjKEi51OFgpqZx+DmlEdi6ffoOXwK45sCqfdcjRg8DPDGIWpbi18BsCg6CWeOj/OeD6x/CPop1DlJtAs60ThstlJi9Zy+/gf0XusLrAvgqaRlpkbXUelzBtdfQDoqdBkpfjXJXqswr6pnHh9sgykjUiLb1nxuSG0BY5sVvlSEZq+hgR3KSPPCS85oJ7wx+7U+ko5BYNVaG1K3AeutjSDH46Ga8J/XDZnI7eaRv04ztDs3ndQ49q1FpaMhlsxtzlYbMRjo1vUGtb7xddkOuRp++TkdEJmqJDCUkO0sJpTAS9UjbaaDQPsisDBWUSIOYsI3d8qHYoXj5rwYUX3W1AT5vavMRdm8it7WpnZ3P+5I3Db+0ZTbtbyXnGfbTgjTfcmL5I/KjBsK6oWe++vSP9pr4guI689rDn/turh+uhp1TR7OgPYv0hZzgl3MvPIXXGMtiakd7s6YgW8CnKkuChU/8gzjt82WyTZ1Mj+/xFz6R7kcc369/9BBziPZ/ZEkr+K91NVyQWSHk23KpMXsguCCzu46C9qBzm/YYgqRs814bl2wB/muqhqkp0OtijR1bB8TQ2+E2JbonIuDenGSO4Rab+xLN65mgz7+p5MqaAxX7ri/w0TU7TL84fPuAq1aM+/CEsMyfKsbBssB/d+5OAH/SKLyJfu9MFBgqGR8yVBQV245Y492YRz/qUH/y6WAmYcT1WndnsUhIJJuUlcMi5SH5CLk59iswBhYb5xcb+9HhjYwbu6wg5zxSPxJJFNPfxaY/M+Z9/CxUT9d3947T0Kau1b8jQEZZZTd/O4zllasZ6g1U3PA7Id7Z6fND456bCp8GR91NOCoCtQH1mRvTuAfQcmhe0sghMMu9Hkqs2AbIFa3eTaKy2gP4aC9ByyQ9Ged3cY8qGPLKbLpvkwAYOXR+mH5heKagoXnujldPAfaa2jYhnfAc4p3h6S70NUaTDgrW7dFdFnH+q0NGzuquJ8reZbB/96OXQrUKrEYO3l5UsbmoPzkWlg3+2Hn0UxXavAl+D/YQQjqqIwF7kXcL+e5Knl+GXmSSP8NRayWraTbdZN4KrSbnpjuoZ3LZK2bNucgbonBD+goZjpR0oqgTYnV6JF9gPMpBLwxOhpfHqXfU4CNYSncom4zAqFeZtpZZ6pO2hPSd/1LZq/U4nElDFKjL9ENl5nqIaWeH8f9yKQTvh4H2w3mrc/03YSD9cEES3iDaiv6RSkUghRsWt6y47w7kV90YvOphmBIyLzjdVHBT8o2hUJ0o2xrLoA/Xo+yb0NV0CMxMuARK7SnYxhMhcc36R935qriFUGotJNTL/8eVi5ulbQmT5bpNlu5cEbsZrufnVBKyQIY8Lif341bg/vubrkKfes1T3PX6ed8eZnqtRtoML4xTxSJ1IAouUZqVkyyjok3AHHIxgiaMryYvqpSWDC98nVre7NxZfYHay9PW0uqjbP91kjs/oO38MO1iI/hRNubyO+XVt2r+xRaP1FFKCHnkfTNVriF32NNCiMVW9QV31tTL01r865H+9RGDsZ+dxzdJUe0s4+5cCbk36JaPGMgJcm6v+4Z5AC1Gxa+/m0ebTpZDLDj9/Zq1JWf57W7WXIlvZMAhHyllqMzphp8gb6tJsFTb83pCMcwsrkW2rCyg1sYM1AgC6o8gqy6KENSLbNtKZ6ArsZNdD5TnIYR3LzB95IStDmd/vkCwHm134jZXCWE4lL8P7O99XVggW2bGuiAEj3RJ1/5/Ff4m47zD0no3ZaZRsuYuuYNqzu4UaXAms7PBuW02TQfcjeJtE2TseUO7GV/tyQd3O3jx54MNYPj8cvCz4bXi/FKEx2QqC6NPLrl10j0GhGTbczLqjN/ym585aPFjHWuiuXGSZe5715VZ6t9Y7d/T/L0b+7p9W6uGD+85b6G9P1Gx7KBNLq4+TTgTelaxmnuta/3ZwuKiU/TWJs64SctDdzssZenvuw1YZxl6k4CNbnUaB1ACgRvjtKIH9HDeIY+BiMHP9i27v4D75ovslvm5S7XF5CIX+mPyT9F5ujdNE+eryHT7dS+2Q4xP5G02iuQi95HBd0c2f5TFolzRFeMuyS6xa0Qykh7s1emNkPHrLLJeyih+3uD7+89sSNi1l0lQShlFL421Vs4RQsHzT8+tsXmv51x8+dkWg4zw9WriThEKDGCku+zeJ7Z+0l9zg35H5dykybxF9wg1aPKx0H3SKEKAqAuthzQtj4z6+sMMHRoH1JjYGPlJ19ry41rXVtxFjYeNc6l5wympBWmJVmbTKHsNy7NI+mKGC5YtG6TbXHnDIkn2sNnVp1B5u5ZAlTSaehcjuqH5K6KKrYbOml7LMIHtddo2APmbDwmAfzozG4FC54Zex2G0dvDvmvHrugExVN3l9XCzcGl5wW/MGb6N3Ycol8sagjnpo3+c3l9ymtu2kf6wadhHTN1UVrYJJ8ixtXcbT6CFC4NbxapSXFsciT2AcCm19ZGsSI6yoVLajWBbT/Jbe1lzJqsKvdNW7cYLRFWiRTVmt0qF2WOtWV8x2wySHbXo0dzuTHyP5tidA23kTrTN2XjtsHJoPm+VjHA+1+dCwzg20dcnYv5F6QOz/Wh8A/EwRJVpwTQCbINmRfAq1csAiuB5d9/9JHkTzFhWmEZDJM5bsW5n5P54XQ83pin9VNPz7FM96Rs1tzZ1rJ82XeNMV8k6PhN7/fO/oLh/P4/FUg0GctzH5zdgmjryZo0HefOulgAh1sZKg1YYQ8iMIFxgp8VsjCTULa0oen7gwPfGfg6/K/rbWbB6RtjdJz69RPs8knA5IVXmjiaYQvTGg72uozSjsN9vVdAzG2/FNw/l+7aW3Q4tKZo3oGPeYc7+zfzb9hded6bzZKcRT78xuQob3Y7cKAWAi5bwDbLvLWJiP7+imaHKUgVSQhcfTSddqY5jEjW5BdJwZ9aOhfuNol0ig8ickxsR/XDY6lGSwtpkPhNiKfglKZubB2YFeAbz08zBWJnaSvVNCGHZxPFovDw3VxlagXR/5n0uzNZ+L6iooE6Go5TAZjqAv+1toWuyFIHk0ORLGaQ9VQl0sjY33+Nuv/0GWa3WpueqB1NWlNgg6qXgAkEeQhxkSd5c0mcpYUPaOfpq5JtTAn/pbCFqCmsCassM8A7ie85bcClKputC8MdL4gvw0uD3vO9VM/lnNQ9mLC1LhLAWvX0kiFxWqDHt+C1+IK/302D4Poic/D2I1f8jZ86cpLmPQSeCZPP/b1RjHA1QPhQ1o7R2Rp1TBEwgadbAJJkFYVzp6jphbVvyBMsCpYUZ5aApcSAW+yPENDC9kEtiujhhJ0L3y9OiB3FIBDXZZtkN8Om9XLUHa+t7i1NBxgfD0qolh4bk/seYfmMTI/VoXCZ5YeclkkzGfiBGBOgNS4pE9TGvtgT1hhakA7rnulnQSIC8bioujuOv98//+wnMnz583LX+Dxd44mtvvNfzSWMG8xUkKlvcFxAGef8I9E6re2xWh7MuE5Bsts87sBFPFxlQbEqZbKDmKKZI3/ngfFKpodHpTwD5s1CNLqTL43gQwEST9tRRWGBDMZEdxMd7RigatfWiS4GIQl2+xHWnc+dXhE9pw0hLn3RF9eGczHs2xrZnEu5it4QXZ+Pdc5m3ozcgPea/lEfXWfXL5VMn9xnYm0V3D/EV5XH29Nd/r/mxd6zz6Df2+NdpX+PV6nb3sR8VJfHkOJD/zSe7zoqQSyTkj1dJH9HivNxtTFfbOND9vuFb27L72eqQMZL79allmMgBnZLwOAxN2yXcAJ4MFjb2FgswzB3E130jaTizZa36SSGnFO5b/LDwRH67bilqKJzzs4Gbs8QYzfTVGEdcjd8AR0vfMzyjj+w5259zmTAMIDhzBKEWk1gK/fgCcoSPp7+LcAb6KfnmcYLUN/B8ucbTHVvO1kxIMEa1Hs1+CTcZ3qAq43wUKLyoYIF4wdXi6n15RQEEKEvlhnw6tXr5oM83Sp2uXy6ToYVZ2+ea7O29CPEYwPq8W6D723svPyMSEV6wn/OhA0WYBhriXrdz7xkWtta/B6sLNsJkuzUDhMRmSNubEZg6V0hvGmKvVepwiWYhI1LSYJDtqdgMIU8bjQyIAvAhi1nihMGumFecziXzpvRI1u00pGD6NS4bTvBygbVZWriMh6FAUfASs2pW5xwxm5s21MxeKCKtXwBwDEFAILiagKuZfbpX1R8qKcF+ztWQisZRQMln6AVSWcYC9ZrUoLYZzcBREeWYgzoVu17As2JJ1gs2IbkZgWSMEq4SaZi66tMjh8IYtHqhFOAFrAJX6W/JgEtahQxOXSmaCig0wUZdyIdM0YxRwBrYOd28g7pFVGd9rqSd32Sg1bBGoYBAZQGN5sXADsjN5mHE2f29tw5jOAdrxTdC2SMs5HAALiEBeY02oUNEi93CDIBNIehsAoNQixUBUwYbJDAMDZAAtJysB6RkLKiOQhsyvZQUTJGQrsdSTg1thj/YStlsWAE7OdZkrSV2/f//8JV6fPnUg/tJUnHzye4JfNXt8koND0xfjgePhFucMNFPqfW35uYXFNvxf/3F2o2o3vC4fLcmG6UkbRlyBTzsCyhXMjwTDFpaChZebU1yGeacC/vgcZvtEmPvaocHFbri1VFWY3Y4WRfThgFAU3J/TnBuo/8Wyr7P0pLUAtsDDhNmZWq3KxhTdD9U++in291n3FPGfjVaPZoY3PZwMXfg6K27N9wy8TCMvH7eIYDRb1y+fqSedu3OX98seVr12ZyVwhOoPPP5EAx0/OH2XEODE8IXVaKzFscqtmMYkLxFeMw64iVW0FSzsDWpgSt73Wu+aNuTnG2URUiI+eVlohL7hzU7TFNBmfBLQ5EDbDCBIzxHkmzxqYUDM6bocVt2TqilEkdTNbOK+Pyn6BO/lvn3FDCzGa34QimPvrUcrbad0OTcICDm3oJLbuDdVhbLPjfk157t/zjJESc/Huj+4chPsH1S2zeO0d9J/vJvfF/Nlfbf443/hfwzWkkMiSm6viRfYEOp3c6xtutQlSzno4fIoHCfGMRgOHEkCGREFGsi8wlkavnjLJ/YOuwQs8f+ED/zYK8Z5n3I2BEY/Jf3IdD0iR/7ZuLMiJ2xQFD2IfOycZPZ6PNhD5KPPPLHHMA4yBhr8o54jANjPSRiWAwp2rwxXeJv8DmbbE8uZ1dfffq1el84Br9+T4V7wE1J97N75/qvf3Xm3vmrT78Pf0tUwCExDS5WwyeOGW3hIlwYzyUWDaIMfwtcSaMIGT7UYr7QtnkLwcqdxEpJN7Ub0tjX2SXheyc/ayKOj/PjfWnB6JLLSVOUkyzOKStRQvg0iwAxIwaMVEWNPcpovma5WtKe97lRVi7MJYrTJkuJk7NJkaVheX0shjUZ5OyLkaphQITlAWgNdb138kiV2S2m9TTLjLR1YCs1jU+PtixfQnfck9cvqDU8t4xdHl62faAiuSyLzNtPQNUqeZFTGlrtlob31t+dCy6rps8h1L4UktrJjFB5DJP2Np80pz3ADkPipMg0NcaDpmg3Evk9QngpRz8XCYydDRkQpiaQkMLLVF2tRMd1YLKMQsGVhNBnQILDOCZhwkDNOJXBgVb6JoUMsmYp+cIm7Q51jhdmTYixqIXGAVp4GHc0A2PnVkq+MRY5AUthGLVPpmRa9hQEgBZes1mISUOMo4e+R5nXLAYJH3PFYrhJuIsFSbaUQO3a+rsLdNVfMKo9LIcGW6xasYphU9lG2YqwvdrHJEB2PDmo2R/5p7CjGRbMnF2jSItEmCC0wZM5VKP3RI0Az7JTcr71eBmvzDOVzyKWlDsnfDIOWyAXPHAholso+bry+qyyEXhSKva8iXSXhIZaS8ZfdFIUM8VTdQ0+i4KK5rT16KRvV9kDNyjc9vD7DO2NJvehcVW4pHda5YGxbIZeKcsHYXXkMWM5fK8dBCN7nCIpCYF8ryKlzynbP0zzYru7f3DxdxbdMyq/uQIUo33pCvuVCxI+ehqIcSrPvgE8LK8cb/oxbQG4TNLt4/AUBGMg/YxVxQmsrjizkKZFOiYVi0PYURnyfpQJsnyOrSWWTTppeBn8HMaMvivIWJhEht1+pT4S4LE+6Te3V8fvMBeRUCkgWUvRnq+oJxWpzNp2sWaSGMO5rTsI8noRiEqgPCdIKWK8jHIliUVsCLsRwbagiXKzZMDIjaLPOtKnuRnRc5DFQNnHXMTEp2TSQ0hwHshhorCYsWRiUsO7R2GvYTPwIpXHpyt571Rq74RUFjmXVsLn8LhkJ9frU++6GRqtagLJogUVSbUk5/F3LRVD17v5iUSSczZt+ys7diG6MwyGjkSdoQtuWRZOYgvCzHV3baP+Xk6/lYN+3RuyraPpbx0E0IQMofy81amHs29rIijmtnQkAHA7n9W6iHak1wwD2nrDQjNz6IrFvpTkaNRUseZOcagyDFUQ7QpBkYmiyKThSsY+0frbZmtWwuqkMmxAlU9dOXNu53vPLLRaUKboXfXDoFUT1Y8euOYxMBpbqqqQ8VNyVVfw8UCPNk+YPOarcnOnGavdUitztWKX2G5WUFPYTKsCl7Tkiis9u0afjRalUIPERuEoAPHfFhS4hIXiAkGkhrMDPHDzOTT350TYeUyPYleLm1AZMMBKRMOJLnLKuC3x7HwScNA449ziiBunNeNKC7oeBkVN7kJFDeuzui9yNlPR4vmOZR0bLl68aU8XfQ6wc5/ycWSkud9lI+iP6hF99YKlY2JtcNltXXauNfUW8EqOi89tdoeDc1NijADVJVTAjICqiAsJlKrsCto80Zro4pAhEo+xwrgj/Y5Bo7hJLFxBvclgQNLZmE7xp0RwENH5zqOp82ahTYBQJ9MI3cGNMfBlZek40p7M9JgkBoMin2ayNG0IwLTsMCXyZLf7oB4TY1+8B7rSW5uQiIe6STl9M2AC1O0rIIU1ORnaJnpMNGcohirqLBdNF3aLMivzb320E8+taOdOIM91x2Wwxb0wTyS7/dLngkI50T4BAzidx+N/WJugeUlOImaFoWJCK1MYgIuHVhuaFXhR+XZf7z/Lzs/gcd6TKYyDk6NjtTkwrRwPXg+ablrm9A0cDo6OKz2j6C7DwPpl1iIYj2WzOfOfhfVMnCB+KvrnxVMj9L4SUl/ic5tHNmFGak9astMklwBAqeW7IM3gEMkwNc3aDgFOODfPckR1xqktF8vaRB0j4xL5UCyi+wu4gQL8NQqYp0+mRsrJ2suSNgSK7Ws0oQ5C4LAucltNI1YVh+OolNRomKCRNU2QYvh+KIiQFn0C4AlYFs8Z44LBBCa1dYlTCxkJUxBrjPUG5e0VLc6pAzoPpwnD5TwsACc4S18NrGuRD2MgdLUiaWKYYABFyVhFJL6BdQYgPK65hCNkgu2ciRICsaJdonDAsUTIdx29mRDlEgkyIkxprQBNoEBKdS6iVpiqLhi6RDLRR0znrxIEKt8OPi8QG8L5/CYVrsQ2nxm0zaOOQEsx8FU1vrH+oCElpV1WnjAN0Noo6kToybkaBGRiDDodhpbCOyOkfjz06Vj5V9kEs4MFhc34Wj1++VQcI+ux/iXFmSWFnNlSPyfXx8+3frt0l6ZTZxmCnAD428e5yOpdpqDO3zDAOZGlZJ4gnNsnJfK+4EobWr/0wAeblm8bocf8/4+W5ZdLlpdP/eJdmJvV0nvcek8Fr9VbPPKFr93Fxezpsrie0qvZ08nv85scHl7wiXcnZDgWq9eLvn+bcvGXXEqaGBzlrYWnpfACovfg28shpw413dpiljolVw/6zH1iBojfBATrl5eiz0MOkpuhLkka+Oc2NzyzSpUs5UrIKq8OV4BwqDoS6jxG1MQnfjbJKOwcwy7LAVMod8HVIRGSiROza+5hexiql4yGIJeUBl2/wU+UqF7AL9s9TL6q8Mrjy4EBuAAEYuCV/GWSziwO7ieHmiCRiJ0P6zi3G/IgG5ND8mnTJ37nTL8tCsgL5uRlA9mmkGmvCnUrHzl5+wSbCrNP8QX+NZ1uz+n9X9CR+oByl7RQ8AO7DouED3SJV4Fna/EFbpkqJkuHV2vH7Lpmwe4Nkj/5p8+32f7Z+WfffHT/oxCBz39SwQ5BWanAAIiN0kIkllSPkyNltliWSjPTbPVpTPvwrK7l8LvZ3WwGOkGsBdJWCKDN8wZGboYkklRV+ebAQ/iYN9k9v/r2QwXDx2dX+pwl/Y2jq3T9zes6SKX8Jpdy9qu0ChvUlAgB5tjA7XJHOLyJfbtVt9yYYVnl0kE1dRtxGFE3vViut7svwXBYLmAD1e/S+kcmJuP26jiErLpFBSGp/6jKDrAKs8spFKRJmlSqLLkQRMUt5wdHzpIeiU/47iiPA7XpC9fXaqo4L2iKL2bkSlwAa23VCtevMpmoZI37hG7sXexOnr9oaY595pCS17t91j7xDI3ec9Ol+nVQMqkGeXRhWK/DSVEwuTWZOFUaO1gNJwAVOLSZYCKU3kCGsyHMbKuBCacUo4K9i4gifoZRQJWxUNChu2p3osgyKDFVvDeuKjlwCru64JyZkHp6sRDMRvbDShQ/gak1m0ZYAm61jF5mS8Nayi9xXgP8YaQaY+vkZCVhdh2NGOlQHBzY5+ijuYTWrBkpBwN5ah2wGZ6CEYcni1B72t3f+8aiTDJs7YGCcFRkKR4kPGh43DCCIBFDdiIAP5dyVWxgnOiHSDbwyOqgBapILD9UU1Na4s3kYdrYyiyt4FFTOr6aGaLdfaUQ1mZZYnO2dqOMoK1hwEJYtJ9hQGENBYQy0t4LckLOM+28kos+KcyXJEAapaoBDo8lowEeoRXjvpbg26NE2CBKdJRh89AfGABmNVklk3AeXC4MwrCDLI147M1pgUqJY3cnMzA1SqpEsz3djlkXKkLKqYvcWZdqPyo0TBPF0IEY8XyQ0Z4iOpLIKEaL8PDh6s2bsOP5J4JGhSRG9yWh7noTvt+NA/V5PcqYWlUm5bA5daLafxfvbTox3Dvi/WXa+FDcvnOUGAKjRBXUqmIz3i8jhYyAJCnNxSKTpOYha/cp88VJbyWpK5MCZ6vD6X10dTcH5AHiPG+bkVuExWrbWjd8Rz66Emrj6XkHKrjR2wt7l69rHPEiiS8Q0PK945Phtcwz85A8xNjq8nC0AX03x+siglv0BNfA0w33/O5RXfZsyLeTzK6OYD/7zstGg3ed6AMXz9Tul+kLxf3Tn+An7514eHLET1/N9VXzwt7qVy+Xtg+nHvCUcw9u70onDE8N1uh3FqckXl3Ai0ELGiE2cFDD0yYRapzk0FrTEvFNgCl6peqOyoKrfk1BU67tnozDZLq7pR1Lsb/KyktQ3TnT+zWJj5wkGa9EtaSl5A7vPtIZh8LfBJwj7Fcpsjcips3yY8LMm3C1QQsEC9vk/N6fbMw6Ww/FCA11Gwv1Fueo6gR0FIiKyhIONUzjxBTbwObCXD2jkkupXMg/U5Med7J3NhJgSxpQKP22Wt+J2icmfQ7Kau4EjbZkwxdQ9HE5xnQ5bU6yewARl91l+8eqrVqlxzBSKo5zJyRJG+lEgAIqRJwRqRrPqK5BjbCO6rDtkw8Xf1P3rGiVj6++GRHAoseGjp/zWjHe3Ts8v3r/4R6s/ar6n3mBmK3fF14nNIFRwqmrw5v31+nq3vaB7K+/v8GfSxJxolYJlzoP/DRhPb6uWiWU1zmCH4sOCUFahFQcAWgSzYW4EgnoTfITIxoiLhnCUlUI3zInJNooz3mNosdrsQTlALDvOccowQhULhGIkhpkgDJPYTBzpEZ0BQzQ+Ewwg9WVHPmXjU0mp86HfC70TFd33apLMGy5oWCF7NGut3+KaVKD2M+gbECpx1OggaQFeIp3HqYYoeomoA5xPZUYXhHJJsQ3Vac4rdgkNTFAubvOcXT2XFU7Be+OG+xoIQyqw9pYCf/uduHvoGpn+UKpc5v60e2q63p/T9u4Q7eS3lEW48dHDs/YrTvtXtzg1Zte6t6GI7ev7JtR3nhPGrsk02vOcUDvrNNbQe1pnH+YvNhuH79kHpPdi1apilhlWmQRda8GOkL8PZHT7tec+/5HXh1rVtGiDV4sUS23ENjiPFhsZmn+paFHI9mbALyU3KpU8TJePAs5jLzV2WDTHA2YJTwC1UqAvn9OCC0Odo9SSEkxWLYW3vF6c+JHpIdLy4/WRzGSlzdvBphiuHx/NYW8S5Xi3eb+4ljoce3Bv32+123VZdij9YkkSB4dlNLCumEQKDEzg5iQfJKrY00VMKpxvqWoHpkh4e+bLb+djJ7OcqYcBi8MA7YoeBVKMHPLcEjIEBLaWT0YBpxGSgWqHE9moGwLh5086kewPtmjq92Fu+FKB5cneGsTW2+zyghLG01a52ThwRa2dMRdg3H9MOr9DzakXLbKYViuVrJpWNxNAj/nZxJmWpUC/a53zM0D9Obt8g3Lc+gzN/G5sy+/Dvil5w/dPR7Qdwfqdtwn9FnSTy0r+pzmDXInm3013yXnFwmFs9V+3srCz9Fr3zt4d09bk4quvyw6Ayr5+m9784Zld+cvP/zipYd7/pz5mrz70URPWYkk3CK+lC/pjjp/vA/XGyM5hVvdh+NMD3nR8OkO/oLiJlAnbqEhe+/lfIoSPPKE7Q2DDZ/8Nw2/FoH6kkR5CXrvu5/pPv77/lb4MtgdlML7qvWCss/efeEc9NNbkt7flSW6tmQbfanQUPirFHHUOm/5S2rDn9fZJ3IoU6Z+OfLEPb9LRfrBkt9nephhdHj4KvcGXJy//OyHKDt8BvNPlyL6qaA/Fwsq973ySDB8gWLghqHhSI7MsW2pBif/WMhz7ILqjnQioj0/pflq7kE00bPhfw6QPL14+45w2yUs4bSrxbfqmA0rr01TtZkLP5NQ+Qq6Lt3vXp8uVchs/D3AqwO16smhp8cL3mYGKSd4Vb4MO+r8GIKJbYE1xo3BpiSnDoacdq+b7brPTJOJUASWgsMA5F0aOPHmsEAZL3i+2r0h8DpNGi8CzyMR0enq5hmzGhDQGCZoEsJP904wURL1N9MN3ews1KBxOVzs/GZtNiBvh00+lMxgbMvO25z0uSFYlLKrR30kZss2VO4vJmwxWIKDyprNODqstWY7eBKvltI0SNYn1GyN5TxGNUTU0U7dgnoygCVikG9bMrhBFzy5zVS6dd4nSdS7sRzCAbYZ7WZR6uHwsxCf0gP9BSpl2ObebOaBHfDpfk27XXVdd3vak0UFUWj4hHRFwDzXvQ2pshDUU/oSm7ztMf9c1oK5wuB6RlmVpNjYFa0tKjS4kIkzyKl2oEhQwNTPTaNKBJ8e6S49az63Ns2v4Ua4SRJ+DFi3U71uDj8TTTWqMc7xV69lJJsbpRjovDykQBHB53J2ALKe1l1GPdy8sJJavz3KaFaios5HUUHqWhjtU60037hndQKU8Se0TZ0aKGeFjJ7KGCC9aSF2HqPJ+jQhECll/ygAxEFTgQmKQZEOoSa9YRgIUQ5qAoq6warnm+RJUp6+WoS6Ro3E0bBEQp1niJ6MjKUAinFqSN2QY+LYawPymkihdBF0dCLgk1gPAtYTWNRUWJuIk1KPbVbAwaIwyZfDYF2UtCl8s1inedLasr0235vbhnszjDaXSnrHfaafUBBgz04zspZohzsoWDuNM6fdWcKKPp3nfKTmv+mSwPmS/Ya22nZYjf1Gfh0++wjyqvzO5S312U7Fgg/y07Cxf2tHtn2yC68nAXq/6amE93ZgODV4mbJ45+5omRozNuWoBhbjwczC05CnZga0Q3YOWREVPHqAqKuRmW8J+llkZVZbyeIupVEIan9nm5hqdbNt8g7TOyoa+2alGn2GqtyAWFhp3Pp4v7HfaVS3bu5XWhXhujU0YIS89g8XQ1hPZwjixnjoDq0p1j1T4IgtiK7lEbA0rjoSJC0ay71KQ0FQTC0U4F3esUCwNiinTu3R7B/6pTITcRmQZk7poNVjpsDxEreWT1iJX6rVF4EV38Rx8e547yJMQvbwGLCksHhSml40qHw8jBuvMNjr1khVkEUuJr2rIst5TUJQ4vuiPYVYUeNiVrF5Eou9nXe81mx8QPsB/vRJLqookINtt5HsCjJEzAH9sQihbzUKPTG4VGxzBH8SwkkCOTW3RBzml+hA0WaJM/uE3nVm/iWk7vGS0TCqcK08AofkJ1CifeMj8uiEbQU5IaglUrX6pAUvGWUIejNlbffNnPGhowBkgBRM6BMuSkVdVXN1gZrpwTuRbxFlOyZaBglacd2f4mRrrG4RC7GFFedysIH2W3rZLvM4XB4ByDQkaPwVLNkFmsMkML/Ay2MnFssjvkguc29bFLcdI7ojS7N0NC/SCQBht1bMfJz4+FjWZuZzLcPFQT4ArY6mEb9vyc6HgT5qC5660X25FcN1tU0EsWromxFoT5TTsNXmt2CkmJO1t5FSl0t633DBsOPRSVxK/BYWmEUZ7LtdUQEEKdlvyt3ARKrU+OPY3d3tVFqZ1vck5cnIFI2G3Fwnk1s9UJ/pdPKnxSc6ghXequeJeXKK/sD5Vrkj6kmAj3ID2qWXFLsrby5ew06uHSuoWVxjfxtn9ALt0FOlLrjhJBincGD4nJXYP4wzBbDzg8kEewDD9D+Pksr1vL/oagHwHw6L+egr/lf+z2RLknkACy2IWSjA/PK/pQaLd97Bqah8e1ZObYzv4bc2ZGwGiO+dGJ5wf2sU1hv0HqZqEkMUKB1LYI1azuzRYl0KlESPNanTFg77Ya1hqPpBVZIoKoW9UARFkVQGvHqgvEQ0Nc1aU9hRrSc2qSs/mJ1t+vRKtuV11GlAUTvFNce57vDZdwa9PgmYOqOv0fQ7ovGBrPwOuJfb4ER0XgI3ZYwG0tRfAnfBMuoVZr2TNC2B19oiakHCitobMm1A2wbA8fjOkTLIgYOKQtIydRJigq5YiG6pLaUgaEqhoPYTjENQ6VxRDdgS20Xiv9Jy1Hv+D3bNNGLT6xSOuMCrDR0nbMlHsKNNRh3RUxnAUxlw/gyiSiAmX0DGO+VxzJiQZUJtO+3wY6KqaUzb2pLsb++0I7UIKkOifDrEmk66qqCo3fZWrba0HcnqFrUm4/tyCSzOUMwmyBPZzn70y9JiJEkZkYi5ga2lQtNkoZhmGKYOipxGPd2V1Lj5V+DdR2lOvxd4kMmA9iO/U8i+ggxlIZmjujb8JSjhBMbVI+dKZ8vprsSLZidyaYhOfwnwAHFRTeD/8Tezsq6RQFlwRbo0uQQlnAe9buJ5vWfRQbOgiMLRR9elyE2myv0Aj5EkFhn3AOIbgDiq2voDIJ4AuAJw+0tALkl0jmdM1FgT1Y+HPhTgypTo1G0nUpKVXQkwvqfgShPlMqYniD2gqhIB8neoqgD3bjzhRm/AA9hg5wNoD8+aNgABT5OHNwWsx0oDxBWATYEyfYU0hmJ/GvdQuFJNh+Gb6laOaEvYkpo0aflwpymV8X4xza5kpjQac+JZNrOzU8OgCZqa0W0SpRmNc2XaQui8P/1F0Hwx/RV8fpr+ZsmCYb/Dpg5/28UEdFh4EGcvnB8CmyrBbDkH66tr6jFYmo5aX4QnTDg8Ne8shZiDq3dZv/7U8x+10QdCtVy6bo4Ir1ZMCweTeuexjYgBvfMsp8OpoTeGc9D0351qyGgH15nsv2tkhcGxCkf8fdBp0IgzKFfDL6eKOlBsKHqqqTk+LMEzdsTC+DvJPvp9/cE88Ohgo3MTz/OmvWjJed683uKR533Dfa1nSbbhbXvfzwu8wG81IfKBctmWL+RtOADVX6wxJ1exCatn+9XlXePKpRsZjVEVYOviHILTl86pJXIE10IK4SF5X4UGzt+KjuObX/7HRcbvkoTqfyDAu+1n2EH1jX5HGMANlhG0dEd8ok5dSDl/VYTiXOMcXBviGNNgOYD2V7Z+ykvTgM3hZlDCqFZu6MWA4VN+TzWxFPMUHvm3Dzs3GjW+eIj7q0yfNH/0iphUMX7JHQ+KD2VIp3HkWxgzqwnPf2zjgeQvcln+xHYzgzqVCGwNJWuhMks+o5NfIhhSRgqkbmE8vIxLgCArFLHHPLlyIL6lFQlp5lfabcWSOafTEKK4f14trrYxKw9QLob9ekhuFJcTZcmL/ZXqCX2R0OwOTh/F/lcvrFbFOQTPcf8ioM5PEGyMaOSHAFOi1Tz9zNY20ml6FHN4VL3qlJvNu0d40bPRbt34eBuL50QW1uSlDarOIXlGmrwvldyCs9BPlAxnb98UoOewRMO+mn2Ky/LWeuGCf/ZXXq4fr18goPHzWKGt4NqSP7iCInGpAOsbsLq1D/zT+xs7BdBoO0phb96LU44OupIDSMD7kbbNZS+V0XIMuG0AxMD2gnXWJSSlpBH+n/F+PmRkNfC3XIBAKwQJFmKlUGHCRYgUJVoMoFggceIlSAQGkQQqGUzK11fnNOkyZMqSLed/Rf3ey4OSrwAaBhYOHgERCRkFFQ0dAxML+78DRNyfknwCQiJiElIycgpKKurwhuc1WMftvUad2gwZtSt8oOaxtfrCF5NwOObDI2Y9iwXwlL1+/42fjTsccNklYzS0uulcpTfnihuu+dZ1HxjccdMtBxn91OO+u+4x+eQHLYqYFbOysNnGruRh+xErVa5MhVU+qlStympr1Dhmu2/Ucqnzvc9OOOSwkx56FH7I+F/a/NTJYBYbwj+WR0AExooIimBHjJty1HkTJl3QZF+EOONsrIxQ7REW4RERkdG2Ub5GS5XDlDqv3GaGFw2vt2HgohnDIsCf5s9gcmREIRH8qX6EP82f7s/wZ/qz/NkSlQ8TS4XDoql+BrOx3KnXqUtN860IYn4G0ZtQ7rTz3lRwYBGxIfDaj/CnrS69Ah5lqcHVUPsObZL7DLZG3/e6NXjZFNBrKWhJFp5f6EE2bZAESizXEloZUSw/DswsCCbNBDK0nx4fILyNTEdoG65HcBvKRGyrGRti5CJlVNBl/p7YZ66Y2lfs1Of1M8b6Mj7mkuVs0DoxQ2KH1FWH7vPMSzHulwlYUhnvywRNEYovvkyIWeRgvsPfKWuxI8hQQvHIqGlpG50CDVjH51kAAAA=) format('woff2');\n                font-weight: normal;\n                font-style: normal;\n            }\n            body {\n                font-family: \"ralewaylight\";\n            }\n            pre {\n                color: limegreen;\n                background-color: black;\n                padding: 15px;\n            }\n            .markdown {\n                margin: 0 20%;\n            }\n\t\t</style>\n    </head>\n\n    <body>\t\n\t\t\n\t\t<!-- Markdown -->\n\t\t<div class=\"markdown\" id=\"markdown\">\n\t\t\t{{ . }}\n\t\t</div>\n\t\t\n\t</body>\n</html>"),
+		FileModTime: time.Unix(1792328833, 0),
+		Content:     string("<!DOCTYPE html>\n\n<html>\n    <head>\n        <title>ZEUS WIKI</title>\n        <meta charset=\"utf-8\">\n\n\t\t<!-- Fonts -->\n\t\t<style>\n\t\t\t@font-face {\n                font-family: 'ralewaylight';\n                src: url(data:application/font-woff2;charset=utf-8;base64,d09GMgABAAAAAFtwABMAAAAA0swAAFsCAAIAQgAAAAAAAAAAAAAAAAAAAAAAAAAAP0ZGVE0cGiYbvhQcbAZgAINiCDoJhGURCAqCsliCmDoBNgIkA4ckC4NUAAQgBZJEB4VjDIJdP3dlYmYGG4zCF9DbdlJwt6qEIprJFEWwcQAJgo3ORkRuB1S7zS3O/v8/JUENGfpgPSBEo9Z1IryIsKl0shRmdKHUnGYq3dJUDR0etjtNq0ONOAkPHeGsmqkel+5zrYnGKfIyZKIyVbpSsISL8JLNiI1hg2UuPe7XvV8P4YILHnZZDJvHFB7+s2P8tZdT+MNrv00VhhTClI38yOADL4kLu5h/vwiL5WfSBl+NQ2X+FX6rj/m2iUul3PLTvfXZD/4K4xZeaCdHnjzUr5G9/t09s8sqBJY0kPCJjgJiYyJMlIswfAhy6+TVKbwet/xdSJDdBI6K6lXMX/KQtqlwVDX3ZmbC/w41/SoaqfkAzK1GqpgIAkKPERtjjEGPwTJZN6wYISByA5GMiYENioFBGIVgYJzV71lx57WX78F/tJ/vBOhRsgGg+0uwJRZOTtGpCnOEzJCbfT+EEYPBhgBBQxKIELNdfyr33YumvLL5tqyZ93qma+93lIBxAGHY2gCY9bP83/vK72utfCV9U3rZlL4ppW76pu/dJcsiIqYsIiIiIiIiIsMwDDIwDIOIiAz+X135a6ygN/t+T8Vds3NcUNWxjlYHfPf4pB/zBvFGTP/j9ReugoMGD5BgiGyyWWI7yPjqenWPJ9/jfeOzO5ir4HSc4iS4IjOgWNx1huKCjpQJBnQbEsr81jDe9ut2jZQ2DXY6JzmtAP7z3x/gZ6p+YEAFEOKhZiu1OM47P9L4pdXtpvnG8YYxyySIT9uXGt/+Ax4tEM8/0oERgijvftLuVTlyEjnIx1y5iyT4yFSrtHoGkHogC+mcke7e+SDiipTeuyD8IOF0zww4BiDGgNTMgJJAUAYAZQBQ+gNI/Q8MSQCkVuBanpfOOEsAlOUZmnN8K741NrxLgg8VKrwwvP3Ih/mH4dv3usp0u+Z0BEFmkiF0kC2GuebNs7xve98By79kYNX3VFkl0ywcoNYUXzmIACPnU2Oa4zFSlDpzmhn+/X223GQtHXjAOBsba//0uOynPYGugUctdlDLacRI0Y59n29MCxCwrc8vevdC/NqvS2EYGpFGRKQQKeT972/Wb6iPsedRH2qIBrgJxIhQ9u8dtlmXiq3w6lYMVtFCkKJJJhPhrCMC8O7kc47udPuZOQC+3JpZZ221BuwGwIhsdEfk3/WeJy0tuHZe0gWUx42zyY7mWyNeKM71i4FrYMRo+EwK7j6E+il90d1Ygq0iNEBRETdH5mgfI2N/+sYX5v0KLEmV1ZZ62r56rzZ0Q4/0sT7TN/vzNDFHzq75b/pj4Qu7WxQs7l8iLl1bZi5PL/+tenle5hO+8tP9dXumI4MFwXXBwyHP8imhj2FDeFPEFrg4sidqWZEe7RldEwvERsR0eRocFFwZfD8EHlIR8nQldWX3yr9D00MLQ3WhRaENoVvCosPiw8hhJ8Lh4Znh7PDe8KcR1Ah2RGOQ2SMR1yKeRcJ5ax1gCYCHwBLe01RrnWmybLLDWruopxMyIoYSmdgKM70NhVLcSkEBGVH5RorhA1pseoipbRG2B457bpU8p1tBfdY6FEaCOLZEyyPx7Se913WMn6Jk2nKFPU4f7x9B4qe9olaGtsV1b9jKH3WjDbCDBWZ0pT1uX0n8dB/lIWzl4FpRSQArhBaiBx0XMAPsECdSbpinCgNRlzjhrXvAEAuhqH/jOEoiITGug24nG3Uf9JDSCpDS1HVDDdysmh3gRHMF8s7uAIHroaLn2WEYEgGKf/UQv9xGuU/poWorZykiIqEyX9FVOVV8w0k0OZEl4bLbilClaICublpvu+douXTP6hbgPdXHB3Aw5ILZCWqiO48oBOTC+4gKDZ5prcV7QQv+LVzgmRZIu6oBEWpGriQukYB0ks1q8X8kkEWRxJfZikVlzytQldhmanbophnCJnFZENoIpEMLdLvKC36P1NcbCkIgUnJs/+FMgJGC+P824CGxlfYWPKFDnlABTayjpv021YHvPRfyNFuw7/kVn/C4KBCOmT4rfgDkfbixFsXUsFDbgMNu7gZ5rvikK9GcwIxGnZL6UZvl8NGeJz6XRMG0QjdoOmzTHW4uv5bnns+44pFwE/aVzH+xBWIxVaIF2bbA6Ac+/UoyBx0LjOFk0WJqlOAKMDD8opjnpCRok0xooInYYkeVxyFMwsZMWBZnE6+pICl9oBq0D6MdT5sMI/d+IX4iYI6RVX8Tk++eja2GCO6p/ziw9yC1Ez1qp+ICYIQeMw37MNiEMkvB1D5vc0rq2SrmoFvvek5mK36AnHHFEF9JQ0rrAfBtAB8iiKUgJaTiet2sgjUmryMGqykxLbNotuOOfp5nia/RDujggbgLnal6OdiXDvjDmCykRpSEPOFN3ZxtP2/hwlJ+TazA1OrM2l8Cfo9rrYHcFjUZVy0HRFyXbIHDt3MBj96SBaoueqEAFQQYKrDsjWI4QUcqgsE0mx1VlStBNBRxZVPuHUiTuDJvicBsolNZJCS6GRlwh7Xcv81uOKEnnJAXKyFZb+s6TdVZcOHQSFF2WOzDHdmR4fjdF/K+4JUxKhqlpWydTKW7ouD3alVkwM1N4hl8A1gBfOTAQLBplaWeAGbQX4PvVNgDDZilOcE0Hg3ntSSGzFPcLIuQHnfA+plTa58YG6vf02R1bApoXfGF5z29vq5nMGHBxHOYU3Jj8RSqqJ9/R9wn9lClldtr3qMgD/iP/b/qJTgz9zwwD/ZoPtEdHMhDSeRIej49JcVWlwFeaOBy8UBqsUbmi5gUh/DKcDqobrLduVuSC40LGKmkLMab+oIICxdokLxeIaar2MstixfN94sm4L8qRIm2G6QHGJQm7kdp1wkqGAXpbWolABTKSazGUSaRrcC1AsdZwE7l/KRwoA4Kj4EAX/ugQgv/oPQ11gKwv7wmNDQzM3yI5abtHcdbtrrPNzJgpjM73BqAQ0lojmHKsHET9tRbs605X1t4oz4kclXqrUyTrp3OvbIfDumoNOU88MIed6XGyhK6sBMbj+BPh3PlIEkx/UngWZ5G8e0KTXpZmKM5c3gXFGq5Wsa1dD+YClviiG4F9EELi0dMejAqqzAoii+KA5IoxB9OgG56a4gBeNgCwZ1De1AjdKWr0A5GM46DiHeAEdqLrtPA+RvZ7HpmEKa43SKMoccuymYHDYSYG30kP5aeyS6S4AEmvGyREFbyxWKpWrEzFb4hJBxRg8weATAFtmEMYyoRPWdKFKodOjzkRdwYS1LSEqcoyb4jg0L1USzLYgomVFHH2nENwUHRYclpeTNlXZbtrT0MdP/R7vNvt+NCXUOzTPeOQWr6hS+GvdelX/ihl4N9nw2Q4boKTYg0PjYvMWWkNmHObDUx/CnnZXN7N3KngBaGot45eON/6P8Z+N9NOU4Qa7HPdNZrHThA4IIMGVJkIAlOOLL2A3oo0Me8IBcHvaA7Xsy6x1lwmx2/XxWFEk/mKraVKRVBVbxNAzqeTB3fpgZ7Mb4rhEto3OlfeIIGroB7p9k6LGQgwn5gMwVidDewp/nrXuK3Me7k97E9tNfKpjQg6wn/VuuRnoeYByWtubZhy1zyQgawK/Gwa/6XrhwHjpo58vVfHYYD1AET2NHwmlESHHU+iif3SoGXRTEYJSBKAVFSWRqMcfJAMeSHtEhlQlcHSFgE5VNCkYvql/Xu5z/JVFnEPj773Z1ia02ymjOFt17QFEmS/llZVzGvrFWhV7nbbu4w6w5rluj+MrSbiH74ANRQg4JNxci+a4FgFx6NbsekxJORLv6qCXnmgfgE382n1JeY3atvJLex3bU0Z45Lh/4gcSqRuB4wEfFfJOCGoCNHYz25wHvJPi86L1k6sMrW3Qk00xkdCiCSIo3Ts9llwFVdxZMYhL+1Oa6WwkcKBOX32O5YVOF7RGeI0fBdVOV1bqVa9IwLofsHHImm+5+WGVcOj0utGtRQGdKmwjFv8R5OLp5cM66lwDtEjBQxonHxlFigSzy9a2b8nFnOmCECdpMyrXFp0cDZ6oCH9ywGe0j7NnJRa4X7RHygL4ioCGNZksNG8FS6AOMRf+naVtvGauSUnXBaGtq+sHanJjdUFD8c4CdmITAHVTCDpDAruTLE2aFTgGSRNtNUnLlZR7ngiVvmXm6GkK0sGaETdgSW/e1wv8NcS6+BGtyQ6f1YtuATpDhshx2Bf8MELrdXhxNBEnw7tpAS0RJJmRnbkzv2KgRbvXWIEsOfcxSeoXnC8v7WtLUPt+Z2ddLt9NY+2Zr4RO/U3pQdtBXbiC8ax0fcvm1n4tnaqW1jAmb5Xtj67q8f8h3Ilp/MCj26sk0e05POIx8XlUrh1BvI/R1LcdbPOsZZT1mqWDury9vCR6UuZZo5HY6spEWWrdxrHQphv7yVGn79k/aswJK61rUN2DTLUtmQM6a0OwS81Smq3iZxaY4DVvQO/eoUytAuVq319qGewF7D7wW0H/NVkyhCCNqIWeB7wp8s2VNPJXI+Owme4FPxDJ9btkgSFfUqFWXqIbsLRSoSUdZIPzd3rveSBUkPlCQHMQWz2NOjW3fz7s+DnGc9cZN3pu/ykY3cJJJiNo6QyMszcg6NhqeBsUXXbweIVv3rfonyu/rlCr0Ojwe4Hjt1qL+LD6Vu0jZbCbgzkoDuZk8urF6y37K+zdmVztq9R0CplI5ZpklyTWOT/2ACWn7bYl+G2cT2yuaYxDJ3ubTbUwYJQI5PyQKRAfOT8HZ7isNtYtbQcLYTDHWqx1Yw2XzxmpsruUoFyPwz/KiNpWetxjd2gGZpWqa1OVSp0IBbj2DXu5wmTxkDptRPNcs0gQqy2fCgxSHAQDHuiVptcxFAXDJh/Yq5ZlUFEggbVxIJyoEpuVchHdd0JY8S5GGZO1rJBRjB4OZOFaHKUzQGOJ8Hhnx0dInxPLRctjEn67imqfQYirlmYxeqV0wBjXJDYYw+HagRWhYqWm5wrCwPqMyRVhL0yOozWL1oyBYf5yR8+st8QgCzqJYaI0t9s5ckYCYtv9z5ZV8FAQpkYxD20Wv7IiSLFKPLwiHcqEXlSfAS8rU9Ve/Mk/00bmM+CarRDeBhQdsBEUJsCU6noGm18OpIcKx40s+dtXIxApaZwIELGZJHQ5IHGKHr6qs3XAaht1rbStVLXtCPBMcPqWs/DThz3dPypItQnR6S475j7jYNtHCYVmZCW8m9WS9yXWRjSuJefqCwl29pMUhkc5E0nPpEKc8QUNp45hc/7R5IStIdNs7oTx6dL6vDI0GW+SctKRF8+YaTEKoICU/PectyBTb8D8IWwVpPr2NHUY7j552FXAaMw6ZBi5CnAWlYZiGvnhrC4X6JBuREqtVi8xNrx3YUdhw/7yzaZcg4tmm0RWxPQ9LYMuvniVUfgEiV6UMQLR1ksJHiOZhrXsBEFzr5kKGtwbA75DYxBZ6z+jYEyPrSqoQ7fTCGkZ4yue+fC2G/mxK/jTj101yF2reM+/rhdR3N+tvOU+x7l0TXCO1fqD0b1oIHBAcOxmrJ46tnwouHY9C9XiqyGPFAVtBvDDAnwyqMRDMLOi0+Zrx05TG7V5468mJbNAq0JJTGF9u6GNaN/10rxYIIBZUCCKFAPDyWdByF0HiUsLTMrRZkKgmyVAbZXEGO7mChDZ+da6ttePZ8doExhwkd/+xS9z0k8+SzK33nOZWXQY03Qa13F6T7dvjXv3HV4CbNNESvRUNaCBXtPS/zsLxjKbq3PIX8Jn7fF/KA5vGqAQHlQ+vHOg0g9vDvTH5mGAAwliGeiAmIvwF4ewpwhvxBu/M0xBHA6t+wXvnPAJhfxs8A4DUAta/HAOzlBVSnnTwAfuK1arWTt28AHGRcZD9+K7AKr2CbVFw23XS5/HTtio5gd0zHOPbGJObgUNylxnv9/WMBgOWwFzbbES3ITw8YwK6Ytjkb2Pdj6htkxjl2tFf/Prj4/NzzM0474aiDRtTLfHbu8Xuf18FNjThcskH+WMn0wwyln/6vB1QsVpvd4XS5PV6fPxAMhSPR2Aj7UZwM0iwvympYj8aTpp3O5otuuVpvtju1+8PxdL5cb27v7h8eMVgcnkAkkSlUGp3BZLE5hVweXyAUiSVSmVyhVKlND+sam1t71w/t2L5zeNfInr2j+/aPHTh46MjE+OTRqZMnTp0GlFxo2nhl0zP2otv1TdC0AeAEqDwCAABseQ67j7u0VgAAbH1xdV61wX1uxg8uXgqjT6Y1eAlu3L0Hld9FsLatvr2ls6u7o38A0Ldp80bAeX0nAMB1odfTViBj4xGROAUwkdHQMTBubpFZkWJ2TYBmQAENgZWqANGDBIIgXEsfmyS9n6rbeF7YlWyazNfTH9ocHW8fKpzRNUFM4MND0xJkErUtspzrqi1gFuO7IhU1SbWn9QRZRLEWKldnyifKFbGCWVL+e+MHscJVsmqSXk/V9/YWnuqEVXhlA2aWTeyLs2bP2eXyQYMUgjKSdrGv5+7mx/YOEQpidjJBToFzKpAv8lUhmXtVcyRL7xoCTXnjr8YfMcnPUlRVVwzD10Iq01yBJQJKQCVLugXerEufh/chSXZtkESs5BXDJ6DEUSOKE4zi9cp3T6CBlcapDDfQ4FUpvGSdHvt808iOQ4+jt3i86vNSmH83RFUVvNt6DPgCJ6uRoD0sVc1z0i843q24JUdZ26qoxHRp1ObMam5wNKq7Mjy30CdLJ0GBc4CuBA+eJI1CoBjLPn5WN6wRpKuHRQ0MY9kxa1kziht2wiaZp5y1LhkRB6AIi0pKWO1RB0ZCTHIa7RJatEg+Agl4idIFxp/0GxS1yP0AoXR9yX4DGZxL5UiKR6f5saOCLDYnWLRJAINIAQUyG7PSTbXIOoDJAoDTKYDTa4Dv9r2rliM7oUXjgNqhCQ8nVkoJbh5M2amErDKSiEroyrhW2euXJQUh8UZFUptkmYKEQgqRWkYZd0l7shuFtT5c+9OcTUHjJBUyZhdP4SpOpBxCsNe7NOaY0iU/SwpbxgIcFC5sD3qektxynSDwFzthrsYBboNgRCEkSmBboIW/HFLTt6zW1if5XEJfYosHgbKiyLPWNCGeZJxCmrN162g9KXQtokZH5X0PQOk0URBonWm1TVPiOZoXsCghrMWbCYSv+j6z2rEiBCcnPAal1xKzNazWC8GBHwSE+FiY0ifr5UgLqSmAyGXCd7OldMznrCKXSCgVssh3XV4GAfwAQjbZUKZSJjDqIxoPGXStXetAAUQuBd8dL+VDIB53AnKZxB66r7gJIl7yUz2FUR/RHqhhX2fDKtgGghVRy8wIl4pXzrrIlVqwXM7s63KBDKusu85MG4wMxSQnlCabvhK5JQPNEufVQQYDHgJUikpzZksZBJYeyp1YjRISGXZSIrS1AHaC5xcJILzV7AW5nsL33XqE1RDDVtgBriN0qu3Jcg8cWLR3Ly7n34Dr0f+9t+q/kGr+P747nUlOmNKA/C8UDzpbNEURsqebyHZlgiOy8odfshzv2SBYNiFEfK83SPNVh0q2jIQP3sVaxS8p2c1cEHZ0uR7fa2qkZbOQw4W10BaLpYNmnGMmGDGKW/WXERk8n0qYPZovAys+6EyZgFYdCfvEbNGWUyZk0EyeG6ocSt3veS7/p539OpU8d5znd8uCJac8jxNhQtFPLez3ezUzQyiQqDjBQIc9tD2bLFwTOXF0tk/W55yFzMrPg7/rVC8/8gSiWD1LLkDUxWTuICm6fSELfrt/c3/NPaPz6C1BvZAvbHflGb6MfkEpRobmwEM5VQlBQsHOXUc7C9sXb4GFe3ajCwrG3Lmf/OH7QLXOmfA5aIqbmycN3HZvBhfTkdpTv30XdvnnFGpveFTigYUjihyIeU2LXILU8i6yrDL85+it8NJaLpgw4fP/338e8aXvd8OKXSpm8k52M6ssCovFPNqebfGcDIZEGjI3hc2gDwQS+GAC1cLwEiuzGwTYpnVm7myDVQ4aNPaYp+XgVxoa/cEmVOaVZNxAUyP0jdquDWs5nV3SG7Q2DEn5JwBxnTVK8s+KE4r3urS/HIsmtejs/pdD6YPUnAqhdO8eqlJsJCm7co+HyPNqfJ8MTW9Naf4BYSmPzZeeIZutcp6T2ESbd6ekDG6yCqwNB/uoJUKjRpBBK8CkzSHl0RIyLimn46FGh2DgsaDt1g/SsWjLal7l8MpNYX3zxl160xbeu++qHUiQrZHOqBqK6zvYCizFQC7D8yySrAhPihOyN2/kPjul2BKlZgky0RD24ncUNI/hVI1q/yEqUudrxESk/8oJ/jdiQ+nZpmFOx8sOtXldrXcUiyRkz9twnd2qX2oU9Yy/x//HzkFD8FUztMh5MhfKIIexmtQZJ157+FYAFhgJSjbtMHJKAQ1Ml8vqay8hwJBGulbJERqnOaVehsry0/GrP12wpsaIROoAl9nmJiImFbtewPWf+1ofxPH5NMDGvGz2g7fMMsVb8H2jS6OnRPPECqoKL/W+Ei35bm03cIZcbxRV9q0/YmT8uXac5lgUyfrHDPj0Sy1VlhsGlv43HGre9QXy5Mc5xkAJ2Zhua5365JdX4/8LMVnSWbtP1C6XOieYpOYQl3wOp8iFEudXcatXvoum/gMNb9xElQH9FG1JtNG+T7VaG4961Pfs61EkGVUGc3AwCw7WX3/6adSrnzMDaZ4/9tMAtKtee+2JHJJtHa5kvRpN+CxEnZDrn4LmPfnmqwwf+JezXiEqVd8n7Plc//3tt5G9d4h1Lar98cYTYCU50Wx9SDZVPLQTIsEvz/EJCEN4DSt5f7mD/BbpSvhJHszK4ScVklTtoWiBVeOQ32ibPmqXjvdtJE4CjJhiA020CbnQID7oV+I6562/NhJ9avsNrDmT5TI8u29TA78KCx46FgSjXh9Ih+w9vtFZ64yU7gX/ho6ryKD9FhaN39NUj/9/qPWEFSqhphLMFNhlOMamX+arLkCyHv4e8bP1hBJugHITFI+2gzkQnjUlzDVr3qakaWCNLVDRiMVNW2zUUJgFfARWXon0+g98yPWP2+7rUjdgb+NUvz0ytna/GUlZoGnB57bpi33RqvDs19wpNwZvoQ8mqCnnv7X6At/9dWjY8u/XNWL7OlCSRUGJS+Qdrv9jqupt0wRySSeNdIb7buzjJJWQoTDyxYDrWP2Lan4vOU+8/HHb/kGhsB79/TWyxmAg66e++OUXJYMmSNxQwSbIHysVIjgMzg7c8uR1R0C4zownuURVI0m+R/zxRbvpW8+0qnrN6ivtb5fjMt8f+4mx4xl9REUvmWmXrmZLhL+iomTiYHh9p1J9vjF9v5Y2XP/87ceRvWe++Xwzv/1GtU/eOAcZRb4qufcOv7wV3pdc/5t2dV9Yb6/fhnimvMXUTcI6Qe/68YyghhadZ6E2t9F7Sz9LkfDp9UXcZZVNOSid+OTiiSRT3OshZPAudZB14/SpDH3VRtY4ymMfXogCcErsR4LX1KT7EpNM2jc1/m3RFukNwNVkUTZtsMpNolLOd8ySLPLmhaEtSOaPWcqr2VTSrG2blQeryhgCT9WIAlKlHxosos0rDLD5HDON/M8H8XvgtEJSGk+HbqzBSKoGSuB5UCBlG0+bXU1Ama58BLLp8SfDVtNTpsRm09OkKpSYVNYl8yIZrvotCt+b18oleJr2N34Vo293HQt79HfNDndhcm7LJOTh3Rk6J1S2TFlqdYuEmTIUceTM1Pklcnl3mlP8KdyMyD1B4iLUv3/+wku2mC7/C23+M5gkp4nAEqjKoZmkvJuK91Dl4K06XVlgq0aVIK3evx3aIlEl5WAOyqfIamfiVXI9WDSDzZfk4qkk88etLV/layp8qrwn4U7dd0vEEUk1CUSlUdV+qRIv55CFm01XCYxN/tMboiU3sxhWgNflEPw5Vtz8kQq875fkLGNIpVQWltnb7xzrXC6XtJXUaoE8k5O8zZgNUM5QQDbsvUgN8NckAOBYfWlWU4iZSaaZiVP3y0vKqb7/9e8NMb589+ZU7bfv3mPrks6V0gmTaLr/TjqSn0MkyVFkQWaLrMiA1/kwQzDDmAltheZctNGkT9UX0yiep2IM0y1T72oEsK1fW7+vkf2NrBD5LomzApgjACbrGKb/WUDSPg0BtL5v/b8vjwS6NP3Y0P6RO/G8AgUuHj/Pg2vi/Pl+BYoUHuDDj1YrfYIb/HYQduKW8Zbh9FfjV/D89DuvZK/wbMk8zmHev8bxJexY/U6JcefeeIkf2J7G8UAe2HhYYj68l/1StnD3msf3/ydo1zK5XOEPtv4FsrKQg5mV3LwKiQLXXWUayzZ73UB/2+h1m8vnZHBa1//uw6sImc5tEBPrVSpyR6PyFNzucRZ9DrHUaxcYCxFL8luhykDizLwDQ6E6zZkHrR14hFJHGkzXBf2HNu9tKJiJxbJAUSiuwGLBTCgkrIjFMD90Bszs8VtO3nTqpU7UOfgcoGEkBcg6wIpJSmbFdgA5SSnY1xjACQa1uyDcWbeHF0PzlTxaP1NmbibkpcRwTjGAybl7Z6+0b4v+h2UohjOpt1DTqoRFpVIMtJ2lQ3CCqHEFmQ4V35VKI1ZChBS4AYlKt0jllWIcZPIKjJN60ixaXfAsVzbPv+n4DFf9qqxJtjO9Vktp1/GpaKuJVgfClp8x4YXX3+7RchlSiNH8Ouw1hEgVf4eDFrMYDAgnRVDv0GfJQSRUPKd8XvqXack0WDljglxhzGpnkGpWIhSjmKRehd9Vb2utqj5GgzZMDzQEeOunWInZav0MYxZyxXSuv6F+mgGtPtq6eqsKfo9xVToVMJ8fmNQO9+mltQXa4GGtUGEVnl9TO6bzq1COfQK/GFWMX3xrC2vCl9iCRiRHchipqfDW/yuYCmDbmGQMjEIOjK1CTo4FVQqbaK+adx1X2O1auHEX41VTE+Nl4/AJqe35hyhtHqa91DeWI8+aW9fTJJJ+WlFr7tny8txzRS39dPtYt55ubkGew/q8MhnTnJlqAi4/w5zmrehWxB75k5WtRBiALDsyoIPU3thcljPfleWkMXW4pFguCVOUSKI6ErlZzbcIxFYmoSi7IbFQ0o7QiBFlWB5tlVPgytMq42vENEO0NeeJgsjPpOUlqk86W3ac2u12z4wdSZVkjBwcWIOHG/mUijji6qN2iBKf3VgodmAagFTZYIZDnX/+sbAgzkbhm5DvWoN2X3XLsP2VRbtzjRvf14sglRJY2hnyiQnFxBrDGo5cskLD9adAss3X69YUdmaaDHmtgsLcRoO6OYNtOibNFWgi/AVBFWGdS0jA3QlXj6uK7fm5mH1EjWY/zlVNHjVYmQeaasexOmZ7YiVNahwpy33DYLc+PWcu73vjTq8nZiXaZhpXq4ak1sMFHiNBGH6xndEIoZqm4hY0MiYMJtZ4c9MpelHJaXbzesZjK5SH6XXY+vN5vN58m+32Ojweps9m7cfweAMYq9V+6Zt8QVTVPdiKsfIkPjPA0ktOrxYqrbgejAyFZySqs0WsxlrpFhibvRVWK2U1iHIgKgJLmQd86yRy1fBKooLjbrVMZevs49h6B9Ft5DNJq0q4HVACsR1m4+Mr6UxilZPbDiWSW2FWPqEaocQWqNSESiASWQ3SEPK1V0vnapW4GhAWWwWSk3J0LtbW8Dsdp1tLTnJa3YynLbX/Ks2Yy5DDT9G1+toFrdaB4n6MzYru4xWiAdDBvnxrgaZnTDTms7Y8PpXsCl/GvJ1tT+7ra3llKMLPqIx6T9vMoZmDjqDeUaaeqZtbFCZZFjtmD84esn3ynwtUkPeuY2NSjR3nAmLp7alWJaZbLcgsF/JtSErMKAQbVMSWsFc4oVxMbvMePUJIzCliUxFWLsUSk1N+sipFs/C3tsuSyyzz/f01ITcvLbH1DS4su3K9NGJvhI8ywBJvxQiVWbjUZ6DFpMyiKUFRx6715XScsSivMRQn6QAmMUNc2VIDgs7uRXz+TILtQCqt31Bwlf791DAZyEjE88RproDePLfR99j0H46utj9Mp0/aPDb2Gb2Pnf3D1iNwx9PiDazEfYmCwcU+b3FZ8NzSFBDtF254aoY+VD1b/nh1pGBtG6Q4qyiAA0XQ/jv2ih8eE8RPjpVceTbV/j16lV6H+gt6DuAzNkyvRvNNsAa83XpUA6kB9VyuY6OazYaubHa+vABlgtO40KKcnEQLhauF5zBFgUgNnMRKLMpCxptwLDksJ0anfL5W6nXo2n/etln/w5hWPhxEM+or8+vD0W13HX3j1JeldeL3G4/cNa/GNke78DYLiCKEgDg1tZv4ByA895f68du6hWkH8j30ar+Xc98sf557RLlw/Mx/G7ZUy1d3PVb27aO9a2phvBvY/VDesTob1W5Kwcx7JXklUymo231H5s8c7FXJ1Qm0+mGXzu/w1V8re+wHYI1cbSmQwEXQOn9xbay7busdZ7yuqhO/HTp011i97lO1oz6MyhuZlb249THQw6Pvn9rxm4YFpVQp0/qB9WadMh8bOFawo+83qW9nTCIumgvshIhWtfIpO4M+D74wvRgJX6Y8O1608VgyYumSDK/SKmlNws7UCtLPifBzGSX1epKtU0DXdtzrZR+03NSH2Dv3sML4QLSmWAUXAF97692vzp/48IGhvt9j8pu8+ldlz+BfrsM31fOa+ox+U5f/MDfkz9rxa5oFptY13LeDh28XfbP2tm39JOf1qjX8txuO3DavWXvX1s8UPOGHC4FsI8Phyt/OEeXuLK4doMn+jVv3hVyCZGkhLiIZ8Y1QU5aN/YD19Ce90e6vMW6fKXI28wnX6eUV/kkZ9hOIufuuW6sHOFLhD2v8MtxPqpBtYlhdBStkpw6IK2rp1EXm9Col6EHcuq/EEhTHO4cnZqvLkLifpAy6rY+0VOnnGDYXbkLO1Dopo+nOkLKQw5nVhsgGaGjrG40n00s7/scV8zM47UOe985tbyHC5cb87TBbUEXISXMEkW5I62pWnoGX8370nGQrehSBsMvmYIW5o7efmJ5UpbwuOTxt6embsTnHYa/L79fdk+/XefldxZ7972s6ruf6ZHn8PmYBTiYBB5Ls8QNjipjtQg7g72nJs/+VDQ+X1T7Ddv3UWeC9NGf8mzFVwSf0J3U8YD9Y9U+/L7Qq53nitYpPro+uKv9Q4j3Kx5xRs3HRXnGlsa9a4kxgOFKA7v/7399VHVbE+xvwO9hhDsA3/4bYCvDpsIoyvLQvZCXLg+Q+D4b4RsxDWb7cslKLEa1MwyjUDw0P/ROCFEGhvoQH5bUHCNFxhHQi2lnB3Q7TCA5GEkLNg/sO9bMPoIWoRML11qlzz56dX5CWJOtZviBuBiyiKF/q11FdCKUwvVJLzUiUU3Da+JxVcyI4NRF8elP2rm2z9Y1bAB4YBmi2ay1NJZPKmiHsBaOdcaG5/4S46JuH9Xn6ewu0EprRRquFZlEW7L+CdggkyGGza4Ahwa+CaRkM/QiXH1LCuNTeO8139jypzy7VRsOEB7VmkRvvqC4YVqlwex01brIgyxIlzcARp7RhmVyVk9AcRy05K2wf5Myl8f3tlLl692FxEbEm0YgViG8PGAE+5E788PjPT66MXY0xP6pdZT8pbR6kXTQaZZidZbWDZEnWN1Ari6aXKE2FxiJaNTRHNsAqduUM65vyKNaF1v7vJ1vJaWFbP/OizS/Se7ePlDzkcPZieFmWSGk6iUYXBzcLVWWEViw6suaMbrKPnLTJ7ugbdX9jOBzYzWyvOyKee9uF7Y87kE4QE0S5yA1LNjl2bdmRwksRGUQCob+VNFc/OCm2tl3vJhvfyVFWzs6W4sksnawPaC8QSn3xsHzLI+3qwm6oRZhfL/6A0T+oLbcflTYOUs7r9S1ZO74WYeQQ9IXKdrbRRKmEoFTrOcb6rGELdX467cfUvO1dJ6pFc6vjD1h1hsJLjbN12Fq4RcyuXE7oI2wBG8C++rBThlMwn8hMHJQTAE4gR6wH0ZINBg0eYeOzi6BZWHtMcIU2Xs0tb3mBjdpVpw9erXBszf7nbSF45zhArEkb5o3EEZDBH0YDOMl6/ziB0Zi8Baz4inPd6axvGt6y1LUxysfzUP+X5xis42rN2Tp8OVwlJFYGUnoMhd9Wn67DlsM0QvKt0afJ03KRWldBbI4nj3X+8jr1zhtlJ/c0wO1i/voIfSS+fwlrrt19SmiznxZ2uJlzJXbmpTb3WaGdarwBsSFzRrMCM+yodhMFgvVEey1uWKXAjdhr1pMFPDfZWYvZix/C2f6f7B6ge1yr7cflG0ybM9vxl1zucaG15W43xrghqJWj1zMqIQWyAYbZlbNLIMMMl9RuoomyamE21tFFXNb7HmLNNbuPi4vXPGzI0z5QAnyoadslPf5+KU9TkjjVd9esth+SugYJWwWlUHtZMb4UqiGzdFOyakpRMacWni/byHK4ULs7/BCn8s10TLUbFwR6LFZ1e93bDmnfmILpRBD4jKMUe9tomTkVguXDakHcQHFAQyI/B8IpYOdVWOjt8ew1Z7kZaDd9NE+FNAUz0qHc3JT32MSiSIq/NmQT1IjOlBC6no2eWP2c/bMX9x7PbTVY/+b97YfkIDMKZJCBSE6pfoK8NCmJFuwCElJiVd0zBqOoZRHeBbNyuRUhxG2GjQK1zsPLFiRpwDtIrp87OwRiz36wNaR0NmR8Vhd2Hlwcc3td3cq1x54tnByV9Cp7lJLx0VzmSu4xY/hdsOBHxGeEKNnjN7CTf3RRuBYoGQoa8i/lTob62S13bvaP9wwEW0K1F0LGL+hB66oiKVoY2S9wu3w52WtlqQ1Ezl4HMcciqSm4rjdswdxor67PbFjZ8cLAuZ84C5Fg/MLtYkdW4HLKokXLqQEMh2j7sr9iC+Ni0KoDCwBRMqQHS9XMT7Pl/vSFi/wpgVkl4h3zdWCkXyFgMLhQG42OK4z9sGyHofV3XxuMwMjMSmbEM6DUeGgC84unN8E7E47jpOen8kEMGBEUG+v9/W+enOMeU2jbt+yTO/2fgHT1UhNuunrgoLp43k/5P23yulCfaVVHI6TbxWqvviRjmDSNQDxhCAvByJUVGFdUzsv818Z5B7S16wknTWpe5qC2uonFXNAm9GnmKl3wIatlO+9aeyofwg+XppGza5TyukyK9WQpUvMVLtyqF2VIEwnEeEVWSrgQyk8X2kw8wsRiRlJGsDrDwLfm4RhLScmUhYTF+EVxYPHy8ZUcG9yWzFrCWMpeDE8pWfopmkqj6avzTCHytOSlnNf/NZo1CT/64KhX675+e4L5AmPMiKXH7jLtiqHH5KC0r5hTZYcXkGQkn9kvh18mEb46HZNQYxO3wsh9w0YEjQMK/EoLZS+MyyiK5uQjpLlYCI+Vbg7BeFA8uqMdFGKpABlnuK7N2yyiNZQJ2yE4YlmEOo2FBuDyGZTNRHmGGcjPz1AgC9JYHIh+mQfXqzNxOv7DUM7OitFYnhes2aAkI/gcuCmgeKF2CeMjSJhSkM0XphcFppZwgM0R3P9iOz9qQjJxKlhWiuX+Fvvii+f97dt2BNjnzjsWb9lm0bmL+R+FHdv87Rcv2hfnDvUY/zo29Y+xr+cf9bEJ85fBPuM/x479re/v+0d/bMr418duXsaovXmUX153xQq1qKNVhAIqkLUwSlRP4FshzXgZut2kqkEgwfwgFuRrkDr8SD5TQ3DB6KLd6Ppq1sE6X9we1WhlM9Lh/xItsxVrrDVcVqe8uW1rf2tOXKyoZKkv+OXyQARmA1aSJgRickEcRTpWy6MmS4MpieejSl6ZFmfoapj18p9RvxvkW+O3xsqFKtSvBvlQ/NChCDBaoTBdlhVPyQ+bFcGt/bI7dvgLtwQ9Pm9rn57568TfG3v0f6D/bURg7M1bXG0cWYfUIYicRFNGWpQ8h8ZB+LyzKJ+pl2j2Z8jktSJZcaUgebfvPRWdRAfPM5KxUikGL4wlbhQeK5GjMQl7Ty4NDCw4udc3bkvrNkfrGLW3U9QgWhsv8k9QJQSIAg4PbWLGKDO6DM1X5j18oY3mwNKxCKxZZc/gBqOBcz8ChNdYMCeITi4GkdAx3NSCXIEwWws6QAnhF0CxS3DhfxN53q8kH8ObCVBlaJapi0+kD/PbUjApa7tJ5DjyFW5QiqCm4DwIspWkwtqBnFwYJ6XjKEi51OFgpqZx+DmlEdi6ffoOXwK45sCqfdcjRg8DPDGIWpbi18BsCg6CWeOj/OeD6x/CPop1DlJtAs60ThstlJi9Zy+/gf0XusLrAvgqaRlpkbXUelzBtdfQDoqdBkpfjXJXqswr6pnHh9sgykjUiLb1nxuSG0BY5sVvlSEZq+hgR3KSPPCS85oJ7wx+7U+ko5BYNVaG1K3AeutjSDH46Ga8J/XDZnI7eaRv04ztDs3ndQ49q1FpaMhlsxtzlYbMRjo1vUGtb7xddkOuRp++TkdEJmqJDCUkO0sJpTAS9UjbaaDQPsisDBWUSIOYsI3d8qHYoXj5rwYUX3W1AT5vavMRdm8it7WpnZ3P+5I3Db+0ZTbtbyXnGfbTgjTfcmL5I/KjBsK6oWe++vSP9pr4guI689rDn/turh+uhp1TR7OgPYv0hZzgl3MvPIXXGMtiakd7s6YgW8CnKkuChU/8gzjt82WyTZ1Mj+/xFz6R7kcc369/9BBziPZ/ZEkr+K91NVyQWSHk23KpMXsguCCzu46C9qBzm/YYgqRs814bl2wB/muqhqkp0OtijR1bB8TQ2+E2JbonIuDenGSO4Rab+xLN65mgz7+p5MqaAxX7ri/w0TU7TL84fPuAq1aM+/CEsMyfKsbBssB/d+5OAH/SKLyJfu9MFBgqGR8yVBQV245Y492YRz/qUH/y6WAmYcT1WndnsUhIJJuUlcMi5SH5CLk59iswBhYb5xcb+9HhjYwbu6wg5zxSPxJJFNPfxaY/M+Z9/CxUT9d3947T0Kau1b8jQEZZZTd/O4zllasZ6g1U3PA7Id7Z6fND456bCp8GR91NOCoCtQH1mRvTuAfQcmhe0sghMMu9Hkqs2AbIFa3eTaKy2gP4aC9ByyQ9Ged3cY8qGPLKbLpvkwAYOXR+mH5heKagoXnujldPAfaa2jYhnfAc4p3h6S70NUaTDgrW7dFdFnH+q0NGzuquJ8reZbB/96OXQrUKrEYO3l5UsbmoPzkWlg3+2Hn0UxXavAl+D/YQQjqqIwF7kXcL+e5Knl+GXmSSP8NRayWraTbdZN4KrSbnpjuoZ3LZK2bNucgbonBD+goZjpR0oqgTYnV6JF9gPMpBLwxOhpfHqXfU4CNYSncom4zAqFeZtpZZ6pO2hPSd/1LZq/U4nElDFKjL9ENl5nqIaWeH8f9yKQTvh4H2w3mrc/03YSD9cEES3iDaiv6RSkUghRsWt6y47w7kV90YvOphmBIyLzjdVHBT8o2hUJ0o2xrLoA/Xo+yb0NV0CMxMuARK7SnYxhMhcc36R935qriFUGotJNTL/8eVi5ulbQmT5bpNlu5cEbsZrufnVBKyQIY8Lif341bg/vubrkKfes1T3PX6ed8eZnqtRtoML4xTxSJ1IAouUZqVkyyjok3AHHIxgiaMryYvqpSWDC98nVre7NxZfYHay9PW0uqjbP91kjs/oO38MO1iI/hRNubyO+XVt2r+xRaP1FFKCHnkfTNVriF32NNCiMVW9QV31tTL01r865H+9RGDsZ+dxzdJUe0s4+5cCbk36JaPGMgJcm6v+4Z5AC1Gxa+/m0ebTpZDLDj9/Zq1JWf57W7WXIlvZMAhHyllqMzphp8gb6tJsFTb83pCMcwsrkW2rCyg1sYM1AgC6o8gqy6KENSLbNtKZ6ArsZNdD5TnIYR3LzB95IStDmd/vkCwHm134jZXCWE4lL8P7O99XVggW2bGuiAEj3RJ1/5/Ff4m47zD0no3ZaZRsuYuuYNqzu4UaXAms7PBuW02TQfcjeJtE2TseUO7GV/tyQd3O3jx54MNYPj8cvCz4bXi/FKEx2QqC6NPLrl10j0GhGTbczLqjN/ym585aPFjHWuiuXGSZe5715VZ6t9Y7d/T/L0b+7p9W6uGD+85b6G9P1Gx7KBNLq4+TTgTelaxmnuta/3ZwuKiU/TWJs64SctDdzssZenvuw1YZxl6k4CNbnUaB1ACgRvjtKIH9HDeIY+BiMHP9i27v4D75ovslvm5S7XF5CIX+mPyT9F5ujdNE+eryHT7dS+2Q4xP5G02iuQi95HBd0c2f5TFolzRFeMuyS6xa0Qykh7s1emNkPHrLLJeyih+3uD7+89sSNi1l0lQShlFL421Vs4RQsHzT8+tsXmv51x8+dkWg4zw9WriThEKDGCku+zeJ7Z+0l9zg35H5dykybxF9wg1aPKx0H3SKEKAqAuthzQtj4z6+sMMHRoH1JjYGPlJ19ry41rXVtxFjYeNc6l5wympBWmJVmbTKHsNy7NI+mKGC5YtG6TbXHnDIkn2sNnVp1B5u5ZAlTSaehcjuqH5K6KKrYbOml7LMIHtddo2APmbDwmAfzozG4FC54Zex2G0dvDvmvHrugExVN3l9XCzcGl5wW/MGb6N3Ycol8sagjnpo3+c3l9ymtu2kf6wadhHTN1UVrYJJ8ixtXcbT6CFC4NbxapSXFsciT2AcCm19ZGsSI6yoVLajWBbT/Jbe1lzJqsKvdNW7cYLRFWiRTVmt0qF2WOtWV8x2wySHbXo0dzuTHyP5tidA23kTrTN2XjtsHJoPm+VjHA+1+dCwzg20dcnYv5F6QOz/Wh8A/EwRJVpwTQCbINmRfAq1csAiuB5d9/9JHkTzFhWmEZDJM5bsW5n5P54XQ83pin9VNPz7FM96Rs1tzZ1rJ82XeNMV8k6PhN7/fO/oLh/P4/FUg0GctzH5zdgmjryZo0HefOulgAh1sZKg1YYQ8iMIFxgp8VsjCTULa0oen7gwPfGfg6/K/rbWbB6RtjdJz69RPs8knA5IVXmjiaYQvTGg72uozSjsN9vVdAzG2/FNw/l+7aW3Q4tKZo3oGPeYc7+zfzb9hded6bzZKcRT78xuQob3Y7cKAWAi5bwDbLvLWJiP7+imaHKUgVSQhcfTSddqY5jEjW5BdJwZ9aOhfuNol0ig8ickxsR/XDY6lGSwtpkPhNiKfglKZubB2YFeAbz08zBWJnaSvVNCGHZxPFovDw3VxlagXR/5n0uzNZ+L6iooE6Go5TAZjqAv+1toWuyFIHk0ORLGaQ9VQl0sjY33+Nuv/0GWa3WpueqB1NWlNgg6qXgAkEeQhxkSd5c0mcpYUPaOfpq5JtTAn/pbCFqCmsCassM8A7ie85bcClKputC8MdL4gvw0uD3vO9VM/lnNQ9mLC1LhLAWvX0kiFxWqDHt+C1+IK/302D4Poic/D2I1f8jZ86cpLmPQSeCZPP/b1RjHA1QPhQ1o7R2Rp1TBEwgadbAJJkFYVzp6jphbVvyBMsCpYUZ5aApcSAW+yPENDC9kEtiujhhJ0L3y9OiB3FIBDXZZtkN8Om9XLUHa+t7i1NBxgfD0qolh4bk/seYfmMTI/VoXCZ5YeclkkzGfiBGBOgNS4pE9TGvtgT1hhakA7rnulnQSIC8bioujuOv98//+wnMnz583LX+Dxd44mtvvNfzSWMG8xUkKlvcFxAGef8I9E6re2xWh7MuE5Bsts87sBFPFxlQbEqZbKDmKKZI3/ngfFKpodHpTwD5s1CNLqTL43gQwEST9tRRWGBDMZEdxMd7RigatfWiS4GIQl2+xHWnc+dXhE9pw0hLn3RF9eGczHs2xrZnEu5it4QXZ+Pdc5m3ozcgPea/lEfXWfXL5VMn9xnYm0V3D/EV5XH29Nd/r/mxd6zz6Df2+NdpX+PV6nb3sR8VJfHkOJD/zSe7zoqQSyTkj1dJH9HivNxtTFfbOND9vuFb27L72eqQMZL79allmMgBnZLwOAxN2yXcAJ4MFjb2FgswzB3E130jaTizZa36SSGnFO5b/LDwRH67bilqKJzzs4Gbs8QYzfTVGEdcjd8AR0vfMzyjj+w5259zmTAMIDhzBKEWk1gK/fgCcoSPp7+LcAb6KfnmcYLUN/B8ucbTHVvO1kxIMEa1Hs1+CTcZ3qAq43wUKLyoYIF4wdXi6n15RQEEKEvlhnw6tXr5oM83Sp2uXy6ToYVZ2+ea7O29CPEYwPq8W6D723svPyMSEV6wn/OhA0WYBhriXrdz7xkWtta/B6sLNsJkuzUDhMRmSNubEZg6V0hvGmKvVepwiWYhI1LSYJDtqdgMIU8bjQyIAvAhi1nihMGumFecziXzpvRI1u00pGD6NS4bTvBygbVZWriMh6FAUfASs2pW5xwxm5s21MxeKCKtXwBwDEFAILiagKuZfbpX1R8qKcF+ztWQisZRQMln6AVSWcYC9ZrUoLYZzcBREeWYgzoVu17As2JJ1gs2IbkZgWSMEq4SaZi66tMjh8IYtHqhFOAFrAJX6W/JgEtahQxOXSmaCig0wUZdyIdM0YxRwBrYOd28g7pFVGd9rqSd32Sg1bBGoYBAZQGN5sXADsjN5mHE2f29tw5jOAdrxTdC2SMs5HAALiEBeY02oUNEi93CDIBNIehsAoNQixUBUwYbJDAMDZAAtJysB6RkLKiOQhsyvZQUTJGQrsdSTg1thj/YStlsWAE7OdZkrSV2/f//8JV6fPnUg/tJUnHzye4JfNXt8koND0xfjgePhFucMNFPqfW35uYXFNvxf/3F2o2o3vC4fLcmG6UkbRlyBTzsCyhXMjwTDFpaChZebU1yGeacC/vgcZvtEmPvaocHFbri1VFWY3Y4WRfThgFAU3J/TnBuo/8Wyr7P0pLUAtsDDhNmZWq3KxhTdD9U++in291n3FPGfjVaPZoY3PZwMXfg6K27N9wy8TCMvH7eIYDRb1y+fqSedu3OX98seVr12ZyVwhOoPPP5EAx0/OH2XEODE8IXVaKzFscqtmMYkLxFeMw64iVW0FSzsDWpgSt73Wu+aNuTnG2URUiI+eVlohL7hzU7TFNBmfBLQ5EDbDCBIzxHkmzxqYUDM6bocVt2TqilEkdTNbOK+Pyn6BO/lvn3FDCzGa34QimPvrUcrbad0OTcICDm3oJLbuDdVhbLPjfk157t/zjJESc/Huj+4chPsH1S2zeO0d9J/vJvfF/Nlfbf443/hfwzWkkMiSm6viRfYEOp3c6xtutQlSzno4fIoHCfGMRgOHEkCGREFGsi8wlkavnjLJ/YOuwQs8f+ED/zYK8Z5n3I2BEY/Jf3IdD0iR/7ZuLMiJ2xQFD2IfOycZPZ6PNhD5KPPPLHHMA4yBhr8o54jANjPSRiWAwp2rwxXeJv8DmbbE8uZ1dfffq1el84Br9+T4V7wE1J97N75/qvf3Xm3vmrT78Pf0tUwCExDS5WwyeOGW3hIlwYzyUWDaIMfwtcSaMIGT7UYr7QtnkLwcqdxEpJN7Ub0tjX2SXheyc/ayKOj/PjfWnB6JLLSVOUkyzOKStRQvg0iwAxIwaMVEWNPcpovma5WtKe97lRVi7MJYrTJkuJk7NJkaVheX0shjUZ5OyLkaphQITlAWgNdb138kiV2S2m9TTLjLR1YCs1jU+PtixfQnfck9cvqDU8t4xdHl62faAiuSyLzNtPQNUqeZFTGlrtlob31t+dCy6rps8h1L4UktrJjFB5DJP2Np80pz3ADkPipMg0NcaDpmg3Evk9QngpRz8XCYydDRkQpiaQkMLLVF2tRMd1YLKMQsGVhNBnQILDOCZhwkDNOJXBgVb6JoUMsmYp+cIm7Q51jhdmTYixqIXGAVp4GHc0A2PnVkq+MRY5AUthGLVPpmRa9hQEgBZes1mISUOMo4e+R5nXLAYJH3PFYrhJuIsFSbaUQO3a+rsLdNVfMKo9LIcGW6xasYphU9lG2YqwvdrHJEB2PDmo2R/5p7CjGRbMnF2jSItEmCC0wZM5VKP3RI0Az7JTcr71eBmvzDOVzyKWlDsnfDIOWyAXPHAholso+bry+qyyEXhSKva8iXSXhIZaS8ZfdFIUM8VTdQ0+i4KK5rT16KRvV9kDNyjc9vD7DO2NJvehcVW4pHda5YGxbIZeKcsHYXXkMWM5fK8dBCN7nCIpCYF8ryKlzynbP0zzYru7f3DxdxbdMyq/uQIUo33pCvuVCxI+ehqIcSrPvgE8LK8cb/oxbQG4TNLt4/AUBGMg/YxVxQmsrjizkKZFOiYVi0PYURnyfpQJsnyOrSWWTTppeBn8HMaMvivIWJhEht1+pT4S4LE+6Te3V8fvMBeRUCkgWUvRnq+oJxWpzNp2sWaSGMO5rTsI8noRiEqgPCdIKWK8jHIliUVsCLsRwbagiXKzZMDIjaLPOtKnuRnRc5DFQNnHXMTEp2TSQ0hwHshhorCYsWRiUsO7R2GvYTPwIpXHpyt571Rq74RUFjmXVsLn8LhkJ9frU++6GRqtagLJogUVSbUk5/F3LRVD17v5iUSSczZt+ys7diG6MwyGjkSdoQtuWRZOYgvCzHV3baP+Xk6/lYN+3RuyraPpbx0E0IQMofy81amHs29rIijmtnQkAHA7n9W6iHak1wwD2nrDQjNz6IrFvpTkaNRUseZOcagyDFUQ7QpBkYmiyKThSsY+0frbZmtWwuqkMmxAlU9dOXNu53vPLLRaUKboXfXDoFUT1Y8euOYxMBpbqqqQ8VNyVVfw8UCPNk+YPOarcnOnGavdUitztWKX2G5WUFPYTKsCl7Tkiis9u0afjRalUIPERuEoAPHfFhS4hIXiAkGkhrMDPHDzOTT350TYeUyPYleLm1AZMMBKRMOJLnLKuC3x7HwScNA449ziiBunNeNKC7oeBkVN7kJFDeuzui9yNlPR4vmOZR0bLl68aU8XfQ6wc5/ycWSkud9lI+iP6hF99YKlY2JtcNltXXauNfUW8EqOi89tdoeDc1NijADVJVTAjICqiAsJlKrsCto80Zro4pAhEo+xwrgj/Y5Bo7hJLFxBvclgQNLZmE7xp0RwENH5zqOp82ahTYBQJ9MI3cGNMfBlZek40p7M9JgkBoMin2ayNG0IwLTsMCXyZLf7oB4TY1+8B7rSW5uQiIe6STl9M2AC1O0rIIU1ORnaJnpMNGcohirqLBdNF3aLMivzb320E8+taOdOIM91x2Wwxb0wTyS7/dLngkI50T4BAzidx+N/WJugeUlOImaFoWJCK1MYgIuHVhuaFXhR+XZf7z/Lzs/gcd6TKYyDk6NjtTkwrRwPXg+ablrm9A0cDo6OKz2j6C7DwPpl1iIYj2WzOfOfhfVMnCB+KvrnxVMj9L4SUl/ic5tHNmFGak9astMklwBAqeW7IM3gEMkwNc3aDgFOODfPckR1xqktF8vaRB0j4xL5UCyi+wu4gQL8NQqYp0+mRsrJ2suSNgSK7Ws0oQ5C4LAucltNI1YVh+OolNRomKCRNU2QYvh+KIiQFn0C4AlYFs8Z44LBBCa1dYlTCxkJUxBrjPUG5e0VLc6pAzoPpwnD5TwsACc4S18NrGuRD2MgdLUiaWKYYABFyVhFJL6BdQYgPK65hCNkgu2ciRICsaJdonDAsUTIdx29mRDlEgkyIkxprQBNoEBKdS6iVpiqLhi6RDLRR0znrxIEKt8OPi8QG8L5/CYVrsQ2nxm0zaOOQEsx8FU1vrH+oCElpV1WnjAN0Noo6kToybkaBGRiDDodhpbCOyOkfjz06Vj5V9kEs4MFhc34Wj1++VQcI+ux/iXFmSWFnNlSPyfXx8+3frt0l6ZTZxmCnAD428e5yOpdpqDO3zDAOZGlZJ4gnNsnJfK+4EobWr/0wAeblm8bocf8/4+W5ZdLlpdP/eJdmJvV0nvcek8Fr9VbPPKFr93Fxezpsrie0qvZ08nv85scHl7wiXcnZDgWq9eLvn+bcvGXXEqaGBzlrYWnpfACovfg28shpw413dpiljolVw/6zH1iBojfBATrl5eiz0MOkpuhLkka+Oc2NzyzSpUs5UrIKq8OV4BwqDoS6jxG1MQnfjbJKOwcwy7LAVMod8HVIRGSiROza+5hexiql4yGIJeUBl2/wU+UqF7AL9s9TL6q8Mrjy4EBuAAEYuCV/GWSziwO7ieHmiCRiJ0P6zi3G/IgG5ND8mnTJ37nTL8tCsgL5uRlA9mmkGmvCnUrHzl5+wSbCrNP8QX+NZ1uz+n9X9CR+oByl7RQ8AO7DouED3SJV4Fna/EFbpkqJkuHV2vH7Lpmwe4Nkj/5p8+32f7Z+WfffHT/oxCBz39SwQ5BWanAAIiN0kIkllSPkyNltliWSjPTbPVpTPvwrK7l8LvZ3WwGOkGsBdJWCKDN8wZGboYkklRV+ebAQ/iYN9k9v/r2QwXDx2dX+pwl/Y2jq3T9zes6SKX8Jpdy9qu0ChvUlAgB5tjA7XJHOLyJfbtVt9yYYVnl0kE1dRtxGFE3vViut7svwXBYLmAD1e/S+kcmJuP26jiErLpFBSGp/6jKDrAKs8spFKRJmlSqLLkQRMUt5wdHzpIeiU/47iiPA7XpC9fXaqo4L2iKL2bkSlwAa23VCtevMpmoZI37hG7sXexOnr9oaY595pCS17t91j7xDI3ec9Ol+nVQMqkGeXRhWK/DSVEwuTWZOFUaO1gNJwAVOLSZYCKU3kCGsyHMbKuBCacUo4K9i4gifoZRQJWxUNChu2p3osgyKDFVvDeuKjlwCru64JyZkHp6sRDMRvbDShQ/gak1m0ZYAm61jF5mS8Nayi9xXgP8YaQaY+vkZCVhdh2NGOlQHBzY5+ijuYTWrBkpBwN5ah2wGZ6CEYcni1B72t3f+8aiTDJs7YGCcFRkKR4kPGh43DCCIBFDdiIAP5dyVWxgnOiHSDbwyOqgBapILD9UU1Na4s3kYdrYyiyt4FFTOr6aGaLdfaUQ1mZZYnO2dqOMoK1hwEJYtJ9hQGENBYQy0t4LckLOM+28kos+KcyXJEAapaoBDo8lowEeoRXjvpbg26NE2CBKdJRh89AfGABmNVklk3AeXC4MwrCDLI147M1pgUqJY3cnMzA1SqpEsz3djlkXKkLKqYvcWZdqPyo0TBPF0IEY8XyQ0Z4iOpLIKEaL8PDh6s2bsOP5J4JGhSRG9yWh7noTvt+NA/V5PcqYWlUm5bA5daLafxfvbTox3Dvi/WXa+FDcvnOUGAKjRBXUqmIz3i8jhYyAJCnNxSKTpOYha/cp88VJbyWpK5MCZ6vD6X10dTcH5AHiPG+bkVuExWrbWjd8Rz66Emrj6XkHKrjR2wt7l69rHPEiiS8Q0PK945Phtcwz85A8xNjq8nC0AX03x+siglv0BNfA0w33/O5RXfZsyLeTzK6OYD/7zstGg3ed6AMXz9Tul+kLxf3Tn+An7514eHLET1/N9VXzwt7qVy+Xtg+nHvCUcw9u70onDE8N1uh3FqckXl3Ai0ELGiE2cFDD0yYRapzk0FrTEvFNgCl6peqOyoKrfk1BU67tnozDZLq7pR1Lsb/KyktQ3TnT+zWJj5wkGa9EtaSl5A7vPtIZh8LfBJwj7Fcpsjcips3yY8LMm3C1QQsEC9vk/N6fbMw6Ww/FCA11Gwv1Fueo6gR0FIiKyhIONUzjxBTbwObCXD2jkkupXMg/U5Med7J3NhJgSxpQKP22Wt+J2icmfQ7Kau4EjbZkwxdQ9HE5xnQ5bU6yewARl91l+8eqrVqlxzBSKo5zJyRJG+lEgAIqRJwRqRrPqK5BjbCO6rDtkw8Xf1P3rGiVj6++GRHAoseGjp/zWjHe3Ts8v3r/4R6s/ar6n3mBmK3fF14nNIFRwqmrw5v31+nq3vaB7K+/v8GfSxJxolYJlzoP/DRhPb6uWiWU1zmCH4sOCUFahFQcAWgSzYW4EgnoTfITIxoiLhnCUlUI3zInJNooz3mNosdrsQTlALDvOccowQhULhGIkhpkgDJPYTBzpEZ0BQzQ+Ewwg9WVHPmXjU0mp86HfC70TFd33apLMGy5oWCF7NGut3+KaVKD2M+gbECpx1OggaQFeIp3HqYYoeomoA5xPZUYXhHJJsQ3Vac4rdgkNTFAubvOcXT2XFU7Be+OG+xoIQyqw9pYCf/uduHvoGpn+UKpc5v60e2q63p/T9u4Q7eS3lEW48dHDs/YrTvtXtzg1Zte6t6GI7ev7JtR3nhPGrsk02vOcUDvrNNbQe1pnH+YvNhuH79kHpPdi1apilhlWmQRda8GOkL8PZHT7tec+/5HXh1rVtGiDV4sUS23ENjiPFhsZmn+paFHI9mbALyU3KpU8TJePAs5jLzV2WDTHA2YJTwC1UqAvn9OCC0Odo9SSEkxWLYW3vF6c+JHpIdLy4/WRzGSlzdvBphiuHx/NYW8S5Xi3eb+4ljoce3Bv32+123VZdij9YkkSB4dlNLCumEQKDEzg5iQfJKrY00VMKpxvqWoHpkh4e+bLb+djJ7OcqYcBi8MA7YoeBVKMHPLcEjIEBLaWT0YBpxGSgWqHE9moGwLh5086kewPtmjq92Fu+FKB5cneGsTW2+zyghLG01a52ThwRa2dMRdg3H9MOr9DzakXLbKYViuVrJpWNxNAj/nZxJmWpUC/a53zM0D9Obt8g3Lc+gzN/G5sy+/Dvil5w/dPR7Qdwfqdtwn9FnSTy0r+pzmDXInm3013yXnFwmFs9V+3srCz9Fr3zt4d09bk4quvyw6Ayr5+m9784Zld+cvP/zipYd7/pz5mrz70URPWYkk3CK+lC/pjjp/vA/XGyM5hVvdh+NMD3nR8OkO/oLiJlAnbqEhe+/lfIoSPPKE7Q2DDZ/8Nw2/FoH6kkR5CXrvu5/pPv77/lb4MtgdlML7qvWCss/efeEc9NNbkt7flSW6tmQbfanQUPirFHHUOm/5S2rDn9fZJ3IoU6Z+OfLEPb9LRfrBkt9nephhdHj4KvcGXJy//OyHKDt8BvNPlyL6qaA/Fwsq973ySDB8gWLghqHhSI7MsW2pBif/WMhz7ILqjnQioj0/pflq7kE00bPhfw6QPL14+45w2yUs4bSrxbfqmA0rr01TtZkLP5NQ+Qq6Lt3vXp8uVchs/D3AqwO16smhp8cL3mYGKSd4Vb4MO+r8GIKJbYE1xo3BpiSnDoacdq+b7brPTJOJUASWgsMA5F0aOPHmsEAZL3i+2r0h8DpNGi8CzyMR0enq5hmzGhDQGCZoEsJP904wURL1N9MN3ews1KBxOVzs/GZtNiBvh00+lMxgbMvO25z0uSFYlLKrR30kZss2VO4vJmwxWIKDyprNODqstWY7eBKvltI0SNYn1GyN5TxGNUTU0U7dgnoygCVikG9bMrhBFzy5zVS6dd4nSdS7sRzCAbYZ7WZR6uHwsxCf0gP9BSpl2ObebOaBHfDpfk27XXVdd3vak0UFUWj4hHRFwDzXvQ2pshDUU/oSm7ztMf9c1oK5wuB6RlmVpNjYFa0tKjS4kIkzyKl2oEhQwNTPTaNKBJ8e6S49az63Ns2v4Ua4SRJ+DFi3U71uDj8TTTWqMc7xV69lJJsbpRjovDykQBHB53J2ALKe1l1GPdy8sJJavz3KaFaios5HUUHqWhjtU60037hndQKU8Se0TZ0aKGeFjJ7KGCC9aSF2HqPJ+jQhECll/ygAxEFTgQmKQZEOoSa9YRgIUQ5qAoq6warnm+RJUp6+WoS6Ro3E0bBEQp1niJ6MjKUAinFqSN2QY+LYawPymkihdBF0dCLgk1gPAtYTWNRUWJuIk1KPbVbAwaIwyZfDYF2UtCl8s1inedLasr0235vbhnszjDaXSnrHfaafUBBgz04zspZohzsoWDuNM6fdWcKKPp3nfKTmv+mSwPmS/Ya22nZYjf1Gfh0++wjyqvzO5S312U7Fgg/y07Cxf2tHtn2yC68nAXq/6amE93ZgODV4mbJ45+5omRozNuWoBhbjwczC05CnZga0Q3YOWREVPHqAqKuRmW8J+llkZVZbyeIupVEIan9nm5hqdbNt8g7TOyoa+2alGn2GqtyAWFhp3Pp4v7HfaVS3bu5XWhXhujU0YIS89g8XQ1hPZwjixnjoDq0p1j1T4IgtiK7lEbA0rjoSJC0ay71KQ0FQTC0U4F3esUCwNiinTu3R7B/6pTITcRmQZk7poNVjpsDxEreWT1iJX6rVF4EV38Rx8e547yJMQvbwGLCksHhSml40qHw8jBuvMNjr1khVkEUuJr2rIst5TUJQ4vuiPYVYUeNiVrF5Eou9nXe81mx8QPsB/vRJLqookINtt5HsCjJEzAH9sQihbzUKPTG4VGxzBH8SwkkCOTW3RBzml+hA0WaJM/uE3nVm/iWk7vGS0TCqcK08AofkJ1CifeMj8uiEbQU5IaglUrX6pAUvGWUIejNlbffNnPGhowBkgBRM6BMuSkVdVXN1gZrpwTuRbxFlOyZaBglacd2f4mRrrG4RC7GFFedysIH2W3rZLvM4XB4ByDQkaPwVLNkFmsMkML/Ay2MnFssjvkguc29bFLcdI7ojS7N0NC/SCQBht1bMfJz4+FjWZuZzLcPFQT4ArY6mEb9vyc6HgT5qC5660X25FcN1tU0EsWromxFoT5TTsNXmt2CkmJO1t5FSl0t633DBsOPRSVxK/BYWmEUZ7LtdUQEEKdlvyt3ARKrU+OPY3d3tVFqZ1vck5cnIFI2G3Fwnk1s9UJ/pdPKnxSc6ghXequeJeXKK/sD5Vrkj6kmAj3ID2qWXFLsrby5ew06uHSuoWVxjfxtn9ALt0FOlLrjhJBincGD4nJXYP4wzBbDzg8kEewDD9D+Pksr1vL/oagHwHw6L+egr/lf+z2RLknkACy2IWSjA/PK/pQaLd97Bqah8e1ZObYzv4bc2ZGwGiO+dGJ5wf2sU1hv0HqZqEkMUKB1LYI1azuzRYl0KlESPNanTFg77Ya1hqPpBVZIoKoW9UARFkVQGvHqgvEQ0Nc1aU9hRrSc2qSs/mJ1t+vRKtuV11GlAUTvFNce57vDZdwa9PgmYOqOv0fQ7ovGBrPwOuJfb4ER0XgI3ZYwG0tRfAnfBMuoVZr2TNC2B19oiakHCitobMm1A2wbA8fjOkTLIgYOKQtIydRJigq5YiG6pLaUgaEqhoPYTjENQ6VxRDdgS20Xiv9Jy1Hv+D3bNNGLT6xSOuMCrDR0nbMlHsKNNRh3RUxnAUxlw/gyiSiAmX0DGO+VxzJiQZUJtO+3wY6KqaUzb2pLsb++0I7UIKkOifDrEmk66qqCo3fZWrba0HcnqFrUm4/tyCSzOUMwmyBPZzn70y9JiJEkZkYi5ga2lQtNkoZhmGKYOipxGPd2V1Lj5V+DdR2lOvxd4kMmA9iO/U8i+ggxlIZmjujb8JSjhBMbVI+dKZ8vprsSLZidyaYhOfwnwAHFRTeD/8Tezsq6RQFlwRbo0uQQlnAe9buJ5vWfRQbOgiMLRR9elyE2myv0Aj5EkFhn3AOIbgDiq2voDIJ4AuAJw+0tALkl0jmdM1FgT1Y+HPhTgypTo1G0nUpKVXQkwvqfgShPlMqYniD2gqhIB8neoqgD3bjzhRm/AA9hg5wNoD8+aNgABT5OHNwWsx0oDxBWATYEyfYU0hmJ/GvdQuFJNh+Gb6laOaEvYkpo0aflwpymV8X4xza5kpjQac+JZNrOzU8OgCZqa0W0SpRmNc2XaQui8P/1F0Hwx/RV8fpr+ZsmCYb/Dpg5/28UEdFh4EGcvnB8CmyrBbDkH66tr6jFYmo5aX4QnTDg8Ne8shZiDq3dZv/7U8x+10QdCtVy6bo4Ir1ZMCweTeuexjYgBvfMsp8OpoTeGc9D0351qyGgH15nsv2tkhcGxCkf8fdBp0IgzKFfDL6eKOlBsKHqqqTk+LMEzdsTC+DvJPvp9/cE88Ohgo3MTz/OmvWjJed683uKR533Dfa1nSbbhbXvfzwu8wG81IfKBctmWL+RtOADVX6wxJ1exCatn+9XlXePKpRsZjVEVYOviHILTl86pJXIE10IK4SF5X4UGzt+KjuObX/7HRcbvkoTqfyDAu+1n2EH1jX5HGMANlhG0dEd8ok5dSDl/VYTiXOMcXBviGNNgOYD2V7Z+ykvTgM3hZlDCqFZu6MWA4VN+TzWxFPMUHvm3Dzs3GjW+eIj7q0yfNH/0iphUMX7JHQ+KD2VIp3HkWxgzqwnPf2zjgeQvcln+xHYzgzqVCGwNJWuhMks+o5NfIhhSRgqkbmE8vIxLgCArFLHHPLlyIL6lFQlp5lfabcWSOafTEKK4f14trrYxKw9QLob9ekhuFJcTZcmL/ZXqCX2R0OwOTh/F/lcvrFbFOQTPcf8ioM5PEGyMaOSHAFOi1Tz9zNY20ml6FHN4VL3qlJvNu0d40bPRbt34eBuL50QW1uSlDarOIXlGmrwvldyCs9BPlAxnb98UoOewRMO+mn2Ky/LWeuGCf/ZXXq4fr18goPHzWKGt4NqSP7iCInGpAOsbsLq1D/zT+xs7BdBoO0phb96LU44OupIDSMD7kbbNZS+V0XIMuG0AxMD2gnXWJSSlpBH+n/F+PmRkNfC3XIBAKwQJFmKlUGHCRYgUJVoMoFggceIlSAQGkQQqGUzK11fnNOkyZMqSLed/Rf3ey4OSrwAaBhYOHgERCRkFFQ0dAxML+78DRNyfknwCQiJiElIycgpKKurwhuc1WMftvUad2gwZtSt8oOaxtfrCF5NwOObDI2Y9iwXwlL1+/42fjTsccNklYzS0uulcpTfnihuu+dZ1HxjccdMtBxn91OO+u+4x+eQHLYqYFbOysNnGruRh+xErVa5MhVU+qlStympr1Dhmu2/Ucqnzvc9OOOSwkx56FH7I+F/a/NTJYBYbwj+WR0AExooIimBHjJty1HkTJl3QZF+EOONsrIxQ7REW4RERkdG2Ub5GS5XDlDqv3GaGFw2vt2HgohnDIsCf5s9gcmREIRH8qX6EP82f7s/wZ/qz/NkSlQ8TS4XDoql+BrOx3KnXqUtN860IYn4G0ZtQ7rTz3lRwYBGxIfDaj/CnrS69Ah5lqcHVUPsObZL7DLZG3/e6NXjZFNBrKWhJFp5f6EE2bZAESizXEloZUSw/DswsCCbNBDK0nx4fILyNTEdoG65HcBvKRGyrGRti5CJlVNBl/p7YZ66Y2lfs1Of1M8b6Mj7mkuVs0DoxQ2KH1FWH7vPMSzHulwlYUhnvywRNEYovvkyIWeRgvsPfKWuxI8hQQvHIqGlpG50CDVjH51kAAAA=) format('woff2');\n                font-weight: normal;\n                font-style: normal;\n            }\n            body {\n                font-family: \"ralewaylight\";\n            }\n            pre {\n                color: limegreen;\n                background-color: black;\n                padding: 15px;\n            }\n            .markdown {\n                margin: 0 20%;\n            }\n            .nav {\n                margin: 20px 20% 0;\n                display: flex;\n                align-items: center;\n            }\n            .nav a {\n                margin-right: 15px;\n            }\n            .nav input {\n                margin-left: auto;\n                width: 250px;\n            }\n            .results {\n                margin: 10px 20%;\n            }\n            .results .snippet {\n                color: grey;\n                margin: 2px 0 10px;\n            }\n            .editor {\n                display: none;\n                margin: 0 20%;\n            }\n            .editor textarea {\n                width: 100%;\n                height: 600px;\n                font-family: monospace;\n            }\n            .error {\n                color: red;\n            }\n\t\t</style>\n    </head>\n\n    <body>\t\n\n\t\t<!-- Navigation -->\n\t\t<div class=\"nav\">\n\t\t\t<a href=\"/wiki\">Index</a>\n\t\t\t<a href=\"/wiki/commands\">Commands</a>\n\t\t\t{{ if .Editable }}<button id=\"edit\">Edit</button>{{ end }}\n\t\t\t<input id=\"search\" type=\"search\" placeholder=\"Search the wiki\">\n\t\t</div>\n\n\t\t<!-- Search Results -->\n\t\t<div class=\"results\" id=\"results\"></div>\n\n\t\t<!-- Editor -->\n\t\t<div class=\"editor\" id=\"editor\">\n\t\t\t<textarea id=\"source\"></textarea>\n\t\t\t<button id=\"save\">Save</button>\n\t\t\t<button id=\"cancel\">Cancel</button>\n\t\t\t<span class=\"error\" id=\"error\"></span>\n\t\t</div>\n\t\t\n\t\t<!-- Markdown -->\n\t\t<div class=\"markdown\" id=\"markdown\">\n\t\t\t{{ .Content }}\n\t\t</div>\n\n\t\t<script>\n\t\t\tvar page = {{ .Page }};\n\n\t\t\tfunction getCookie(name) {\n\t\t\t\tvar m = document.cookie.match(new RegExp(\"(?:^|; )\" + name + \"=([^;]*)\"));\n\t\t\t\treturn m ? decodeURIComponent(m[1]) : \"\";\n\t\t\t}\n\n\t\t\tfunction api(method, url, body) {\n\t\t\t\treturn fetch(url, {\n\t\t\t\t\tmethod: method,\n\t\t\t\t\tcredentials: \"same-origin\",\n\t\t\t\t\theaders: {\n\t\t\t\t\t\t\"Content-Type\": \"application/json\",\n\t\t\t\t\t\t\"X-CSRF-Token\": getCookie(\"zeus-csrf\")\n\t\t\t\t\t},\n\t\t\t\t\tbody: body ? JSON.stringify(body) : undefined\n\t\t\t\t}).then(function(res) {\n\t\t\t\t\treturn res.json().then(function(data) {\n\t\t\t\t\t\tif (!res.ok) {\n\t\t\t\t\t\t\tthrow new Error(data.error || res.statusText);\n\t\t\t\t\t\t}\n\t\t\t\t\t\treturn data;\n\t\t\t\t\t});\n\t\t\t\t});\n\t\t\t}\n\n\t\t\tfunction text(tag, content, className) {\n\t\t\t\tvar e = document.createElement(tag);\n\t\t\t\te.textContent = content;\n\t\t\t\tif (className) {\n\t\t\t\t\te.className = className;\n\t\t\t\t}\n\t\t\t\treturn e;\n\t\t\t}\n\n\t\t\t// search\n\t\t\tvar timer;\n\t\t\tdocument.getElementById(\"search\").addEventListener(\"input\", function(e) {\n\t\t\t\tclearTimeout(timer);\n\t\t\t\tvar q = e.target.value.trim();\n\t\t\t\ttimer = setTimeout(function() {\n\t\t\t\t\tvar results = document.getElementById(\"results\");\n\t\t\t\t\tresults.innerHTML = \"\";\n\t\t\t\t\tif (q === \"\") {\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tapi(\"GET\", \"/api/wiki/search?q=\" + encodeURIComponent(q)).then(function(data) {\n\t\t\t\t\t\tif (data.length === 0) {\n\t\t\t\t\t\t\tresults.appendChild(text(\"p\", \"no results for \" + q));\n\t\t\t\t\t\t}\n\t\t\t\t\t\tdata.forEach(function(r) {\n\t\t\t\t\t\t\tvar a = text(\"a\", r.title);\n\t\t\t\t\t\t\ta.href = r.url;\n\t\t\t\t\t\t\tresults.appendChild(a);\n\t\t\t\t\t\t\tresults.appendChild(text(\"div\", r.snippet, \"snippet\"));\n\t\t\t\t\t\t});\n\t\t\t\t\t}).catch(function(err) {\n\t\t\t\t\t\tresults.appendChild(text(\"p\", err.message, \"error\"));\n\t\t\t\t\t});\n\t\t\t\t}, 200);\n\t\t\t});\n\n\t\t\t// editor\n\t\t\tvar edit = document.getElementById(\"edit\");\n\t\t\tif (edit) {\n\t\t\t\tvar editor = document.getElementById(\"editor\"),\n\t\t\t\t\tmarkdown = document.getElementById(\"markdown\"),\n\t\t\t\t\terror = document.getElementById(\"error\");\n\n\t\t\t\tedit.addEventListener(\"click\", function() {\n\t\t\t\t\tapi(\"GET\", \"/api/wiki/page?page=\" + encodeURIComponent(page)).then(function(data) {\n\t\t\t\t\t\tdocument.getElementById(\"source\").value = data.markdown;\n\t\t\t\t\t\terror.textContent = \"\";\n\t\t\t\t\t\teditor.style.display = \"block\";\n\t\t\t\t\t\tmarkdown.style.display = \"none\";\n\t\t\t\t\t}).catch(function(err) {\n\t\t\t\t\t\talert(err.message);\n\t\t\t\t\t});\n\t\t\t\t});\n\t\t\t\tdocument.getElementById(\"cancel\").addEventListener(\"click\", function() {\n\t\t\t\t\teditor.style.display = \"none\";\n\t\t\t\t\tmarkdown.style.display = \"block\";\n\t\t\t\t});\n\t\t\t\tdocument.getElementById(\"save\").addEventListener(\"click\", function() {\n\t\t\t\t\tapi(\"PUT\", \"/api/wiki/page?page=\" + encodeURIComponent(page), {\n\t\t\t\t\t\tmarkdown: document.getElementById(\"source\").value\n\t\t\t\t\t}).then(function() {\n\t\t\t\t\t\tlocation.reload();\n\t\t\t\t\t}).catch(function(err) {\n\t\t\t\t\t\terror.textContent = err.message;\n\t\t\t\t\t});\n\t\t\t\t});\n\t\t\t}\n\t\t</script>\n\t\t\n\t</body>\n</html>"),
 	}
 
 	// define dirs
@@ -203,6 +208,7 @@ func init() {
 			filel, // "ascii_art.txt"
 			filem, // "ascii_art.yml"
 			filen, // "ascii_art_color.txt"
+			filep, // "report.html"
 			fileo, // "wiki_index.html"
 
 		},
@@ -222,6 +228,7 @@ func init() {
 			"ascii_art.txt":       filel,
 			"ascii_art.yml":       filem,
 			"ascii_art_color.txt": filen,
+			"report.html":         filep,
 			"wiki_index.html":     fileo,
 		},
 	})
@@ -0,0 +1,445 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnknownSchema means there is no schema with the requested name
+var ErrUnknownSchema = errors.New("unknown schema")
+
+// jsonSchema is a subset of JSON Schema draft 7
+// it is generated from the Go structs that the ZEUS files are unmarshaled into
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+}
+
+// descriptions for the schema properties, mapped by struct type and YAML field name
+var schemaDescriptions = map[string]string{
+	"CommandsFile.language":    "default language for all commands",
+	"CommandsFile.globals":     "global variables, visible for all commands",
+	"CommandsFile.commands":    "all commands of the project",
+	"CommandsFile.services":    "service groups: long running commands that are started together with the up builtin",
+	"commandData.description":  "a short description text that will be displayed on startup",
+	"commandData.help":         "a multi line manual entry for detailed explanations",
+	"commandData.language":     "scripting language of the command",
+	"commandData.arguments":    "a list of typed arguments, allows optionals and default values",
+	"commandData.dependencies": "a list of dependency commands with their arguments",
+	"commandData.outputs":      "a list of output files or directories",
+	"commandData.buildNumber":  "increment the build number on each execution",
+	"commandData.async":        "detach the command in a screen session, attach on demand",
+	"commandData.exec":         "the script to run, instead of a file in the script directory",
+	"commandData.path":         "custom path for the script file",
+	"dataFields.buildNumber":   "current build number",
+	"dataFields.deadline":      "project deadline",
+	"dataFields.milestones":    "project milestones",
+	"dataFields.aliases":       "alias names mapped to commands",
+	"dataFields.events":        "mapping from watched path to the corresponding event",
+	"dataFields.author":        "project author",
+	"dataFields.keyBindings":   "keys mapped to commands",
+}
+
+// a named schema for one of the ZEUS files
+type schemaFile struct {
+	name  string
+	file  string
+	value interface{}
+	title string
+}
+
+// all ZEUS files that have a schema
+var schemaFiles = []*schemaFile{
+	{name: "commands", file: "commands.yml", value: CommandsFile{}, title: "ZEUS CommandsFile"},
+	{name: "config", file: "config.yml", value: configFields{}, title: "ZEUS project config"},
+	{name: "data", file: "data.yml", value: dataFields{}, title: "ZEUS project data"},
+}
+
+// generated schemas, mapped by name
+var schemas = generateSchemas()
+
+func generateSchemas() map[string]*jsonSchema {
+	m := make(map[string]*jsonSchema, len(schemaFiles))
+	for _, f := range schemaFiles {
+		s := schemaForType(reflect.TypeOf(f.value))
+		s.Schema = "http://json-schema.org/draft-07/schema#"
+		s.Title = f.title
+		m[f.name] = s
+	}
+	return m
+}
+
+// generate the schema for a Go type
+// fields are named like the YAML package does: the yaml tag or the lowercase field name
+func schemaForType(t reflect.Type) *jsonSchema {
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaForType(t.Elem())
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: schemaForType(t.Elem())}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: schemaForType(t.Elem())}
+	case reflect.Struct:
+		s := &jsonSchema{
+			Type:                 "object",
+			Properties:           make(map[string]*jsonSchema, t.NumField()),
+			AdditionalProperties: false,
+		}
+		for i := 0; i < t.NumField(); i++ {
+
+			field := t.Field(i)
+
+			// unexported
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+
+			p := schemaForType(field.Type)
+			p.Description = schemaDescriptions[t.Name()+"."+name]
+			s.Properties[name] = p
+		}
+		return s
+	}
+
+	// anything goes
+	return &jsonSchema{}
+}
+
+// JSON returns the indented JSON representation of the schema
+func (s *jsonSchema) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// property returns the schema for the value of the named key
+// known is false if the key is not allowed
+func (s *jsonSchema) property(name string) (p *jsonSchema, known bool) {
+
+	if p, ok := s.Properties[name]; ok {
+		return p, true
+	}
+
+	switch ap := s.AdditionalProperties.(type) {
+	case *jsonSchema:
+		return ap, true
+	case bool:
+		return nil, ap
+	}
+
+	// additional properties are allowed by default
+	return nil, true
+}
+
+// properties returns the sorted names of all properties
+func (s *jsonSchema) properties() []string {
+	var names []string
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// schemaError is a violation of the schema in a YAML document
+type schemaError struct {
+
+	// line number starting at 1
+	line int
+
+	msg string
+}
+
+func (e *schemaError) Error() string {
+	return "line " + strconv.Itoa(e.line) + ": " + e.msg
+}
+
+// a mapping or sequence in the YAML document that is currently checked
+type schemaFrame struct {
+
+	// indentation of the key that opened the frame
+	indent int
+
+	// schema for the value of the key, nil if there is nothing to check
+	schema *jsonSchema
+
+	// keys seen in the mapping, mapped to their line
+	keys map[string]int
+
+	// frame was opened by a sequence item
+	item bool
+}
+
+// check a YAML document against the schema
+// finds unknown keys, duplicate keys and scalar values of the wrong type
+// YAML does not expose line numbers after parsing, so the document is walked line by line
+func (s *jsonSchema) check(contents string) (errs []*schemaError) {
+
+	var (
+		stack = []*schemaFrame{{
+			indent: -1,
+			schema: s,
+			keys:   make(map[string]int, 0),
+		}}
+
+		// lines that are indented deeper belong to a block scalar
+		blockIndent = -1
+	)
+
+	for i, line := range strings.Split(contents, "\n") {
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+
+		indent := countLeadingSpace(line)
+		if blockIndent >= 0 {
+			if indent > blockIndent {
+				continue
+			}
+			blockIndent = -1
+		}
+
+		var (
+			isItem    = strings.HasPrefix(trimmed, "- ") || trimmed == "-"
+			content   = trimmed
+			keyIndent = indent
+		)
+
+		// close all frames the line does not belong to
+		for len(stack) > 1 {
+			top := stack[len(stack)-1]
+			if top.indent > indent || (top.indent == indent && (!isItem || top.item)) {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			break
+		}
+
+		if isItem {
+
+			parent := stack[len(stack)-1]
+			content = strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			keyIndent = indent + len(trimmed) - len(content)
+
+			var items *jsonSchema
+			if parent.schema != nil {
+				items = parent.schema.Items
+			}
+
+			// the item is a mapping, its keys are checked in a new frame
+			if yamlKey(content) != "" {
+				stack = append(stack, &schemaFrame{
+					indent: indent,
+					schema: items,
+					keys:   make(map[string]int, 0),
+					item:   true,
+				})
+			} else {
+				if isBlockScalar(content) {
+					blockIndent = indent
+				}
+				continue
+			}
+		}
+
+		key := yamlKey(content)
+		if key == "" {
+			continue
+		}
+
+		var (
+			frame = stack[len(stack)-1]
+			value = strings.TrimSpace(strings.SplitN(content, ":", 2)[1])
+			child *jsonSchema
+		)
+
+		if frame.schema != nil {
+
+			p, known := frame.schema.property(key)
+			if !known {
+				errs = append(errs, &schemaError{line: i + 1, msg: "unknown field: " + key})
+			}
+			child = p
+
+			if first, ok := frame.keys[key]; ok {
+				errs = append(errs, &schemaError{line: i + 1, msg: "duplicate field: " + key + ", first declared in line " + strconv.Itoa(first)})
+			}
+			frame.keys[key] = i + 1
+		}
+
+		switch {
+		case isBlockScalar(value):
+			blockIndent = keyIndent
+		case value == "" || strings.HasPrefix(value, "#"):
+			stack = append(stack, &schemaFrame{
+				indent: keyIndent,
+				schema: child,
+				keys:   make(map[string]int, 0),
+			})
+		case child != nil:
+			if msg := child.checkScalar(value); msg != "" {
+				errs = append(errs, &schemaError{line: i + 1, msg: key + ": " + msg})
+			}
+		}
+	}
+
+	return errs
+}
+
+// check a scalar value against the type of the schema
+func (s *jsonSchema) checkScalar(value string) string {
+
+	// strip comments
+	if i := strings.Index(value, " #"); i > 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+
+	switch s.Type {
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil && value != "yes" && value != "no" && value != "on" && value != "off" {
+			return "expected boolean, got: " + value
+		}
+	case "integer":
+		if _, err := strconv.ParseInt(value, 0, 64); err != nil {
+			return "expected integer, got: " + value
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "expected number, got: " + value
+		}
+	case "object":
+		if !strings.HasPrefix(value, "{") && value != "~" && value != "null" {
+			return "expected mapping, got: " + value
+		}
+	case "array":
+		if !strings.HasPrefix(value, "[") && value != "~" && value != "null" {
+			return "expected list, got: " + value
+		}
+	}
+
+	return ""
+}
+
+// check if a YAML value introduces a literal or folded block scalar
+func isBlockScalar(value string) bool {
+	return strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">")
+}
+
+func printSchemaUsageErr() {
+	l.Println(ErrInvalidUsage)
+	l.Println("usage: schema [commands | config | data | write]")
+}
+
+// handle schema shell command
+func handleSchemaCommand(args []string) error {
+
+	if len(args) != 2 {
+		printSchemaUsageErr()
+		return ErrInvalidUsage
+	}
+
+	if args[1] == "write" {
+		return writeSchemas()
+	}
+
+	s, ok := schemas[args[1]]
+	if !ok {
+		printSchemaUsageErr()
+		return ErrUnknownSchema
+	}
+
+	b, err := s.JSON()
+	if err != nil {
+		return err
+	}
+
+	l.Println(string(b))
+	return nil
+}
+
+// write all schemas into the schema directory
+// editors can reference them, for example with a modeline for the yaml-language-server:
+// # yaml-language-server: $schema=schema/commands.json
+func writeSchemas() error {
+
+	schemaDir := filepath.Join(zeusDir, "schema")
+
+	err := os.MkdirAll(schemaDir, 0700)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range schemaFiles {
+
+		b, err := schemas[f.name].JSON()
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(schemaDir, f.name+".json")
+		err = ioutil.WriteFile(path, append(b, '\n'), 0644)
+		if err != nil {
+			return err
+		}
+
+		l.Println(printPrompt() + "wrote schema for " + f.file + " to " + path + cp.Reset)
+	}
+
+	return nil
+}
@@ -0,0 +1,446 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	// service groups from the CommandsFile
+	// group names mapped to the commands that belong to the group
+	serviceGroups      = make(map[string][]string, 0)
+	serviceGroupsMutex = &sync.Mutex{}
+
+	// ErrUnknownServiceGroup means the requested service group does not exist
+	ErrUnknownServiceGroup = errors.New("unknown service group")
+
+	// ErrAsyncService means an async command was added to a service group
+	ErrAsyncService = errors.New("async commands can not be used as services")
+
+	// ErrServiceCrashed means a service of a group exited with an error
+	ErrServiceCrashed = errors.New("service crashed")
+
+	// time to wait for services to exit after SIGTERM, before they get killed
+	serviceShutdownTimeout = 5 * time.Second
+
+	// format for the timestamp in front of each service output line
+	serviceTimestampFormat = "15:04:05"
+)
+
+// a service is a long running command that is part of a service group
+type service struct {
+
+	// name used as prefix for the output
+	name string
+
+	// command to run and its arguments
+	cmd  *command
+	args []string
+
+	// underlying process
+	proc *exec.Cmd
+}
+
+func printUpCommandUsageErr() {
+	l.Println(ErrInvalidUsage)
+	l.Println("usage: up <group>")
+}
+
+// set the service groups, called when parsing the CommandsFile
+func setServiceGroups(groups map[string][]string) {
+	serviceGroupsMutex.Lock()
+	defer serviceGroupsMutex.Unlock()
+
+	serviceGroups = make(map[string][]string, 0)
+	for name, cmds := range groups {
+		serviceGroups[name] = cmds
+	}
+}
+
+// validate all service groups
+// must be called after the commandMap has been initialized
+func validateServiceGroups() error {
+
+	serviceGroupsMutex.Lock()
+	defer serviceGroupsMutex.Unlock()
+
+	for group, entries := range serviceGroups {
+
+		if len(entries) == 0 {
+			return errors.New("service group " + group + " is empty")
+		}
+
+		for _, entry := range entries {
+
+			fields := strings.Fields(entry)
+			if len(fields) == 0 {
+				return errors.New("service group " + group + " contains an empty entry")
+			}
+
+			cmd, err := cmdMap.getCommand(fields[0])
+			if err != nil {
+				return errors.New("service group " + group + ": " + err.Error())
+			}
+
+			if cmd.async {
+				return errors.New("service group " + group + ": " + ErrAsyncService.Error() + ": " + cmd.name)
+			}
+
+			_, err = cmd.parseArguments(fields[1:])
+			if err != nil {
+				return errors.New("service group " + group + ": " + cmd.name + ": " + err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// handle up shell command
+func handleUpCommand(args []string) error {
+
+	if len(args) < 2 {
+		printServiceGroups()
+		return nil
+	}
+
+	if len(args) > 2 {
+		printUpCommandUsageErr()
+		return ErrInvalidUsage
+	}
+
+	err := startServiceGroup(args[1])
+	if err != nil {
+		Log.WithError(err).Error("service group " + args[1] + " failed")
+	}
+
+	return err
+}
+
+// print all service groups and their commands
+func printServiceGroups() {
+
+	serviceGroupsMutex.Lock()
+	defer serviceGroupsMutex.Unlock()
+
+	if len(serviceGroups) == 0 {
+		l.Println("no service groups defined.")
+		return
+	}
+
+	var names []string
+	for name := range serviceGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	l.Println(cp.Text + "services")
+	for _, name := range names {
+		l.Println(cp.CmdName + pad(name, 20) + cp.Text + strings.Join(serviceGroups[name], ", "))
+	}
+}
+
+// start all services of a group and interleave their output
+// blocks until all services have exited
+// if one service exits, all others will be stopped
+// an error is returned if the group was stopped because a service crashed
+func startServiceGroup(group string) error {
+
+	serviceGroupsMutex.Lock()
+	entries, ok := serviceGroups[group]
+	serviceGroupsMutex.Unlock()
+
+	if !ok {
+		return errors.New(ErrUnknownServiceGroup.Error() + ": " + group)
+	}
+
+	var (
+		services []*service
+		maxLen   int
+	)
+
+	// resolve commands
+	for _, entry := range entries {
+
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			return errors.New("empty service entry in group: " + group)
+		}
+
+		cmd, err := cmdMap.getCommand(fields[0])
+		if err != nil {
+			return err
+		}
+
+		if cmd.async {
+			return errors.New(ErrAsyncService.Error() + ": " + cmd.name)
+		}
+
+		if len(cmd.name) > maxLen {
+			maxLen = len(cmd.name)
+		}
+
+		services = append(services, &service{
+			name: cmd.name,
+			cmd:  cmd,
+			args: fields[1:],
+		})
+	}
+
+	// run dependencies of all services before starting them
+	for _, svc := range services {
+		err := svc.cmd.execDependencies(newExecContext())
+		if err != nil {
+			return errors.New("dependency error: " + err.Error())
+		}
+	}
+
+	type exit struct {
+		svc *service
+		err error
+	}
+
+	var (
+		wg        sync.WaitGroup
+		outMutex  = &sync.Mutex{}
+		exited    = make(chan exit, len(services))
+		sigChan   = make(chan os.Signal, 1)
+		colors    = serviceColors()
+		startedAt = time.Now()
+	)
+
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	l.Println(printPrompt() + "starting service group " + cp.Prompt + group + cp.Reset)
+
+	for i, svc := range services {
+
+		w := newPrefixWriter(svc.name, maxLen, colors[i%len(colors)], outMutex)
+
+		err := svc.start(w)
+		if err != nil {
+			stopServices(services)
+			wg.Wait()
+			return errors.New("failed to start service " + svc.name + ": " + err.Error())
+		}
+
+		wg.Add(1)
+		go func(svc *service, w *prefixWriter) {
+			defer wg.Done()
+
+			err := svc.wait()
+			w.Flush()
+
+			if err != nil {
+				w.printStatus("exited with error: " + err.Error())
+			} else {
+				w.printStatus("exited")
+			}
+			exited <- exit{svc: svc, err: err}
+		}(svc, w)
+	}
+
+	var crashed error
+
+	// wait until the first service exits or an interrupt arrives
+	select {
+	case e := <-exited:
+		l.Println(printPrompt() + "service " + cp.Prompt + e.svc.name + cp.Text + " exited, stopping service group " + cp.Prompt + group + cp.Reset)
+		if e.err != nil {
+			crashed = errors.New(ErrServiceCrashed.Error() + ": " + e.svc.name + ": " + e.err.Error())
+		}
+	case sig := <-sigChan:
+		l.Println(printPrompt() + "received " + sig.String() + ", stopping service group " + cp.Prompt + group + cp.Reset)
+	}
+
+	stopServices(services)
+	wg.Wait()
+
+	l.Println(printPrompt()+"service group "+cp.Prompt+group+cp.Text+" stopped after"+cp.Prompt, time.Now().Sub(startedAt), cp.Reset)
+
+	return crashed
+}
+
+// start the service and wire its output to the supplied writer
+func (svc *service) start(w io.Writer) error {
+
+	argBuffer, err := svc.cmd.parseArguments(svc.args)
+	if err != nil {
+		return err
+	}
+
+	cmd, _, cleanupFunc, err := svc.cmd.createCommand(argBuffer, false)
+	if err != nil {
+		return err
+	}
+
+	// cleanup func is only set for temp files
+	// they are not needed anymore once the interpreter started
+	defer func() {
+		if cleanupFunc != nil {
+			go func() {
+				time.Sleep(time.Second)
+				cleanupFunc()
+			}()
+		}
+	}()
+
+	cmd.Env = os.Environ()
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	// run in a separate process group
+	// so the whole process tree can be stopped
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	svc.proc = cmd
+	addProcess(processID(randomString()), svc.name, cmd.Process, cmd.Process.Pid)
+
+	return nil
+}
+
+// wait for the service process to exit
+func (svc *service) wait() error {
+
+	defer deleteProcessByPID(svc.proc.Process.Pid)
+
+	return svc.proc.Wait()
+}
+
+// stop all running services
+// sends SIGTERM to each process group and kills it if it does not exit in time
+func stopServices(services []*service) {
+
+	for _, svc := range services {
+		if svc.proc != nil {
+			syscall.Kill(-svc.proc.Process.Pid, syscall.SIGTERM)
+		}
+	}
+
+	deadline := time.Now().Add(serviceShutdownTimeout)
+	for _, svc := range services {
+		if svc.proc == nil {
+			continue
+		}
+		for time.Now().Before(deadline) {
+			// signal 0 checks whether the process group still exists
+			if syscall.Kill(-svc.proc.Process.Pid, 0) != nil {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		syscall.Kill(-svc.proc.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// colors for the service name prefixes, taken from the active color profile
+func serviceColors() []string {
+	cp.Lock()
+	defer cp.Unlock()
+	return []string{cp.CmdName, cp.CmdFields, cp.CmdArgType, cp.Text, cp.Prompt, cp.CmdArgs}
+}
+
+/*
+ *	Prefix Writer
+ */
+
+// prefixWriter prefixes each line of output with a colored name and a timestamp
+// all prefixWriters of a service group share a mutex, so lines do not get mixed up
+type prefixWriter struct {
+	name  string
+	color string
+	buf   bytes.Buffer
+	out   *sync.Mutex
+}
+
+func newPrefixWriter(name string, width int, color string, out *sync.Mutex) *prefixWriter {
+	return &prefixWriter{
+		name:  pad(name, width),
+		color: color,
+		out:   out,
+	}
+}
+
+// Write buffers incomplete lines and prints all complete ones
+func (w *prefixWriter) Write(p []byte) (int, error) {
+
+	w.buf.Write(p)
+
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		line := string(w.buf.Next(i + 1))
+		w.printLine(strings.TrimSuffix(line, "\n"))
+	}
+
+	return len(p), nil
+}
+
+// Flush prints the remaining buffer contents
+func (w *prefixWriter) Flush() {
+	if w.buf.Len() > 0 {
+		w.printLine(w.buf.String())
+		w.buf.Reset()
+	}
+}
+
+func (w *prefixWriter) prefix() string {
+	return w.color + w.name + " " + cp.Text + time.Now().Format(serviceTimestampFormat) + " | " + cp.Reset
+}
+
+func (w *prefixWriter) printLine(line string) {
+	w.out.Lock()
+	l.Println(w.prefix() + line)
+	w.out.Unlock()
+}
+
+func (w *prefixWriter) printStatus(msg string) {
+	w.out.Lock()
+	l.Println(w.prefix() + cp.Prompt + msg + cp.Reset)
+	w.out.Unlock()
+}
+
+// complete available service groups
+func serviceGroupCompleter(path string) (res []string) {
+	serviceGroupsMutex.Lock()
+	defer serviceGroupsMutex.Unlock()
+	for name := range serviceGroups {
+		res = append(res, name)
+	}
+	return
+}
@@ -0,0 +1,392 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the project settings as edited in the web panel
+type settings struct {
+	Config      []*settingsField     `json:"config"`
+	DateFormat  string               `json:"dateFormat"`
+	BuildNumber int                  `json:"buildNumber"`
+	Deadline    string               `json:"deadline"`
+	Author      string               `json:"author"`
+	Aliases     map[string]string    `json:"aliases"`
+	Milestones  []*settingsMilestone `json:"milestones"`
+	KeyBindings map[string]string    `json:"keyBindings"`
+	Keys        []string             `json:"keys"`
+	Events      []*settingsEvent     `json:"events"`
+}
+
+// a config field that can be set with the config builtin
+type settingsField struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+type settingsMilestone struct {
+	Name            string `json:"name"`
+	Date            string `json:"date"`
+	Description     string `json:"description"`
+	PercentComplete int    `json:"percentComplete"`
+}
+
+type settingsEvent struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Op            string `json:"op"`
+	Path          string `json:"path"`
+	FileExtension string `json:"fileExtension"`
+	Command       string `json:"command"`
+}
+
+// request body for all settings routes, each route uses the fields it needs
+type settingsRequest struct {
+	Value         interface{} `json:"value"`
+	Name          string      `json:"name"`
+	Command       string      `json:"command"`
+	Date          string      `json:"date"`
+	Description   string      `json:"description"`
+	Percent       interface{} `json:"percent"`
+	Op            string      `json:"op"`
+	Path          string      `json:"path"`
+	FileExtension string      `json:"fileExtension"`
+}
+
+// collect the current settings
+func currentSettings() *settings {
+
+	s := &settings{
+		Config:      make([]*settingsField, 0),
+		Aliases:     make(map[string]string, 0),
+		Milestones:  make([]*settingsMilestone, 0),
+		KeyBindings: make(map[string]string, 0),
+		Keys:        make([]string, 0),
+		Events:      make([]*settingsEvent, 0),
+	}
+
+	conf.Lock()
+	v := reflect.Indirect(reflect.ValueOf(conf.fields))
+	for i := 0; i < v.NumField(); i++ {
+
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Bool, reflect.Int, reflect.String:
+		default:
+			// maps and slices are edited in the config file
+			continue
+		}
+
+		s.Config = append(s.Config, &settingsField{
+			Name:  strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0],
+			Type:  f.Kind().String(),
+			Value: f.Interface(),
+		})
+	}
+	s.DateFormat = conf.fields.DateFormat
+	conf.Unlock()
+
+	projectData.Lock()
+	s.BuildNumber = projectData.fields.BuildNumber
+	s.Deadline = projectData.fields.Deadline
+	s.Author = projectData.fields.Author
+	for name, command := range projectData.fields.Aliases {
+		s.Aliases[name] = command
+	}
+	for _, m := range projectData.fields.Milestones {
+		s.Milestones = append(s.Milestones, &settingsMilestone{
+			Name:            m.Name,
+			Date:            m.Date.Format(s.DateFormat),
+			Description:     m.Description,
+			PercentComplete: m.PercentComplete,
+		})
+	}
+	for key, command := range projectData.fields.KeyBindings {
+		s.KeyBindings[key] = command
+	}
+	for _, e := range projectData.fields.Events {
+
+		// internal watchers are managed by zeus
+		if e.Command == "internal" {
+			continue
+		}
+
+		s.Events = append(s.Events, &settingsEvent{
+			ID:            e.ID,
+			Name:          e.Name,
+			Op:            e.Op.String(),
+			Path:          e.Path,
+			FileExtension: e.FileExtension,
+			Command:       e.Command,
+		})
+	}
+	projectData.Unlock()
+
+	for _, key := range keyMap {
+		s.Keys = append(s.Keys, key)
+	}
+	sort.Strings(s.Keys)
+
+	sort.Slice(s.Events, func(i, j int) bool {
+		return s.Events[i].Path < s.Events[j].Path
+	})
+
+	return s
+}
+
+// let the shell and all web interfaces know about a change from the web panel
+func settingsChanged(change string) {
+
+	msg := cp.Text + "webinterface: " + change + cp.Reset + "\n"
+
+	readlineMutex.Lock()
+	if rl != nil {
+		rl.SetPrompt(printPrompt())
+		rl.Write([]byte(msg))
+	} else {
+		l.Print(msg)
+	}
+	readlineMutex.Unlock()
+
+	broadcast(&streamMessage{Type: "settings"})
+}
+
+// decode the request body and call the handler
+// the handler returns the HTTP status, the change for the notification or an error
+func settingsHandler(handle func(r *http.Request, req *settingsRequest) (int, string, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var req settingsRequest
+		if r.Method != "DELETE" {
+			err := json.NewDecoder(r.Body).Decode(&req)
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, errors.New("invalid request body: "+err.Error()))
+				return
+			}
+		}
+
+		status, change, err := handle(r, &req)
+		if err != nil {
+			writeJSONError(w, status, err)
+			return
+		}
+
+		settingsChanged(change)
+		writeJSON(w, http.StatusOK, currentSettings())
+	}
+}
+
+// JSON values are passed to the config as in the shell
+func settingsValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// get the settings
+var settingsGetHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, currentSettings())
+})
+
+// set a config field, the same as: config set <field> <value>
+var configSetHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	field := strings.TrimPrefix(r.URL.Path, "/api/config/")
+	value := settingsValue(req.Value)
+
+	err := conf.set(field, value)
+	if err != nil {
+		return http.StatusBadRequest, "", err
+	}
+
+	return http.StatusOK, "set config field " + field + " to " + value, nil
+})
+
+// add or replace an alias, the same as: alias set <name> <command>
+var aliasAddHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	err := addAlias(req.Name, strings.TrimSpace(req.Command))
+	if err != nil {
+		return http.StatusBadRequest, "", err
+	}
+
+	return http.StatusOK, "set alias " + req.Name, nil
+})
+
+// remove an alias, the same as: alias remove <name>
+var aliasRemoveHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/aliases/")
+
+	err := deleteAlias(name)
+	if err != nil {
+		return http.StatusNotFound, "", err
+	}
+
+	return http.StatusOK, "removed alias " + name, nil
+})
+
+// add a milestone, the same as: milestones add <name> <date> [description]
+var milestoneAddHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	err := createMilestone(req.Name, req.Date, req.Description)
+	if err != nil {
+		return http.StatusBadRequest, "", err
+	}
+
+	return http.StatusOK, "added milestone " + req.Name, nil
+})
+
+// set the progress of a milestone, the same as: milestones set <name> <0-100>
+var milestoneSetHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	var (
+		name    = strings.TrimPrefix(r.URL.Path, "/api/milestones/")
+		percent = settingsValue(req.Percent)
+	)
+
+	err := setMilestone(name, percent)
+	if err == ErrInvalidPercentage {
+		return http.StatusBadRequest, "", err
+	}
+	if err != nil {
+		return http.StatusNotFound, "", err
+	}
+
+	return http.StatusOK, "set milestone " + name + " to " + percent + "%", nil
+})
+
+// remove a milestone, the same as: milestones remove <name>
+var milestoneRemoveHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/milestones/")
+
+	err := removeMilestone(name)
+	if err != nil {
+		return http.StatusNotFound, "", err
+	}
+
+	return http.StatusOK, "removed milestone " + name, nil
+})
+
+// set the deadline, the same as: deadline set <date>
+var deadlineSetHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	err := setDeadline(req.Date)
+	if err != nil {
+		return http.StatusBadRequest, "", err
+	}
+
+	return http.StatusOK, "set deadline to " + req.Date, nil
+})
+
+// remove the deadline, the same as: deadline remove
+var deadlineRemoveHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+	removeDeadline()
+	return http.StatusOK, "removed deadline", nil
+})
+
+// set the author, the same as: author set <name>
+var authorSetHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	if strings.TrimSpace(req.Name) == "" {
+		return http.StatusBadRequest, "", errors.New("no author name supplied")
+	}
+
+	setAuthor(req.Name)
+
+	return http.StatusOK, "set author to " + req.Name, nil
+})
+
+// remove the author, the same as: author remove
+var authorRemoveHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+	setAuthor("")
+	return http.StatusOK, "removed author", nil
+})
+
+// bind a key, the same as: keys set <KeyComb> <commandChain>
+var keySetHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	key := strings.TrimPrefix(r.URL.Path, "/api/keys/")
+
+	err := setKeyBinding(key, req.Command)
+	if err != nil {
+		return http.StatusBadRequest, "", err
+	}
+
+	return http.StatusOK, "bound " + key + " to " + req.Command, nil
+})
+
+// remove a key binding, the same as: keys remove <KeyComb>
+var keyRemoveHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	key := strings.TrimPrefix(r.URL.Path, "/api/keys/")
+
+	err := removeKeyBinding(key)
+	if err != nil {
+		return http.StatusNotFound, "", err
+	}
+
+	return http.StatusOK, "removed key binding " + key, nil
+})
+
+// add an event, the same as: events add <optype> <path> [filetype] <commandChain>
+// this is a function and not a variable, because events can run builtins
+// and the web builtin creates the router, which would be an initialization cycle
+func eventAddHandler(w http.ResponseWriter, r *http.Request) {
+	settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+		err := createEvent(req.Op, req.Path, req.FileExtension, strings.Fields(req.Command))
+		if err != nil {
+			return http.StatusBadRequest, "", err
+		}
+
+		return http.StatusOK, "added " + req.Op + " event for " + req.Path, nil
+	})(w, r)
+}
+
+// remove an event, the same as: events remove <id>
+var eventRemoveHandler = settingsHandler(func(r *http.Request, req *settingsRequest) (int, string, error) {
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/events/")
+
+	err := removeEvent(id)
+	if err != nil {
+		return http.StatusNotFound, "", err
+	}
+
+	return http.StatusOK, "removed event " + id, nil
+})
@@ -22,9 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -96,7 +94,7 @@ func readlineLoop() error {
 
 				if conf.fields.ExitOnInterrupt {
 					clearProcessMap()
-					os.Exit(0)
+					exit(0)
 				} else {
 					Log.Info("ExitOnInterrupt is disabled, type 'exit' if you want to leave.")
 					continue
@@ -119,11 +117,41 @@ func handleLine(line string) {
 	// set the color
 	print(cp.CmdOutput)
 
+	// run command chains with a trailing & in the background
+	// other commands are passed to the shell, that runs them in the background
+	if strings.HasSuffix(line, jobSuffix) && !strings.HasSuffix(line, jobSuffix+jobSuffix) {
+
+		fields, err := expandChainAliases(strings.TrimSpace(strings.TrimSuffix(line, jobSuffix)))
+		if err != nil {
+			l.Println(err)
+			return
+		}
+
+		chain := strings.Join(fields, commandChainSeparator)
+
+		if isCommandChain(chain) {
+			_, err = startJob(chain)
+			if err != nil {
+				l.Println(err)
+			}
+			return
+		}
+	}
+
+	// connect commands with pipes and redirect their output
+	if isPipeline(line) {
+		err := runPipeline(line)
+		if err != nil {
+			l.Println(err)
+		}
+		return
+	}
+
 	switch line {
 	case exitCommand:
 		l.Println(cp.Text + "Bye." + cp.Reset)
 		clearProcessMap()
-		os.Exit(0)
+		exit(0)
 
 	case helpCommand:
 
@@ -142,9 +170,6 @@ func handleLine(line string) {
 	case infoCommand:
 		printProjectInfo()
 
-	case formatCommand:
-		f.formatCommand()
-
 	case "zeus": // prevent spawning a new interactive shell
 
 	case globalsCommand:
@@ -155,7 +180,7 @@ func handleLine(line string) {
 
 	case wikiCommand:
 		go StartWebListener(false)
-		open("http://" + hostName + ":" + strconv.Itoa(conf.fields.PortWebPanel) + "/wiki")
+		open(webURL("/wiki"))
 
 	case webCommand:
 		go StartWebListener(true)
@@ -178,6 +203,9 @@ func handleLine(line string) {
 	case builtinsCommand:
 		printBuiltins()
 
+	case jobsCommand:
+		printJobs()
+
 	default:
 
 		// split the input line
@@ -194,6 +222,8 @@ func handleLine(line string) {
 		switch commandName {
 		case makefileCommand:
 			handleMakefileCommand(args)
+		case importCommand:
+			handleImportCommand(args)
 		case configCommand:
 			handleConfigCommand(args)
 		case eventsCommand:
@@ -224,13 +254,41 @@ func handleLine(line string) {
 			handleTodoCommand(args)
 		case generateCommand:
 			handleGenerateCommand(args)
+		case formatCommand:
+			f.handleFormatCommand(args)
+		case upCommand:
+			handleUpCommand(args)
+		case fgCommand:
+			handleFgCommand(args)
+		case bgCommand:
+			handleBgCommand(args)
+		case waitCommand:
+			handleWaitCommand(args)
+		case validateCommand:
+			handleValidateCommand(args)
+		case schemaCommand:
+			handleSchemaCommand(args)
+		case graphCommand:
+			handleGraphCommand(args)
+		case historyCommand:
+			handleHistoryCommand(args)
+		case reportCommand:
+			handleReportCommand(args)
+		case daemonCommand:
+			handleDaemonCommand(args)
+		case dashboardCommand:
+			handleDashboardCommand(args)
 
 		default:
 			// check if its a commandchain
 			if strings.Contains(line, commandChainSeparator) {
-				fields := strings.Split(line, commandChainSeparator)
+				fields, err := expandChainAliases(line)
+				if err != nil {
+					l.Println(err)
+					return
+				}
 				if cmdChain, ok := validCommandChain(fields); ok {
-					cmdChain.exec(fields)
+					cmdChain.exec(newExecContext(), fields)
 				} else {
 					l.Println("invalid commandChain")
 				}
@@ -253,9 +311,13 @@ func handleLine(line string) {
 				if command, ok := projectData.fields.Aliases[commandName]; ok {
 
 					projectData.Unlock()
-					handleLine(command)
 
-					s.reset()
+					line, err := expandAlias(command, args)
+					if err != nil {
+						l.Println(commandName + ": " + err.Error())
+						return
+					}
+					handleLine(line)
 					return
 				}
 				projectData.Unlock()
@@ -273,19 +335,19 @@ func handleLine(line string) {
 			}
 			cmdMap.Unlock()
 
-			defer s.reset()
-			count, err := getTotalDependencyCount(cmd)
+			ctx := newExecContext()
+			count, err := getTotalDependencyCount(ctx.status, cmd)
 			if err != nil {
 				l.Println(err)
 				return
 			}
 
-			s.Lock()
-			s.numCommands = s.numCommands + count
-			s.Unlock()
+			ctx.status.Lock()
+			ctx.status.numCommands = count
+			ctx.status.Unlock()
 
 			// run the command
-			err = cmd.Run(args, cmd.async)
+			err = cmd.Run(ctx.withArgs(args), cmd.async)
 			if err != nil {
 				fmt.Printf("command "+cmd.name+" failed. error: %v\n", err)
 			}
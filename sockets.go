@@ -77,6 +77,17 @@ func (s *SocketStore) NumSockets() int {
 	return len(s.sockets)
 }
 
+// Broadcast writes the message to all socket connections
+func (s *SocketStore) Broadcast(msg string) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	for _, socket := range s.sockets {
+		socket.Write(msg)
+	}
+}
+
 // NewSocketStore constructs a new SocketStore
 func NewSocketStore() *SocketStore {
 	return &SocketStore{
@@ -98,14 +109,18 @@ func (s *SocketStore) Unlock() {
 // OnNewSocket is executed when a new glue client connects
 func (s *SocketStore) OnNewSocket(socket *glue.Socket) {
 
-	socket.OnRead(func(sessionID string) {
-		socket.Write("access granted")
+	socket.OnRead(func(data string) {
+		handleSocketMessage(socket, data)
 	})
 
 	// Set a function which is triggered as soon as the socket is closed.
 	socket.OnClose(func() {
 		s.RemoveSocket(socket)
+		streams.unsubscribe(socket)
 	})
 
 	s.AddSocket(socket)
+
+	// let the client know about the runs that it can subscribe to
+	socket.Write(runsMessage().String())
 }
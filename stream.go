@@ -0,0 +1,714 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"html"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/desertbit/glue"
+)
+
+var (
+	// output streams of the runs, for the web interface
+	streams = newStreamStore()
+)
+
+const (
+	// number of streams kept for replay, the oldest finished streams are removed first
+	maxStreams = 100
+
+	// size of the output that is kept for replay, older output is dropped
+	maxStreamOutput = 1024 * 1024
+
+	// prefix for the glue channel of a run
+	runChannelPrefix = "run:"
+)
+
+// ansiStyle is the current graphic rendition of the terminal output
+type ansiStyle struct {
+	bold      bool
+	faint     bool
+	italic    bool
+	underline bool
+
+	// class names or CSS colors, empty for the default color
+	fg string
+	bg string
+}
+
+// open a span for the style, returns an empty string for the default style
+func (s ansiStyle) open() string {
+
+	var (
+		classes []string
+		styles  []string
+	)
+
+	if s.bold {
+		classes = append(classes, "ansi-bold")
+	}
+	if s.faint {
+		classes = append(classes, "ansi-faint")
+	}
+	if s.italic {
+		classes = append(classes, "ansi-italic")
+	}
+	if s.underline {
+		classes = append(classes, "ansi-underline")
+	}
+	for _, c := range []struct {
+		value, prefix, property string
+	}{
+		{s.fg, "ansi-fg-", "color"},
+		{s.bg, "ansi-bg-", "background-color"},
+	} {
+		switch {
+		case c.value == "":
+		case strings.HasPrefix(c.value, "#"):
+			styles = append(styles, c.property+":"+c.value)
+		default:
+			classes = append(classes, c.prefix+c.value)
+		}
+	}
+
+	if len(classes) == 0 && len(styles) == 0 {
+		return ""
+	}
+
+	out := "<span"
+	if len(classes) > 0 {
+		out += ` class="` + strings.Join(classes, " ") + `"`
+	}
+	if len(styles) > 0 {
+		out += ` style="` + strings.Join(styles, ";") + `"`
+	}
+	return out + ">"
+}
+
+// ansiConverter converts terminal output with ANSI escape sequences to HTML
+// the style is kept across writes and incomplete sequences are buffered,
+// each converted chunk is a complete HTML fragment
+type ansiConverter struct {
+	style   ansiStyle
+	pending []byte
+}
+
+// convert a chunk of output
+func (a *ansiConverter) convert(p []byte) string {
+
+	data := append(a.pending, p...)
+	a.pending = nil
+
+	var (
+		out      strings.Builder
+		text     strings.Builder
+		open     = a.style.open()
+		spanOpen bool
+	)
+
+	// spans are only opened for text, so there are no empty spans
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+		if !spanOpen && open != "" {
+			out.WriteString(open)
+			spanOpen = true
+		}
+		out.WriteString(html.EscapeString(text.String()))
+		text.Reset()
+	}
+
+	for i := 0; i < len(data); {
+
+		b := data[i]
+
+		switch {
+		case b == 0x1b:
+			n, params, final, ok := parseEscape(data[i:])
+			if !ok {
+				// incomplete sequence, wait for the next chunk
+				a.pending = append([]byte{}, data[i:]...)
+				i = len(data)
+				continue
+			}
+			i += n
+
+			// only graphic rendition is converted, other sequences are dropped
+			if final == 'm' {
+				style := a.style.apply(params)
+				if style != a.style {
+					flush()
+					if spanOpen {
+						out.WriteString("</span>")
+						spanOpen = false
+					}
+					a.style = style
+					open = style.open()
+				}
+			}
+
+		case b == '\r':
+			i++
+
+		case b < utf8.RuneSelf:
+			text.WriteByte(b)
+			i++
+
+		default:
+			if !utf8.FullRune(data[i:]) {
+				a.pending = append([]byte{}, data[i:]...)
+				i = len(data)
+				continue
+			}
+			r, size := utf8.DecodeRune(data[i:])
+			text.WriteRune(r)
+			i += size
+		}
+	}
+
+	flush()
+	if spanOpen {
+		out.WriteString("</span>")
+	}
+
+	return out.String()
+}
+
+// parse an escape sequence at the start of data
+// returns the length, the parameters and the final byte of CSI sequences
+// ok is false if the sequence is incomplete
+func parseEscape(data []byte) (n int, params string, final byte, ok bool) {
+
+	if len(data) < 2 {
+		return 0, "", 0, false
+	}
+
+	switch data[1] {
+	case '[':
+		// control sequence: parameters and intermediate bytes, terminated by a byte in 0x40-0x7e
+		for i := 2; i < len(data); i++ {
+			if data[i] >= 0x40 && data[i] <= 0x7e {
+				return i + 1, string(data[2:i]), data[i], true
+			}
+		}
+		return 0, "", 0, false
+
+	case ']':
+		// operating system command: terminated by BEL or ESC \
+		for i := 2; i < len(data); i++ {
+			if data[i] == 0x07 {
+				return i + 1, "", 0, true
+			}
+			if data[i] == 0x1b && i+1 < len(data) && data[i+1] == '\\' {
+				return i + 2, "", 0, true
+			}
+		}
+		return 0, "", 0, false
+	}
+
+	// two byte sequence
+	return 2, "", 0, true
+}
+
+// apply SGR parameters to the style
+func (s ansiStyle) apply(params string) ansiStyle {
+
+	if params == "" {
+		return ansiStyle{}
+	}
+
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case code == 0:
+			s = ansiStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.faint = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 22:
+			s.bold = false
+			s.faint = false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code >= 30 && code <= 37:
+			s.fg = strconv.Itoa(code - 30)
+		case code >= 90 && code <= 97:
+			s.fg = strconv.Itoa(code - 90 + 8)
+		case code == 39:
+			s.fg = ""
+		case code >= 40 && code <= 47:
+			s.bg = strconv.Itoa(code - 40)
+		case code >= 100 && code <= 107:
+			s.bg = strconv.Itoa(code - 100 + 8)
+		case code == 49:
+			s.bg = ""
+		case code == 38 || code == 48:
+			color, n := extendedColor(codes[i+1:])
+			i += n
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+
+	return s
+}
+
+// parse a 256 color or true color parameter
+// returns the color and the number of consumed parameters
+func extendedColor(codes []string) (string, int) {
+
+	if len(codes) == 0 {
+		return "", 0
+	}
+
+	switch codes[0] {
+	case "5":
+		if len(codes) < 2 {
+			return "", len(codes)
+		}
+		n, err := strconv.Atoi(codes[1])
+		if err != nil || n < 0 || n > 255 {
+			return "", 2
+		}
+		if n < 16 {
+			return strconv.Itoa(n), 2
+		}
+		return xterm256Color(n), 2
+
+	case "2":
+		if len(codes) < 4 {
+			return "", len(codes)
+		}
+		var rgb [3]int
+		for i := range rgb {
+			v, err := strconv.Atoi(codes[i+1])
+			if err != nil || v < 0 || v > 255 {
+				return "", 4
+			}
+			rgb[i] = v
+		}
+		return hexColor(rgb[0], rgb[1], rgb[2]), 4
+	}
+
+	return "", 1
+}
+
+// CSS color for the xterm 256 color palette, starting at index 16
+func xterm256Color(n int) string {
+
+	// grayscale ramp
+	if n >= 232 {
+		v := 8 + (n-232)*10
+		return hexColor(v, v, v)
+	}
+
+	// 6x6x6 color cube
+	n -= 16
+	level := func(v int) int {
+		if v == 0 {
+			return 0
+		}
+		return 55 + v*40
+	}
+
+	return hexColor(level(n/36), level(n/6%6), level(n%6))
+}
+
+func hexColor(r, g, b int) string {
+	const digits = "0123456789abcdef"
+	out := []byte{'#'}
+	for _, v := range []int{r, g, b} {
+		out = append(out, digits[v>>4], digits[v&0x0f])
+	}
+	return string(out)
+}
+
+// outputStream broadcasts the output and the state transitions of a run
+// to the web interfaces that subscribed to it
+type outputStream struct {
+	id    string
+	chain string
+	state string
+	start time.Time
+
+	// converted output for the replay
+	chunks []string
+	size   int
+
+	converter   *ansiConverter
+	subscribers map[*glue.Socket]bool
+
+	// closed when the run has finished
+	done chan struct{}
+
+	sync.Mutex
+}
+
+// streamMessage is sent over the glue sockets
+type streamMessage struct {
+	Type    string `json:"type"`
+	ID      string `json:"id,omitempty"`
+	Chain   string `json:"chain,omitempty"`
+	State   string `json:"state,omitempty"`
+	Command string `json:"command,omitempty"`
+	HTML    string `json:"html,omitempty"`
+
+	Start *time.Time       `json:"start,omitempty"`
+	Runs  []*streamMessage `json:"runs,omitempty"`
+}
+
+func (m *streamMessage) String() string {
+	b, err := json.Marshal(m)
+	if err != nil {
+		Log.WithError(err).Error("failed to marshal stream message")
+		return ""
+	}
+	return string(b)
+}
+
+// summary of the stream, stream must be locked by the caller
+func (s *outputStream) summary() *streamMessage {
+	start := s.start
+	return &streamMessage{
+		Type:  "run",
+		ID:    s.id,
+		Chain: s.chain,
+		State: s.state,
+		Start: &start,
+	}
+}
+
+// send a message to all subscribers of the run, stream must be locked by the caller
+func (s *outputStream) send(m *streamMessage) {
+	msg := m.String()
+	for socket := range s.subscribers {
+		socket.Channel(runChannelPrefix + s.id).Write(msg)
+	}
+}
+
+// Write converts the output to HTML and sends it to the subscribers
+func (s *outputStream) Write(p []byte) (int, error) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	chunk := s.converter.convert(p)
+	if chunk == "" {
+		return len(p), nil
+	}
+
+	s.chunks = append(s.chunks, chunk)
+	s.size += len(chunk)
+	for s.size > maxStreamOutput && len(s.chunks) > 1 {
+		s.size -= len(s.chunks[0])
+		s.chunks = s.chunks[1:]
+	}
+
+	s.send(&streamMessage{
+		Type: "output",
+		HTML: chunk,
+	})
+
+	return len(p), nil
+}
+
+// send a state transition of a command to the subscribers
+func (s *outputStream) commandState(name, state string) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	s.send(&streamMessage{
+		Type:    "state",
+		Command: name,
+		State:   state,
+	})
+	s.Unlock()
+}
+
+// finish the stream and notify all web interfaces
+func (s *outputStream) finish(state string) {
+	if s == nil {
+		return
+	}
+
+	s.Lock()
+	s.state = state
+	summary := s.summary()
+	s.Unlock()
+
+	close(s.done)
+	broadcast(summary)
+}
+
+// subscribe a socket to the run and replay the output so far
+func (s *outputStream) subscribe(socket *glue.Socket) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.subscribers[socket] = true
+
+	replay := s.summary()
+	replay.Type = "replay"
+	replay.HTML = strings.Join(s.chunks, "")
+
+	socket.Channel(runChannelPrefix + s.id).Write(replay.String())
+}
+
+func (s *outputStream) unsubscribe(socket *glue.Socket) {
+	s.Lock()
+	delete(s.subscribers, socket)
+	s.Unlock()
+}
+
+// streamStore contains the output streams of the runs
+type streamStore struct {
+	items map[string]*outputStream
+	sync.Mutex
+}
+
+func newStreamStore() *streamStore {
+	return &streamStore{
+		items: make(map[string]*outputStream, 0),
+	}
+}
+
+// add a stream and remove the oldest finished streams if the store is full
+func (ss *streamStore) add(s *outputStream) {
+
+	ss.Lock()
+	defer ss.Unlock()
+
+	ss.items[s.id] = s
+
+	if len(ss.items) <= maxStreams {
+		return
+	}
+
+	var finished []*outputStream
+	for _, stream := range ss.items {
+		select {
+		case <-stream.done:
+			finished = append(finished, stream)
+		default:
+		}
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].start.Before(finished[j].start)
+	})
+
+	for i := 0; i < len(finished) && len(ss.items) > maxStreams; i++ {
+		delete(ss.items, finished[i].id)
+	}
+}
+
+func (ss *streamStore) get(id string) (*outputStream, bool) {
+	ss.Lock()
+	defer ss.Unlock()
+	s, ok := ss.items[id]
+	return s, ok
+}
+
+// all streams, oldest first
+func (ss *streamStore) list() []*outputStream {
+
+	ss.Lock()
+	var list []*outputStream
+	for _, s := range ss.items {
+		list = append(list, s)
+	}
+	ss.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].start.Before(list[j].start)
+	})
+
+	return list
+}
+
+// remove a closed socket from all streams
+func (ss *streamStore) unsubscribe(socket *glue.Socket) {
+	for _, s := range ss.list() {
+		s.unsubscribe(socket)
+	}
+}
+
+// send a message to all connected web interfaces
+func broadcast(m *streamMessage) {
+
+	socketstoreMutex.Lock()
+	store := socketstore
+	socketstoreMutex.Unlock()
+
+	if store != nil {
+		store.Broadcast(m.String())
+	}
+}
+
+// check if the web interface is running
+func webInterfaceActive() bool {
+	socketstoreMutex.Lock()
+	defer socketstoreMutex.Unlock()
+	return socketstore != nil
+}
+
+// startStream streams the output of the invocation to the web interface
+// returns a copy of the context that writes the output to the stream as well
+// the context is returned unchanged if the invocation is already streamed or the web interface is not running
+func (c *execContext) startStream(id, chain string) (*execContext, *outputStream) {
+
+	if c.stream != nil || !webInterfaceActive() {
+		return c, nil
+	}
+
+	s := &outputStream{
+		id:          id,
+		chain:       chain,
+		state:       stateRunning,
+		start:       time.Now(),
+		converter:   &ansiConverter{},
+		subscribers: make(map[*glue.Socket]bool, 0),
+		done:        make(chan struct{}),
+	}
+	streams.add(s)
+
+	child := *c
+	child.stream = s
+	child.stdout = io.MultiWriter(c.stdout, s)
+	child.stderr = io.MultiWriter(c.stderr, s)
+	child.out = log.New(io.MultiWriter(c.out.Writer(), s), c.out.Prefix(), c.out.Flags())
+
+	s.Lock()
+	summary := s.summary()
+	s.Unlock()
+	broadcast(summary)
+
+	return &child, s
+}
+
+// streamHook streams the output of an invocation and the states of its commands
+func streamHook(ctx *execContext, inv *invocation) (*execContext, *invocationObserver) {
+
+	ctx, s := ctx.startStream(randomString(), inv.line)
+	if ctx.stream == nil {
+		return ctx, nil
+	}
+
+	o := &invocationObserver{}
+	if inv.kind != invocationChain {
+		stream := ctx.stream
+		o.state = func(inv *invocation, state string) {
+			stream.commandState(inv.name, state)
+		}
+	}
+	if s != nil {
+		o.finished = func(inv *invocation) {
+			s.finish(inv.state)
+		}
+	}
+
+	return ctx, o
+}
+
+// final state of an invocation
+func finalRunState(ctx *execContext, err error) string {
+	switch {
+	case err == nil:
+		return stateFinished
+	case err == ErrCancelled || ctx.cancelled():
+		return runCancelled
+	default:
+		return stateFailed
+	}
+}
+
+// handle a message from a web interface
+// clients subscribe to the runs they display and receive the output so far
+func handleSocketMessage(socket *glue.Socket, data string) {
+
+	var m streamMessage
+	err := json.Unmarshal([]byte(data), &m)
+	if err != nil {
+		Log.Debug("ignoring invalid socket message: ", data)
+		return
+	}
+
+	switch m.Type {
+	case "subscribe":
+		s, ok := streams.get(m.ID)
+		if !ok {
+			socket.Channel(runChannelPrefix + m.ID).Write((&streamMessage{Type: "unknown", ID: m.ID}).String())
+			return
+		}
+		s.subscribe(socket)
+
+	case "unsubscribe":
+		if s, ok := streams.get(m.ID); ok {
+			s.unsubscribe(socket)
+		}
+
+	default:
+		Log.Debug("ignoring socket message: ", data)
+	}
+}
+
+// list of all runs, sent to new web interfaces
+func runsMessage() *streamMessage {
+
+	m := &streamMessage{
+		Type: "runs",
+		Runs: make([]*streamMessage, 0),
+	}
+
+	for _, s := range streams.list() {
+		s.Lock()
+		m.Runs = append(m.Runs, s.summary())
+		s.Unlock()
+	}
+
+	return m
+}
@@ -0,0 +1,501 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// span categories
+const (
+	spanCommand    = "command"
+	spanDependency = "dependency"
+	spanChain      = "chain"
+	spanEvent      = "event"
+)
+
+// span status
+const (
+	spanOK         = "ok"
+	spanError      = "error"
+	spanSkipped    = "skipped"
+	spanUnfinished = "unfinished"
+)
+
+var (
+	// tracer for the current session, nil if tracing is disabled
+	activeTracer      *tracer
+	activeTracerMutex = &sync.Mutex{}
+)
+
+// tracer records the spans of all invocations
+type tracer struct {
+
+	// output files, empty if the format is not written
+	chromePath string
+	otlpPath   string
+
+	traceID string
+	start   time.Time
+	spans   []*traceSpan
+	nextID  uint64
+	lanes   int
+
+	sync.Mutex
+}
+
+// traceSpan is a timed operation with an optional parent
+type traceSpan struct {
+	id       uint64
+	parent   uint64
+	name     string
+	category string
+
+	// concurrent spans are recorded on separate lanes
+	lane int
+
+	start time.Time
+	end   time.Time
+
+	args    map[string]interface{}
+	status  string
+	message string
+
+	tracer *tracer
+}
+
+// enable tracing for the session
+func startTrace(chromePath, otlpPath string) {
+
+	id := make([]byte, 16)
+	_, err := rand.Read(id)
+	if err != nil {
+		Log.WithError(err).Error("failed to generate trace id")
+	}
+
+	activeTracerMutex.Lock()
+	activeTracer = &tracer{
+		chromePath: chromePath,
+		otlpPath:   otlpPath,
+		traceID:    hex.EncodeToString(id),
+		start:      time.Now(),
+	}
+	activeTracerMutex.Unlock()
+}
+
+// get the tracer of the session, nil if tracing is disabled
+func getTracer() *tracer {
+	activeTracerMutex.Lock()
+	defer activeTracerMutex.Unlock()
+	return activeTracer
+}
+
+// write the trace files and disable tracing
+func flushTrace() {
+
+	activeTracerMutex.Lock()
+	t := activeTracer
+	activeTracer = nil
+	activeTracerMutex.Unlock()
+
+	if t == nil {
+		return
+	}
+
+	if t.chromePath != "" {
+		err := t.write(t.chromePath, t.chromeTrace())
+		if err != nil {
+			Log.WithError(err).Error("failed to write trace")
+		}
+	}
+
+	if t.otlpPath != "" {
+		err := t.write(t.otlpPath, t.otlpTrace())
+		if err != nil {
+			Log.WithError(err).Error("failed to write OTLP trace")
+		}
+	}
+}
+
+func (t *tracer) write(path string, v interface{}) error {
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// startSpan records a new span as child of the current span of the context
+// returns a copy of the context with the new span as current span
+// spans of detached commands are recorded on a new lane
+func (c *execContext) startSpan(name, category string, detach bool) (*execContext, *traceSpan) {
+
+	t := getTracer()
+	if t == nil {
+		return c, nil
+	}
+
+	t.Lock()
+	t.nextID++
+	s := &traceSpan{
+		id:       t.nextID,
+		name:     name,
+		category: category,
+		start:    time.Now(),
+		args:     make(map[string]interface{}, 0),
+		tracer:   t,
+	}
+	if c.span != nil {
+		s.parent = c.span.id
+		s.lane = c.span.lane
+	}
+	if c.span == nil || detach {
+		t.lanes++
+		s.lane = t.lanes
+	}
+	t.spans = append(t.spans, s)
+	t.Unlock()
+
+	child := *c
+	child.span = s
+
+	return &child, s
+}
+
+// set an argument of the span
+func (s *traceSpan) set(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.tracer.Lock()
+	s.args[key] = value
+	s.tracer.Unlock()
+}
+
+// mark the span as skipped
+func (s *traceSpan) skip(reason string) {
+	if s == nil {
+		return
+	}
+	s.tracer.Lock()
+	s.status = spanSkipped
+	s.message = reason
+	s.tracer.Unlock()
+}
+
+// finish the span, the status is determined by the error unless the span was skipped
+func (s *traceSpan) finish(err error) {
+	if s == nil {
+		return
+	}
+
+	s.tracer.Lock()
+	defer s.tracer.Unlock()
+
+	s.end = time.Now()
+	if err != nil {
+		s.status = spanError
+		s.message = err.Error()
+	} else if s.status == "" {
+		s.status = spanOK
+	}
+}
+
+// traceHook records a span for each invocation
+func traceHook(ctx *execContext, inv *invocation) (*execContext, *invocationObserver) {
+
+	category := spanCommand
+	switch inv.kind {
+	case invocationChain:
+		category = spanChain
+	case invocationDependency:
+		category = spanDependency
+	}
+
+	ctx, span := ctx.startSpan(inv.name, category, inv.detach)
+	if span == nil {
+		return ctx, nil
+	}
+
+	if inv.kind != invocationChain {
+		span.set("args", strings.Join(inv.args, " "))
+	}
+
+	return ctx, &invocationObserver{
+		finished: func(inv *invocation) {
+			if inv.skipped() {
+				span.skip(inv.skipReason)
+			}
+			span.finish(inv.err)
+		},
+	}
+}
+
+// copy of the spans, sorted by start time
+// spans that did not finish end now
+func (t *tracer) snapshot() []traceSpan {
+
+	t.Lock()
+	defer t.Unlock()
+
+	var (
+		now   = time.Now()
+		spans = make([]traceSpan, len(t.spans))
+	)
+	for i, s := range t.spans {
+		spans[i] = *s
+		spans[i].args = make(map[string]interface{}, len(s.args))
+		for k, v := range s.args {
+			spans[i].args[k] = v
+		}
+		if s.end.IsZero() {
+			spans[i].end = now
+			spans[i].status = spanUnfinished
+		}
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].start.Before(spans[j].start)
+	})
+
+	return spans
+}
+
+/*
+ *	Chrome trace event format
+ */
+
+type chromeTraceEvent struct {
+	Name string                 `json:"name"`
+	Cat  string                 `json:"cat,omitempty"`
+	Ph   string                 `json:"ph"`
+	Ts   int64                  `json:"ts"`
+	Dur  int64                  `json:"dur,omitempty"`
+	Pid  int                    `json:"pid"`
+	Tid  int                    `json:"tid"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+type chromeTraceFile struct {
+	TraceEvents     []*chromeTraceEvent `json:"traceEvents"`
+	DisplayTimeUnit string              `json:"displayTimeUnit"`
+	OtherData       map[string]string   `json:"otherData"`
+}
+
+// spans as complete events, each lane is a thread
+func (t *tracer) chromeTrace() *chromeTraceFile {
+
+	var (
+		f = &chromeTraceFile{
+			DisplayTimeUnit: "ms",
+			OtherData: map[string]string{
+				"version": version,
+				"traceID": t.traceID,
+			},
+		}
+		lanes = make(map[int]bool, 0)
+	)
+
+	f.TraceEvents = append(f.TraceEvents, &chromeTraceEvent{
+		Name: "process_name",
+		Ph:   "M",
+		Pid:  1,
+		Args: map[string]interface{}{"name": "zeus"},
+	})
+
+	for _, s := range t.snapshot() {
+
+		if !lanes[s.lane] {
+			lanes[s.lane] = true
+			f.TraceEvents = append(f.TraceEvents, &chromeTraceEvent{
+				Name: "thread_name",
+				Ph:   "M",
+				Pid:  1,
+				Tid:  s.lane,
+				Args: map[string]interface{}{"name": "invocation " + strconv.Itoa(s.lane)},
+			})
+		}
+
+		args := s.args
+		args["id"] = s.id
+		if s.parent != 0 {
+			args["parent"] = s.parent
+		}
+		args["status"] = s.status
+		switch s.status {
+		case spanError:
+			args["error"] = s.message
+		case spanSkipped:
+			args["reason"] = s.message
+		}
+
+		f.TraceEvents = append(f.TraceEvents, &chromeTraceEvent{
+			Name: s.name,
+			Cat:  s.category,
+			Ph:   "X",
+			Ts:   s.start.Sub(t.start).Nanoseconds() / 1000,
+			Dur:  s.end.Sub(s.start).Nanoseconds() / 1000,
+			Pid:  1,
+			Tid:  s.lane,
+			Args: args,
+		})
+	}
+
+	return f
+}
+
+/*
+ *	OTLP JSON
+ */
+
+type otlpTraceFile struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource struct {
+		Attributes []*otlpAttribute `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpScopeSpans struct {
+	Scope struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpSpan struct {
+	TraceID           string           `json:"traceId"`
+	SpanID            string           `json:"spanId"`
+	ParentSpanID      string           `json:"parentSpanId,omitempty"`
+	Name              string           `json:"name"`
+	Kind              int              `json:"kind"`
+	StartTimeUnixNano string           `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string           `json:"endTimeUnixNano"`
+	Attributes        []*otlpAttribute `json:"attributes"`
+	Status            struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	} `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+// OTLP status codes
+const (
+	otlpStatusUnset = 0
+	otlpStatusOK    = 1
+	otlpStatusError = 2
+)
+
+// internal span kind
+const otlpSpanKindInternal = 1
+
+// typed attribute value, 64 bit integers are encoded as strings
+func newOTLPAttribute(key string, value interface{}) *otlpAttribute {
+	switch v := value.(type) {
+	case int:
+		return &otlpAttribute{Key: key, Value: map[string]interface{}{"intValue": strconv.Itoa(v)}}
+	case bool:
+		return &otlpAttribute{Key: key, Value: map[string]interface{}{"boolValue": v}}
+	default:
+		return &otlpAttribute{Key: key, Value: map[string]interface{}{"stringValue": fmt.Sprint(v)}}
+	}
+}
+
+// spans in the OTLP JSON encoding, as accepted by the OTLP/HTTP trace endpoint
+func (t *tracer) otlpTrace() *otlpTraceFile {
+
+	var (
+		rs = &otlpResourceSpans{}
+		ss = &otlpScopeSpans{}
+	)
+
+	rs.Resource.Attributes = []*otlpAttribute{
+		newOTLPAttribute("service.name", "zeus"),
+		newOTLPAttribute("service.version", version),
+	}
+	ss.Scope.Name = "zeus"
+	ss.Scope.Version = version
+
+	for _, s := range t.snapshot() {
+
+		span := &otlpSpan{
+			TraceID:           t.traceID,
+			SpanID:            fmt.Sprintf("%016x", s.id),
+			Name:              s.name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		}
+		if s.parent != 0 {
+			span.ParentSpanID = fmt.Sprintf("%016x", s.parent)
+		}
+
+		span.Attributes = append(span.Attributes, newOTLPAttribute("zeus.category", s.category))
+
+		var keys []string
+		for k := range s.args {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			span.Attributes = append(span.Attributes, newOTLPAttribute("zeus."+k, s.args[k]))
+		}
+
+		switch s.status {
+		case spanOK:
+			span.Status.Code = otlpStatusOK
+		case spanError:
+			span.Status.Code = otlpStatusError
+			span.Status.Message = s.message
+		default:
+			span.Attributes = append(span.Attributes, newOTLPAttribute("zeus.status", s.status))
+			if s.message != "" {
+				span.Attributes = append(span.Attributes, newOTLPAttribute("zeus.reason", s.message))
+			}
+			span.Status.Code = otlpStatusUnset
+		}
+
+		ss.Spans = append(ss.Spans, span)
+	}
+
+	rs.ScopeSpans = []*otlpScopeSpans{ss}
+
+	return &otlpTraceFile{
+		ResourceSpans: []*otlpResourceSpans{rs},
+	}
+}
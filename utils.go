@@ -39,7 +39,6 @@ import (
 
 	yaml "gopkg.in/yaml.v2"
 
-	gosxnotifier "github.com/deckarep/gosx-notifier"
 	"github.com/mgutz/ansi"
 )
 
@@ -268,7 +267,7 @@ func extractLineNumFromError(errMsg, errLineNumberSymbol string) (int, error) {
 }
 
 // count total length of the commands dependencies
-func countDependencies(deps []string) (int, error) {
+func countDependencies(st *status, deps []string) (int, error) {
 
 	if len(deps) == 0 {
 		return 0, nil
@@ -288,14 +287,14 @@ func countDependencies(deps []string) (int, error) {
 			return 0, errors.New("invalid dependency: " + err.Error())
 		}
 
-		err = s.incrementRecursionCount(cmd.name)
+		err = st.incrementRecursionCount(cmd.name)
 		if err != nil {
 			return 0, err
 		}
 
 		count++
 		if len(cmd.dependencies) > 0 {
-			c, err := countDependencies(cmd.dependencies)
+			c, err := countDependencies(st, cmd.dependencies)
 			if err != nil {
 				return 0, err
 			}
@@ -305,8 +304,8 @@ func countDependencies(deps []string) (int, error) {
 	return count, nil
 }
 
-func getTotalDependencyCount(c *command) (int, error) {
-	count, err := countDependencies(c.dependencies)
+func getTotalDependencyCount(st *status, c *command) (int, error) {
+	count, err := countDependencies(st, c.dependencies)
 	return count + 1, err
 }
 
@@ -453,27 +452,11 @@ func validArgType(in string, k reflect.Kind) error {
 // display an OS notification
 func showNote(text, subtitle string) {
 
-	note := gosxnotifier.NewNotification(text)
-	note.Title = "ZEUS"
-	note.Subtitle = subtitle
-
-	// optionally, set a group which ensures only one notification is ever shown replacing previous notification of same group id
-	note.Group = "com.zeus"
-
-	// optionally, set a sender icon
-	note.Sender = "com.apple.Terminal"
-
-	// optionally, specify a url or bundleid to open should the notification be clicked
-	note.Link = "http://" + hostName + ":" + strconv.Itoa(conf.fields.PortWebPanel)
-
-	// optionally, an app icon
-	// note.AppIcon = "gopher.png"
-
-	// optionally, a content image
-	// note.ContentImage = "gopher.png"
-
-	err := note.Push()
-	if err != nil {
+	// the link must not contain the session token, the notification center keeps it
+	err := desktopNotify(subtitle, text, webPageURL("/"), false)
+	if err == ErrNotifySendMissing {
+		Log.Debug(err)
+	} else if err != nil {
 		Log.WithError(err).Error("error pushing notification")
 	}
 }
@@ -517,6 +500,15 @@ func printCompletions(previous string) {
 	case makefileCommand:
 		fmt.Println("migrate")
 		return
+	case importCommand:
+		fmt.Println(strings.Join(importFormatNames(), "\n"))
+		return
+	case graphCommand:
+		fmt.Println(strings.Join([]string{graphDOT, graphMermaid, graphJSON}, "\n"))
+		return
+	case historyCommand:
+		fmt.Println(strings.Join([]string{"show", "stats", "prune", "clear"}, "\n"))
+		return
 	}
 
 	// print builtins
@@ -534,10 +526,16 @@ func printCompletions(previous string) {
 		authorCommand,
 		builtinsCommand,
 		makefileCommand,
+		importCommand,
 		gitFilterCommand,
 		createCommand,
 		generateCommand,
 		editCommand,
+		upCommand,
+		validateCommand,
+		schemaCommand,
+		graphCommand,
+		historyCommand,
 	}
 
 	for _, name := range completions {
@@ -0,0 +1,684 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// ErrValidationFailed means the validate builtin found problems
+	ErrValidationFailed = errors.New("validation failed")
+
+	// ErrNoProjectData means the project data has not been loaded
+	ErrNoProjectData = errors.New("project data not loaded")
+)
+
+// key of a YAML mapping entry
+var yamlKeyExp = regexp.MustCompile(`^\s*([A-Za-z0-9_.-]+)\s*:(\s|$)`)
+
+// a problem found by the validate builtin
+type diagnostic struct {
+	file string
+
+	// line number starting at 1, 0 if unknown
+	line int
+
+	msg string
+}
+
+func (d *diagnostic) String() string {
+	if d.line > 0 {
+		return d.file + ":" + strconv.Itoa(d.line) + ": " + d.msg
+	}
+	return d.file + ": " + d.msg
+}
+
+// validator collects all problems of the zeus directory
+type validator struct {
+	diagnostics []*diagnostic
+}
+
+func (v *validator) add(file string, line int, msg string) {
+	v.diagnostics = append(v.diagnostics, &diagnostic{
+		file: file,
+		line: line,
+		msg:  msg,
+	})
+}
+
+// line numbers of the elements in the CommandsFile
+type commandsFileIndex struct {
+
+	// command name to line
+	commands map[string]int
+
+	// command name to field name to line
+	fields map[string]map[string]int
+
+	// command name to field name to the lines of the list items
+	items map[string]map[string][]int
+
+	// service group name to line
+	services map[string]int
+
+	// commands whose exec field is a block scalar
+	blocks map[string]bool
+}
+
+// handle validate shell command
+func handleValidateCommand(args []string) error {
+
+	if len(args) > 1 {
+		l.Println(ErrInvalidUsage)
+		l.Println("usage: validate")
+		return ErrInvalidUsage
+	}
+
+	v := &validator{}
+	err := v.validate()
+	if err != nil {
+		l.Println(err)
+		return err
+	}
+	v.sort()
+
+	for _, d := range v.diagnostics {
+		l.Println(cp.Prompt + d.String() + cp.Reset)
+	}
+
+	if len(v.diagnostics) > 0 {
+		l.Println(printPrompt() + strconv.Itoa(len(v.diagnostics)) + " problems found" + cp.Reset)
+		return ErrValidationFailed
+	}
+
+	l.Println(printPrompt() + "no problems found" + cp.Reset)
+	return nil
+}
+
+// sort the diagnostics by file and line
+func (v *validator) sort() {
+	sort.SliceStable(v.diagnostics, func(i, j int) bool {
+		if v.diagnostics[i].file != v.diagnostics[j].file {
+			return v.diagnostics[i].file < v.diagnostics[j].file
+		}
+		return v.diagnostics[i].line < v.diagnostics[j].line
+	})
+}
+
+// validate everything inside the zeus directory without running anything
+func (v *validator) validate() error {
+	v.validateFile(zeusDir+"/config.yml", "config", newConfig().fields)
+	v.validateFile(zeusDir+"/data.yml", "data", newData().fields)
+	v.validateScripts()
+	v.validateCommandsFile(commandsFilePath)
+	if err := v.validateAliases(); err != nil {
+		return err
+	}
+	v.validateNotifications()
+	return nil
+}
+
+// check a file against its schema and unmarshal it into out
+// returns the file contents if the file could be unmarshaled
+func (v *validator) validateFile(path, schema string, out interface{}) []byte {
+
+	c, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			v.add(path, 0, err.Error())
+		}
+		return nil
+	}
+
+	errs := schemas[schema].check(string(c))
+	for _, e := range errs {
+		v.add(path, e.line, e.msg)
+	}
+
+	err = yaml.Unmarshal(c, out)
+	if err != nil {
+		// type errors have already been reported by the schema
+		if len(errs) == 0 {
+			v.addYAMLErrors(path, err)
+		}
+		return nil
+	}
+
+	return c
+}
+
+// add all errors from unmarshaling YAML with their line numbers
+func (v *validator) addYAMLErrors(path string, err error) {
+
+	if err == nil {
+		return
+	}
+
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		for _, e := range typeErr.Errors {
+			i, lineErr := extractLineNumFromError(e, "line")
+			if lineErr != nil {
+				i = 0
+			}
+			v.add(path, i, strings.TrimSpace(regexp.MustCompile(`^line [0-9]+: `).ReplaceAllString(e, "")))
+		}
+		return
+	}
+
+	i, lineErr := extractLineNumFromError(err.Error(), "line")
+	if lineErr != nil {
+		i = 0
+	}
+	v.add(path, i, err.Error())
+}
+
+// check the syntax of all scripts in the script directory
+func (v *validator) validateScripts() {
+
+	files, err := ioutil.ReadDir(scriptDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			v.add(scriptDir, 0, err.Error())
+		}
+		return
+	}
+
+	for _, file := range files {
+
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(scriptDir, file.Name())
+
+		lang, err := languageForFile(path)
+		if err != nil {
+			v.add(path, 0, err.Error())
+			continue
+		}
+
+		c, err := ioutil.ReadFile(path)
+		if err != nil {
+			v.add(path, 0, err.Error())
+			continue
+		}
+
+		line, msg := checkSyntax(lang, string(c))
+		if msg != "" {
+			v.add(path, line, msg)
+		}
+	}
+}
+
+// validate the CommandsFile
+func (v *validator) validateCommandsFile(path string) {
+
+	commandsFile := newCommandsFile()
+
+	c := v.validateFile(path, "commands", commandsFile)
+	if c == nil {
+		return
+	}
+
+	index := indexCommandsFile(string(c))
+
+	if _, err := ls.getLang(commandsFile.Language); err != nil {
+		v.add(path, 0, err.Error()+": "+commandsFile.Language)
+	}
+
+	var (
+		names []string
+		args  = make(map[string]map[string]*commandArg, 0)
+	)
+	for name := range commandsFile.Commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// argument declarations
+	for _, name := range names {
+
+		d := commandsFile.Commands[name]
+		if d == nil {
+			d = &commandData{}
+			commandsFile.Commands[name] = d
+		}
+
+		parsed, err := parseArgumentDeclarations(d.Arguments)
+		if err != nil {
+			v.add(path, index.fieldLine(name, "arguments"), name+": "+err.Error())
+			continue
+		}
+		args[name] = parsed
+
+		// argument / global name clashes
+		for i, decl := range d.Arguments {
+			argName := strings.TrimSpace(strings.Split(decl, ":")[0])
+			if _, ok := commandsFile.Globals[argName]; ok {
+				v.add(path, index.itemLine(name, "arguments", i), name+": argument "+argName+" conflicts with a global variable")
+			}
+		}
+	}
+
+	for _, name := range names {
+
+		d := commandsFile.Commands[name]
+		line := index.commands[name]
+
+		// builtin name conflicts
+		if _, ok := builtins[name]; ok {
+			v.add(path, line, "command "+name+" conflicts with a builtin")
+		}
+
+		if d.Path != "" && d.Exec != "" {
+			v.add(path, index.fieldLine(name, "path"), name+": custom path set, but specifies an exec action")
+		}
+		if d.Path != "" {
+			if _, err := os.Stat(d.Path); err != nil {
+				v.add(path, index.fieldLine(name, "path"), name+": "+err.Error())
+			}
+		}
+
+		// dependency references
+		for i, dep := range d.Dependencies {
+
+			depLine := index.itemLine(name, "dependencies", i)
+
+			fields := strings.Fields(dep)
+			if len(fields) == 0 {
+				v.add(path, depLine, name+": "+ErrEmptyDependency.Error())
+				continue
+			}
+
+			depArgs, ok := args[fields[0]]
+			if !ok {
+				if _, inFile := commandsFile.Commands[fields[0]]; inFile {
+					// argument declarations of the dependency are invalid, already reported
+					continue
+				}
+				cmd, err := cmdMap.getCommand(fields[0])
+				if err != nil {
+					v.add(path, depLine, name+": unknown command in dependency: "+fields[0])
+					continue
+				}
+				depArgs = cmd.args
+			}
+
+			err := validateCommandArgs(&command{name: fields[0], args: depArgs}, fields[1:], false)
+			if err != nil {
+				v.add(path, depLine, name+": dependency "+fields[0]+": "+err.Error())
+			}
+		}
+
+		// syntax of the exec block
+		if d.Exec != "" {
+
+			langName := commandsFile.Language
+			if d.Language != "" {
+				langName = d.Language
+			}
+
+			lang, err := ls.getLang(langName)
+			if err != nil {
+				v.add(path, index.fieldLine(name, "language"), name+": "+err.Error()+": "+langName)
+				continue
+			}
+
+			i, msg := checkSyntax(lang, d.Exec)
+			if msg != "" {
+				execLine := index.fieldLine(name, "exec")
+				if execLine > 0 && i > 0 {
+					// block scalars start in the line after the exec field
+					if index.blocks[name] {
+						execLine += i
+					} else {
+						execLine += i - 1
+					}
+				}
+				v.add(path, execLine, name+": "+msg)
+			}
+		}
+	}
+
+	v.findCycles(path, commandsFile, index, names)
+
+	// service groups
+	var groups []string
+	for group := range commandsFile.Services {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		entries := commandsFile.Services[group]
+		if len(entries) == 0 {
+			v.add(path, index.services[group], "service group "+group+" is empty")
+		}
+		for _, entry := range entries {
+			fields := strings.Fields(entry)
+			if len(fields) == 0 {
+				v.add(path, index.services[group], "service group "+group+" contains an empty entry")
+				continue
+			}
+			d, ok := commandsFile.Commands[fields[0]]
+			if !ok {
+				v.add(path, index.services[group], "service group "+group+": unknown command: "+fields[0])
+				continue
+			}
+			if d != nil && d.Async {
+				v.add(path, index.services[group], "service group "+group+": "+ErrAsyncService.Error()+": "+fields[0])
+			}
+		}
+	}
+}
+
+// find cycles in the dependencies of the CommandsFile
+func (v *validator) findCycles(path string, commandsFile *CommandsFile, index *commandsFileIndex, names []string) {
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		state    = make(map[string]int, 0)
+		stack    []string
+		reported = make(map[string]bool, 0)
+		visit    func(name string)
+	)
+
+	visit = func(name string) {
+
+		state[name] = visiting
+		stack = append(stack, name)
+
+		d := commandsFile.Commands[name]
+		if d != nil {
+			for _, dep := range d.Dependencies {
+
+				fields := strings.Fields(dep)
+				if len(fields) == 0 {
+					continue
+				}
+
+				if _, ok := commandsFile.Commands[fields[0]]; !ok {
+					continue
+				}
+
+				switch state[fields[0]] {
+				case visiting:
+					// assemble the cycle from the stack
+					var cycle []string
+					for i := len(stack) - 1; i >= 0; i-- {
+						cycle = append([]string{stack[i]}, cycle...)
+						if stack[i] == fields[0] {
+							break
+						}
+					}
+
+					// report each cycle only once
+					key := cycleKey(cycle)
+					if !reported[key] {
+						reported[key] = true
+						v.add(path, index.commands[cycle[0]], "dependency cycle: "+strings.Join(append(cycle, fields[0]), " "+commandChainSeparator+" "))
+					}
+				case unvisited:
+					visit(fields[0])
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+}
+
+// identify a cycle independent of its starting point
+func cycleKey(cycle []string) string {
+	sorted := append([]string{}, cycle...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// check the aliases from the project data
+func (v *validator) validateAliases() error {
+
+	if projectData == nil {
+		return ErrNoProjectData
+	}
+
+	projectData.Lock()
+	aliases := make(map[string]string, len(projectData.fields.Aliases))
+	for name, command := range projectData.fields.Aliases {
+		aliases[name] = command
+	}
+	projectData.Unlock()
+
+	var names []string
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	path := zeusDir + "/data.yml"
+	for _, name := range names {
+		if _, ok := builtins[name]; ok {
+			v.add(path, 0, "alias "+name+" conflicts with a builtin")
+		}
+		if _, err := cmdMap.getCommand(name); err == nil {
+			v.add(path, 0, "alias "+name+" conflicts with a command")
+		}
+		if err := validateAliasCommand(aliases[name]); err != nil {
+			v.add(path, 0, "alias "+name+": "+err.Error())
+		}
+	}
+
+	return nil
+}
+
+// check the notification sinks of the config
+func (v *validator) validateNotifications() {
+
+	conf.Lock()
+	sinks := make([]*notificationSink, len(conf.fields.Notifications))
+	copy(sinks, conf.fields.Notifications)
+	conf.Unlock()
+
+	path := zeusDir + "/config.yml"
+	for i, s := range sinks {
+		if s == nil {
+			continue
+		}
+		prefix := "notification " + strconv.Itoa(i+1) + ": "
+		if err := s.check(); err != nil {
+			v.add(path, 0, prefix+err.Error())
+		}
+		for _, name := range s.Commands {
+			if _, err := cmdMap.getCommand(name); err != nil {
+				v.add(path, 0, prefix+"unknown command "+name)
+			}
+		}
+	}
+}
+
+// build the line index for the CommandsFile
+func indexCommandsFile(contents string) *commandsFileIndex {
+
+	var (
+		index = &commandsFileIndex{
+			commands: make(map[string]int, 0),
+			fields:   make(map[string]map[string]int, 0),
+			items:    make(map[string]map[string][]int, 0),
+			services: make(map[string]int, 0),
+			blocks:   make(map[string]bool, 0),
+		}
+		section      string
+		nameIndent   = -1
+		fieldIndent  = -1
+		currentName  string
+		currentField string
+	)
+
+	for i, line := range strings.Split(contents, "\n") {
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		indent := countLeadingSpace(line)
+
+		// top level keys
+		if indent == 0 {
+			section = yamlKey(line)
+			nameIndent, fieldIndent = -1, -1
+			continue
+		}
+
+		if nameIndent == -1 {
+			nameIndent = indent
+		}
+
+		key := yamlKey(line)
+
+		switch section {
+		case "services":
+			if indent == nameIndent && key != "" {
+				index.services[key] = i + 1
+			}
+		case "commands":
+			switch {
+			case indent == nameIndent && key != "":
+				currentName = key
+				currentField = ""
+				index.commands[key] = i + 1
+				index.fields[key] = make(map[string]int, 0)
+				index.items[key] = make(map[string][]int, 0)
+			case currentName == "":
+			case fieldIndent == -1 || indent == fieldIndent:
+				if key == "" {
+					continue
+				}
+				fieldIndent = indent
+				currentField = key
+				index.fields[currentName][key] = i + 1
+				if key == "exec" {
+					index.blocks[currentName] = isBlockScalar(strings.TrimSpace(strings.SplitN(trimmed, ":", 2)[1]))
+				}
+			case strings.HasPrefix(trimmed, "- ") || trimmed == "-":
+				index.items[currentName][currentField] = append(index.items[currentName][currentField], i+1)
+			}
+		}
+	}
+
+	return index
+}
+
+// extract the key of a YAML mapping entry
+func yamlKey(line string) string {
+	if m := yamlKeyExp.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// line of a field of a command, falls back to the line of the command
+func (index *commandsFileIndex) fieldLine(name, field string) int {
+	if line, ok := index.fields[name][field]; ok {
+		return line
+	}
+	return index.commands[name]
+}
+
+// line of a list item of a command field, falls back to the line of the field
+func (index *commandsFileIndex) itemLine(name, field string, i int) int {
+	if items := index.items[name][field]; i < len(items) {
+		return items[i]
+	}
+	return index.fieldLine(name, field)
+}
+
+// check the syntax of a script with the syntax check command of its language
+// returns the line of the first error and the error message
+// if the syntax check command is not available, nothing will be reported
+func checkSyntax(lang *Language, code string) (int, string) {
+
+	fields := strings.Fields(lang.SyntaxCheck)
+	if len(fields) == 0 {
+		return 0, ""
+	}
+
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		Log.Debug("syntax check for " + lang.Name + " not available: " + fields[0])
+		return 0, ""
+	}
+
+	dir, err := ioutil.TempDir("", "zeus-validate")
+	if err != nil {
+		return 0, err.Error()
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "script"+lang.FileExtension)
+	err = ioutil.WriteFile(path, []byte(code), 0600)
+	if err != nil {
+		return 0, err.Error()
+	}
+
+	out, err := exec.Command(fields[0], append(fields[1:], path)...).CombinedOutput()
+	if err == nil {
+		return 0, ""
+	}
+
+	msg := strings.TrimSpace(string(out))
+	if msg == "" {
+		msg = err.Error()
+	}
+
+	var line int
+	if m := regexp.MustCompile(regexp.QuoteMeta(path) + `"?(?:, line |: line |:)([0-9]+)`).FindStringSubmatch(msg); m != nil {
+		line, _ = strconv.Atoi(m[1])
+	}
+
+	// remove the temporary path from the message
+	msg = strings.Replace(msg, path, "script", -1)
+	if i := strings.Index(msg, "\n"); i > 0 && line > 0 {
+		msg = msg[:i]
+	}
+
+	return line, strings.TrimSpace(msg)
+}
@@ -0,0 +1,477 @@
+/*
+ *  ZEUS - An Electrifying Build System
+ *  Copyright (c) 2017 Philipp Mieden <dreadl0ck [at] protonmail [dot] ch>
+ *
+ *  This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnauthorized means the request did not contain the session token
+	ErrUnauthorized = errors.New("unauthorized: open the link that was printed in the terminal or pass the session token")
+
+	// ErrInvalidCSRFToken means a state changing request was not sent by the web panel
+	ErrInvalidCSRFToken = errors.New("invalid or missing CSRF token")
+
+	// access token for the webinterface, a new one is generated for every session
+	sessionToken = newToken()
+
+	// token that the web panel sends along with state changing requests
+	csrfToken = newToken()
+)
+
+const (
+	tokenParam  = "token"
+	tokenCookie = "zeus-token"
+	csrfCookie  = "zeus-csrf"
+	csrfHeader  = "X-CSRF-Token"
+
+	// the glue ajax transport can't send custom headers, its requests are checked by origin only
+	glueAjaxPath = "/glue/ajax"
+
+	// validity of the generated certificate
+	certificateValidity = 365 * 24 * time.Hour
+)
+
+// how a request was authenticated
+type authMethod int
+
+const (
+	authNone authMethod = iota
+
+	// Authorization: Bearer <token>, used by API clients
+	authHeader
+
+	// session cookie, set by the browser
+	authCookie
+
+	// token query parameter, used by the link printed in the terminal
+	authQuery
+)
+
+// generate a 32 byte random token
+func newToken() string {
+
+	b := make([]byte, 32)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		Log.WithError(err).Fatal(ErrReadingRandomString)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// compare tokens in constant time
+func tokenEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// check the session token of a request
+func authenticate(r *http.Request) authMethod {
+
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		if tokenEqual(strings.TrimPrefix(auth, "Bearer "), sessionToken) {
+			return authHeader
+		}
+		return authNone
+	}
+
+	if tokenEqual(r.URL.Query().Get(tokenParam), sessionToken) {
+		return authQuery
+	}
+
+	if c, err := r.Cookie(tokenCookie); err == nil && tokenEqual(c.Value, sessionToken) {
+		return authCookie
+	}
+
+	return authNone
+}
+
+// check the metrics token of a request, metrics require the session token if no token is configured
+func metricsAuthorized(r *http.Request) bool {
+
+	conf.Lock()
+	token := conf.fields.MetricsToken
+	conf.Unlock()
+
+	auth := r.Header.Get("Authorization")
+	return token != "" && strings.HasPrefix(auth, "Bearer ") && tokenEqual(strings.TrimPrefix(auth, "Bearer "), token)
+}
+
+// check if the request modifies state
+func stateChanging(r *http.Request) bool {
+	switch r.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return false
+	default:
+		return true
+	}
+}
+
+// check if the request was sent by a page served from the same origin
+// browsers set the Origin header for POST and DELETE requests, the Referer is used as fallback
+func sameOrigin(r *http.Request) bool {
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		origin = r.Header.Get("Referer")
+	}
+	if origin == "" {
+		return false
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return u.Host == r.Host
+}
+
+// check the CSRF protection of a state changing request from a browser
+func validCSRF(r *http.Request) bool {
+
+	if !sameOrigin(r) {
+		return false
+	}
+
+	if r.URL.Path == glueAjaxPath {
+		return true
+	}
+
+	return tokenEqual(r.Header.Get(csrfHeader), csrfToken)
+}
+
+// set the session cookies for the web panel
+// the CSRF cookie is read by the web panel and sent back as header
+func setSessionCookies(w http.ResponseWriter) {
+
+	secure := webTLSEnabled()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     tokenCookie,
+		Value:    sessionToken,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookie,
+		Value:    csrfToken,
+		Path:     "/",
+		Secure:   secure,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// reply with an error, as JSON for the API
+func authError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		writeJSONError(w, status, err)
+		return
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// secureHandler requires the session token for all requests
+// browsers that open the link with the token receive it as cookie,
+// state changing requests from browsers must pass the CSRF check
+func secureHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		// scrapers authenticate with the token from the config
+		if r.URL.Path == metricsPath && r.Method == "GET" && metricsAuthorized(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		method := authenticate(r)
+		switch method {
+		case authNone:
+			authError(w, r, http.StatusUnauthorized, ErrUnauthorized)
+			return
+
+		case authQuery:
+			setSessionCookies(w)
+
+			// remove the token from the address bar and the browser history
+			if r.Method == "GET" {
+				u := *r.URL
+				q := u.Query()
+				q.Del(tokenParam)
+				u.RawQuery = q.Encode()
+				http.Redirect(w, r, u.RequestURI(), http.StatusFound)
+				return
+			}
+		}
+
+		// the Authorization header is never sent by browsers on their own
+		if method != authHeader && stateChanging(r) && !validCSRF(r) {
+			authError(w, r, http.StatusForbidden, ErrInvalidCSRFToken)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+/*
+ *	Listener
+ */
+
+func webTLSEnabled() bool {
+	conf.Lock()
+	defer conf.Unlock()
+	return conf.fields.WebTLS
+}
+
+// address the web server listens on
+func listenAddress() string {
+	conf.Lock()
+	defer conf.Unlock()
+	return net.JoinHostPort(conf.fields.WebBindAddress, strconv.Itoa(conf.fields.PortWebPanel))
+}
+
+// check if the bind address exposes the web server to other machines
+func exposedBindAddress(addr string) bool {
+
+	if addr == "localhost" {
+		return false
+	}
+
+	ip := net.ParseIP(addr)
+	return ip == nil || !ip.IsLoopback()
+}
+
+// host name used in links to the webinterface
+func webHost() string {
+
+	conf.Lock()
+	addr := conf.fields.WebBindAddress
+	conf.Unlock()
+
+	ip := net.ParseIP(addr)
+	if addr == "" || ip != nil && (ip.IsLoopback() || ip.IsUnspecified()) {
+		return hostName
+	}
+
+	return addr
+}
+
+// webURL returns the link to a page of the webinterface, including the session token
+func webURL(path string) string {
+	return webPageURL(path) + "?" + tokenParam + "=" + sessionToken
+}
+
+// webPageURL returns the link to a page of the webinterface without the session token
+// used for links that are stored outside of zeus, like notifications
+func webPageURL(path string) string {
+
+	scheme := "http"
+	if webTLSEnabled() {
+		scheme = "https"
+	}
+
+	conf.Lock()
+	port := strconv.Itoa(conf.fields.PortWebPanel)
+	conf.Unlock()
+
+	return scheme + "://" + net.JoinHostPort(webHost(), port) + path
+}
+
+/*
+ *	TLS
+ */
+
+// path for the generated certificate and key
+func tlsDir() string {
+	return filepath.Join(zeusDir, "tls")
+}
+
+// load the certificate from the directory, or generate a self-signed certificate
+// an expired certificate or one that does not cover the hosts is replaced
+// returns the certificate and its SHA-256 fingerprint
+func loadOrCreateCertificate(dir string, hosts []string) (tls.Certificate, string, error) {
+
+	var (
+		certPath = filepath.Join(dir, "cert.pem")
+		keyPath  = filepath.Join(dir, "key.pem")
+	)
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err == nil {
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err == nil && certificateUsable(leaf, hosts) {
+			return cert, fingerprint(leaf.Raw), nil
+		}
+	}
+
+	certPEM, keyPEM, err := generateCertificate(hosts)
+	if err != nil {
+		return tls.Certificate{}, "", err
+	}
+
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return tls.Certificate{}, "", err
+	}
+
+	err = ioutil.WriteFile(keyPath, keyPEM, 0600)
+	if err != nil {
+		return tls.Certificate{}, "", err
+	}
+
+	err = ioutil.WriteFile(certPath, certPEM, 0644)
+	if err != nil {
+		return tls.Certificate{}, "", err
+	}
+
+	cert, err = tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, "", err
+	}
+
+	return cert, fingerprint(cert.Certificate[0]), nil
+}
+
+// check if the certificate is valid for at least another day and covers all hosts
+func certificateUsable(c *x509.Certificate, hosts []string) bool {
+
+	if time.Now().Add(24 * time.Hour).After(c.NotAfter) {
+		return false
+	}
+
+	for _, h := range hosts {
+		if c.VerifyHostname(h) != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// generate a self-signed certificate for the hosts
+func generateCertificate(hosts []string) (certPEM, keyPEM []byte, err error) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"ZEUS"}, CommonName: hosts[0]},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(certificateValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM, nil
+}
+
+// hosts the certificate is issued for
+func certificateHosts() []string {
+
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+
+	if name, err := os.Hostname(); err == nil && name != "" {
+		hosts = append(hosts, name)
+	}
+
+	conf.Lock()
+	addr := conf.fields.WebBindAddress
+	conf.Unlock()
+
+	ip := net.ParseIP(addr)
+	if addr == "" || ip != nil && ip.IsUnspecified() {
+		return hosts
+	}
+
+	for _, h := range hosts {
+		if h == addr {
+			return hosts
+		}
+	}
+
+	return append(hosts, addr)
+}
+
+// SHA-256 fingerprint of a certificate, in the format used by browsers
+func fingerprint(der []byte) string {
+
+	sum := sha256.Sum256(der)
+
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+
+	return strings.Join(parts, ":")
+}
@@ -19,30 +19,155 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"html/template"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 
 	"github.com/russross/blackfriday"
 )
 
-// serve wiki index page
-var wikiIndexHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+var (
+	// path for the wiki
+	wikiDir = "wiki"
 
-	if r.Method != "GET" {
-		http.Error(w, "method not allowed, only GET here", http.StatusMethodNotAllowed)
-		return
+	// search index for all wiki pages
+	wikiSearch = newWikiSearchIndex()
+
+	// ErrInvalidWikiPage means the page name is not a file in the wiki
+	ErrInvalidWikiPage = errors.New("invalid wiki page")
+
+	// ErrReadOnlyWikiPage means the page is generated or not markdown
+	ErrReadOnlyWikiPage = errors.New("wiki page can not be edited")
+)
+
+const (
+	// name of the wiki index page
+	wikiIndexPage = "INDEX.md"
+
+	// name of the generated command reference
+	wikiCommandsPage = "commands"
+
+	// length of the text around a match in search results
+	wikiSnippetLength = 160
+)
+
+// data for the wiki page template
+type wikiPageData struct {
+	Content  template.HTML
+	Page     string
+	Editable bool
+}
+
+// get the file for a page of the wiki
+// pages are the index or a file in the docs folder, paths that leave the wiki are rejected
+func wikiFile(page string) (string, error) {
+
+	var path string
+	if page == wikiIndexPage {
+		path = filepath.Join(wikiDir, wikiIndexPage)
+	} else {
+		name := strings.TrimPrefix(page, "docs/")
+		if name == page || name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\\x00") {
+			return "", errors.New(ErrInvalidWikiPage.Error() + ": " + page)
+		}
+		path = filepath.Join(wikiDir, "docs", name)
 	}
 
-	w.Header().Set("Content-Type", "text/html")
+	// symlinks must not point outside of the wiki either
+	root, err := filepath.EvalSymlinks(wikiDir)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err == nil && !strings.HasPrefix(resolved, root+string(filepath.Separator)) {
+		return "", errors.New(ErrInvalidWikiPage.Error() + ": " + page)
+	}
+
+	return path, nil
+}
 
-	index, err := ioutil.ReadFile("wiki/INDEX.md")
+// check if a page can be edited in the browser
+func wikiEditable(page string) bool {
+	return page != wikiCommandsPage && strings.HasSuffix(page, ".md")
+}
+
+// URL of a wiki page
+func wikiURL(page string) string {
+	switch page {
+	case wikiIndexPage:
+		return "/wiki"
+	case wikiCommandsPage:
+		return "/wiki/commands"
+	default:
+		return "/wiki/" + page
+	}
+}
+
+// read the markdown of a page
+func readWikiPage(page string) ([]byte, error) {
+
+	if page == wikiCommandsPage {
+		return []byte(commandsMarkdown()), nil
+	}
+
+	path, err := wikiFile(page)
 	if err != nil {
-		Log.WithError(err).Error("failed to read wiki index markdown")
-		return
+		return nil, err
+	}
+
+	return ioutil.ReadFile(path)
+}
+
+// save the markdown of a page, new pages are created
+func writeWikiPage(page string, markdown []byte) error {
+
+	if !wikiEditable(page) {
+		return errors.New(ErrReadOnlyWikiPage.Error() + ": " + page)
+	}
+
+	path, err := wikiFile(page)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(path, markdown, 0644)
+	if err != nil {
+		return err
 	}
 
+	wikiSearch.remove(page)
+
+	return nil
+}
+
+// render markdown to HTML
+// pages can be edited in the browser, so raw HTML is skipped and links are restricted to safe protocols
+func renderMarkdown(markdown []byte) template.HTML {
+
+	r := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.CommonHTMLFlags | blackfriday.SkipHTML | blackfriday.Safelink,
+	})
+
+	return template.HTML(blackfriday.Run(markdown, blackfriday.WithRenderer(r)))
+}
+
+// render markdown with the wiki template
+func renderWikiPage(w http.ResponseWriter, page string, markdown []byte) {
+
 	tpl, err := assetBox.String("wiki_index.html")
 	if err != nil {
 		Log.WithError(err).Error("failed to read wiki index HTML")
@@ -55,24 +180,61 @@ var wikiIndexHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	err = t.Execute(w, template.HTML(blackfriday.Run(index)))
+	w.Header().Set("Content-Type", "text/html")
+
+	err = t.Execute(w, &wikiPageData{
+		Content:  renderMarkdown(markdown),
+		Page:     page,
+		Editable: wikiEditable(page),
+	})
 	if err != nil {
 		Log.WithError(err).Error("failed to exec template")
 		return
 	}
+}
+
+// serve wiki index page
+var wikiIndexHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != "GET" {
+		http.Error(w, "method not allowed, only GET here", http.StatusMethodNotAllowed)
+		return
+	}
+
+	index, err := readWikiPage(wikiIndexPage)
+	if err != nil {
+		Log.WithError(err).Error("failed to read wiki index markdown")
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	renderWikiPage(w, wikiIndexPage, index)
+})
+
+// serve the generated command reference
+var wikiCommandsHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	renderWikiPage(w, wikiCommandsPage, []byte(commandsMarkdown()))
 })
 
 // serve wiki documents
 var wikiDocsHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
-	fileName := strings.TrimPrefix(r.RequestURI, "/")
+	page := "docs/" + strings.TrimPrefix(r.URL.Path, "/wiki/docs/")
+
+	Log.Debug("wikiDocsHandler: ", page)
 
-	Log.Debug("wikiDocsHandler: ", fileName)
+	fileName, err := wikiFile(page)
+	if err != nil {
+		Log.WithError(err).Error("invalid wiki path")
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
 
 	if strings.HasSuffix(fileName, ".html") {
 		tpl, err := ioutil.ReadFile(fileName)
 		if err != nil {
 			Log.WithError(err).Error("failed to read wiki HTML file: ", fileName)
+			http.Error(w, "Not found", http.StatusNotFound)
 			return
 		}
 		t, err := template.New("wiki").Parse(string(tpl))
@@ -117,26 +279,428 @@ var wikiDocsHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/html")
+	renderWikiPage(w, page, b)
+})
 
-	// get template
-	tpl, err := assetBox.String("wiki_index.html")
-	if err != nil {
-		Log.WithError(err).Error("failed to read wiki index HTML")
+/*
+ *	Command Reference
+ */
+
+// generate the markdown reference for all commands
+// it is generated for every request, so it reflects the current commands
+func commandsMarkdown() string {
+
+	var b strings.Builder
+
+	b.WriteString("# Commands\n\n")
+	b.WriteString("This page is generated from the commands of the project.\n\n")
+
+	cmdMap.Lock()
+	defer cmdMap.Unlock()
+
+	var names []string
+	for name := range cmdMap.items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+
+		c := cmdMap.items[name]
+
+		b.WriteString("## " + c.name + "\n\n")
+
+		if c.description != "" {
+			b.WriteString(c.description + "\n\n")
+		}
+
+		if len(c.args) > 0 {
+			b.WriteString("| Argument | Type | Optional | Default |\n")
+			b.WriteString("| -------- | ---- | -------- | ------- |\n")
+
+			var labels []string
+			for label := range c.args {
+				labels = append(labels, label)
+			}
+			sort.Strings(labels)
+
+			for _, label := range labels {
+				arg := c.args[label]
+				optional := "no"
+				if arg.optional {
+					optional = "yes"
+				}
+				b.WriteString("| " + arg.name + " | " + strings.Title(arg.argType.String()) + " | " + optional + " | " + strings.TrimSpace(arg.defaultValue) + " |\n")
+			}
+			b.WriteString("\n")
+		}
+
+		if len(c.dependencies) > 0 {
+			b.WriteString("**Dependencies:** " + strings.Join(c.dependencies, ", ") + "\n\n")
+		}
+
+		if len(c.outputs) > 0 {
+			b.WriteString("**Outputs:** " + strings.Join(c.outputs, ", ") + "\n\n")
+		}
+
+		var flags []string
+		if c.async {
+			flags = append(flags, "runs detached")
+		}
+		if c.buildNumber {
+			flags = append(flags, "increments the build number")
+		}
+		if len(flags) > 0 {
+			b.WriteString("**Note:** " + strings.Join(flags, ", ") + "\n\n")
+		}
+
+		if strings.TrimSpace(c.help) != "" {
+			b.WriteString("```\n" + strings.TrimSpace(c.help) + "\n```\n\n")
+		}
+	}
+
+	return b.String()
+}
+
+/*
+ *	Search
+ */
+
+// wikiSearchIndex is an inverted index over all wiki pages
+// pages are reindexed when they changed on disk
+type wikiSearchIndex struct {
+
+	// term -> page -> number of occurrences
+	terms map[string]map[string]int
+
+	pages map[string]*wikiIndexedPage
+
+	sync.Mutex
+}
+
+type wikiIndexedPage struct {
+	title   string
+	text    string
+	modTime time.Time
+	size    int64
+	terms   map[string]int
+}
+
+// a page that matched a search
+type wikiSearchResult struct {
+	Page    string `json:"page"`
+	URL     string `json:"url"`
+	Title   string `json:"title"`
+	Score   int    `json:"score"`
+	Snippet string `json:"snippet"`
+}
+
+func newWikiSearchIndex() *wikiSearchIndex {
+	return &wikiSearchIndex{
+		terms: make(map[string]map[string]int, 0),
+		pages: make(map[string]*wikiIndexedPage, 0),
+	}
+}
+
+// split text into lower case terms
+func wikiTerms(text string) []string {
+
+	var res []string
+	for _, f := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		if len(f) > 1 {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
+
+// title of a markdown page: the first heading or the page name
+func wikiTitle(page, text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "#") {
+			return strings.TrimSpace(strings.TrimLeft(line, "#"))
+		}
+	}
+	return page
+}
+
+// add a page to the index, index must be locked by the caller
+func (idx *wikiSearchIndex) add(page, text string, modTime time.Time, size int64) {
+
+	idx.delete(page)
+
+	p := &wikiIndexedPage{
+		title:   wikiTitle(page, text),
+		text:    text,
+		modTime: modTime,
+		size:    size,
+		terms:   make(map[string]int, 0),
+	}
+	for _, t := range wikiTerms(text) {
+		p.terms[t]++
+	}
+	for t, n := range p.terms {
+		if idx.terms[t] == nil {
+			idx.terms[t] = make(map[string]int, 0)
+		}
+		idx.terms[t][page] = n
+	}
+
+	idx.pages[page] = p
+}
+
+// delete a page from the index, index must be locked by the caller
+func (idx *wikiSearchIndex) delete(page string) {
+
+	p, ok := idx.pages[page]
+	if !ok {
 		return
 	}
 
-	// parse template
-	t, err := template.New("wiki").Parse(tpl)
+	for t := range p.terms {
+		delete(idx.terms[t], page)
+		if len(idx.terms[t]) == 0 {
+			delete(idx.terms, t)
+		}
+	}
+	delete(idx.pages, page)
+}
+
+// remove a page, it is indexed again on the next search
+func (idx *wikiSearchIndex) remove(page string) {
+	idx.Lock()
+	idx.delete(page)
+	idx.Unlock()
+}
+
+// list the markdown pages of the wiki
+func wikiPages() (pages []string) {
+
+	if _, err := os.Stat(filepath.Join(wikiDir, wikiIndexPage)); err == nil {
+		pages = append(pages, wikiIndexPage)
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(wikiDir, "docs"))
 	if err != nil {
-		Log.WithError(err).Error("failed to create index template")
 		return
 	}
 
-	// execute template
-	err = t.Execute(w, template.HTML(blackfriday.Run(b)))
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), ".md") {
+			pages = append(pages, "docs/"+f.Name())
+		}
+	}
+
+	return
+}
+
+// update the index with the current pages
+func (idx *wikiSearchIndex) refresh() {
+
+	idx.Lock()
+	defer idx.Unlock()
+
+	current := map[string]bool{
+		wikiCommandsPage: true,
+	}
+
+	for _, page := range wikiPages() {
+
+		current[page] = true
+
+		path, err := wikiFile(page)
+		if err != nil {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		if p, ok := idx.pages[page]; ok && p.modTime.Equal(info.ModTime()) && p.size == info.Size() {
+			continue
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		idx.add(page, string(b), info.ModTime(), info.Size())
+	}
+
+	// the command reference changes with the commands
+	commands := commandsMarkdown()
+	if p, ok := idx.pages[wikiCommandsPage]; !ok || p.text != commands {
+		idx.add(wikiCommandsPage, commands, time.Time{}, int64(len(commands)))
+	}
+
+	for page := range idx.pages {
+		if !current[page] {
+			idx.delete(page)
+		}
+	}
+}
+
+// search for pages that contain all terms of the query
+// query terms match all terms with the same prefix
+func (idx *wikiSearchIndex) search(query string) []*wikiSearchResult {
+
+	idx.refresh()
+
+	idx.Lock()
+	defer idx.Unlock()
+
+	var (
+		terms   = wikiTerms(query)
+		scores  = make(map[string]int, 0)
+		results = make([]*wikiSearchResult, 0)
+	)
+
+	if len(terms) == 0 {
+		return results
+	}
+
+	for i, q := range terms {
+
+		matches := make(map[string]int, 0)
+		for t, postings := range idx.terms {
+			if !strings.HasPrefix(t, q) {
+				continue
+			}
+			for page, n := range postings {
+				matches[page] += n
+			}
+		}
+
+		// keep the pages that matched all previous terms
+		for page, n := range matches {
+			if _, ok := scores[page]; ok || i == 0 {
+				scores[page] += n
+			}
+		}
+		for page := range scores {
+			if _, ok := matches[page]; !ok {
+				delete(scores, page)
+			}
+		}
+	}
+
+	for page, score := range scores {
+		p := idx.pages[page]
+		results = append(results, &wikiSearchResult{
+			Page:    page,
+			URL:     wikiURL(page),
+			Title:   p.title,
+			Score:   score,
+			Snippet: wikiSnippet(p.text, terms[0]),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Page < results[j].Page
+	})
+
+	return results
+}
+
+// text around the first match of the term
+func wikiSnippet(text, term string) string {
+
+	var (
+		lower = strings.ToLower(text)
+		i     = strings.Index(lower, term)
+	)
+
+	// the lower case text can differ in length for some characters
+	if i < 0 || len(lower) != len(text) {
+		i = 0
+	}
+
+	start := i - wikiSnippetLength/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + wikiSnippetLength
+	if end > len(text) {
+		end = len(text)
+	}
+
+	// do not split multibyte characters
+	for start > 0 && !utf8RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8RuneStart(text[end]) {
+		end++
+	}
+
+	snippet := strings.Join(strings.Fields(text[start:end]), " ")
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(text) {
+		snippet += "..."
+	}
+
+	return snippet
+}
+
+// check if the byte starts a UTF-8 encoded rune
+func utf8RuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// search the wiki
+var wikiSearchHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, wikiSearch.search(r.URL.Query().Get("q")))
+})
+
+// markdown of a wiki page, for the editor
+type wikiPageSource struct {
+	Page     string `json:"page"`
+	Markdown string `json:"markdown"`
+	Editable bool   `json:"editable"`
+}
+
+// get or save the markdown of a page
+var wikiPageHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+	page := r.URL.Query().Get("page")
+
+	if r.Method == "PUT" {
+
+		var req wikiPageSource
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, errors.New("invalid request body: "+err.Error()))
+			return
+		}
+
+		err = writeWikiPage(page, []byte(req.Markdown))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		Log.Info("saved wiki page ", page)
+	}
+
+	b, err := readWikiPage(page)
 	if err != nil {
-		Log.WithError(err).Error("failed to exec template")
+		writeJSONError(w, http.StatusNotFound, err)
 		return
 	}
+
+	writeJSON(w, http.StatusOK, &wikiPageSource{
+		Page:     page,
+		Markdown: string(b),
+		Editable: wikiEditable(page),
+	})
 })
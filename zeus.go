@@ -29,7 +29,6 @@ import (
 	"sync"
 
 	rice "github.com/GeertJohan/go.rice"
-	"github.com/dreadl0ck/readline"
 	"github.com/mgutz/ansi"
 	"github.com/sirupsen/logrus"
 	prefixed "github.com/x-cray/logrus-prefixed-formatter"
@@ -62,8 +61,8 @@ var (
 	// project data
 	projectData *data
 
-	// shell formatter
-	f = newFormatter("path/to/your/formatter", bashLanguage())
+	// script formatter
+	f = newFormatter()
 
 	g = &globals{
 		Vars: make(map[string]string, 0),
@@ -74,11 +73,6 @@ var (
 	asciiArtYAML string
 	workingDir   string
 
-	// status info
-	s = &status{
-		recursionMap: make(map[string]int, 0),
-	}
-
 	// running a test?
 	testingMode bool
 )
@@ -101,6 +95,8 @@ func initZeus() {
 		err             error
 		flagCompletions = flag.String("completions", "", "get available command completions")
 		flagHelp        = flag.Bool("h", false, "print zeus help and exit")
+		flagTrace       = flag.String("trace", "", "record an execution trace in the Chrome trace event format")
+		flagTraceOTLP   = flag.String("trace-otlp", "", "record an execution trace in the OTLP JSON format")
 	)
 
 	// set up formatter
@@ -133,8 +129,36 @@ func initZeus() {
 	}
 
 	if len(os.Args) > 2 {
-		if os.Args[1] == "makefile" && os.Args[2] == "migrate" {
-			migrateMakefile(zeusDir)
+		if os.Args[1] == makefileCommand && os.Args[2] == "migrate" {
+			makefilePath := "Makefile"
+			if len(os.Args) > 3 {
+				makefilePath = os.Args[3]
+			}
+			report, err := migrateMakefile(makefilePath, commandsFilePath)
+			if report != nil {
+				report.print()
+			}
+			if err != nil {
+				Log.WithError(err).Fatal("failed to migrate " + makefilePath)
+			}
+			os.Exit(0)
+		}
+
+		// import foreign tasks before the zeus directory is checked, it will be created if necessary
+		if os.Args[1] == importCommand {
+			taskFile := ""
+			if len(os.Args) > 3 {
+				taskFile = os.Args[3]
+			} else if f, ok := importFormats[os.Args[2]]; ok {
+				taskFile = f.find()
+			}
+			report, err := importTasks(os.Args[2], taskFile, commandsFilePath)
+			if report != nil {
+				report.print()
+			}
+			if err != nil {
+				Log.WithError(err).Fatal("failed to import " + os.Args[2] + " tasks")
+			}
 			os.Exit(0)
 		}
 	}
@@ -150,6 +174,13 @@ func initZeus() {
 		printHelp()
 	}
 
+	if *flagTrace != "" || *flagTraceOTLP != "" {
+		startTrace(*flagTrace, *flagTraceOTLP)
+	}
+
+	// remove the flags, the arguments are evaluated by position
+	os.Args = append([]string{os.Args[0]}, flag.Args()...)
+
 	stat, err := os.Stat(scriptDir)
 	if err != nil {
 		if stat, err = os.Stat(commandsFilePath); err != nil {
@@ -174,6 +205,13 @@ func main() {
 
 	initZeus()
 
+	// hand the command to a running daemon, the project does not need to be loaded
+	if !testingMode {
+		if code, ok := runOnDaemon(os.Args[1:]); ok {
+			os.Exit(code)
+		}
+	}
+
 	var (
 		cLog           = Log.WithField("prefix", "main")
 		err            error
@@ -202,6 +240,9 @@ func main() {
 	// load persisted events from project data
 	loadEvents()
 
+	// apply the prune policy for the run history
+	pruneHistoryFromConfig()
+
 	projectData.Lock()
 
 	// validate aliases
@@ -212,7 +253,7 @@ func main() {
 		}
 
 		// add to completer
-		completer.Children = append(completer.Children, readline.PcItem(name))
+		completer.Children = append(completer.Children, newAliasCompleter(name))
 	}
 
 	projectData.Unlock()
@@ -279,6 +320,7 @@ func main() {
 		if conf.fields.AutoFormat {
 			// watch zeus directory for changes
 			go f.watchScriptDir("")
+			go f.watchGlobalsDir("")
 		}
 	}
 
@@ -402,7 +444,10 @@ func handleArgs() {
 			printCommands()
 
 		case formatCommand:
-			f.formatCommand()
+			err := f.handleFormatCommand(os.Args[1:])
+			if err != nil {
+				exit(1)
+			}
 		case dataCommand:
 			printProjectData()
 
@@ -412,7 +457,7 @@ func handleArgs() {
 				return
 			}
 
-			handleAliasCommand(os.Args[2:])
+			handleAliasCommand(os.Args[1:])
 
 		case configCommand:
 			handleConfigCommand(os.Args[2:])
@@ -440,16 +485,81 @@ func handleArgs() {
 
 		case makefileCommand:
 			handleMakefileCommand(os.Args[1:])
+
+		case importCommand:
+			handleImportCommand(os.Args[1:])
+
 		case gitFilterCommand:
 			handleGitFilterCommand(os.Args[1:])
 
 		case createCommand:
 			handleCreateCommand(os.Args[1:])
-			os.Exit(0)
+			exit(0)
+
+		case upCommand:
+			err := handleUpCommand(os.Args[1:])
+			if err != nil {
+				exit(1)
+			}
+
+		case validateCommand:
+			err := handleValidateCommand(os.Args[1:])
+			if err != nil {
+				exit(1)
+			}
+
+		case schemaCommand:
+			err := handleSchemaCommand(os.Args[1:])
+			if err != nil {
+				exit(1)
+			}
+
+		case graphCommand:
+			err := handleGraphCommand(os.Args[1:])
+			if err != nil {
+				exit(1)
+			}
+
+		case historyCommand:
+			err := handleHistoryCommand(os.Args[1:])
+			if err != nil {
+				exit(1)
+			}
+
+		case reportCommand:
+			err := handleReportCommand(os.Args[1:])
+			if err != nil {
+				exit(1)
+			}
+
+		case daemonCommand:
+			if len(os.Args) == 3 && os.Args[2] == "run" {
+				err := runDaemon()
+				if err != nil {
+					cLog.WithError(err).Fatal("failed to run daemon")
+				}
+			} else if handleDaemonCommand(os.Args[1:]) != nil {
+				exit(1)
+			}
 
 		default:
 			handleSignals()
 
+			// check if its a pipeline supplied with "" or ''
+			// the exit code is the exit status of the stage that failed
+			if isPipelineArgs(os.Args[1:]) {
+				err := runPipeline(os.Args[1])
+				if err != nil {
+					cLog.WithError(err).Error("pipeline failed")
+					cleanup()
+					exit(pipelineExitCode(err))
+				}
+				if !testingMode {
+					exit(0)
+				}
+				return
+			}
+
 			cmdMap.Lock()
 
 			// check if the command exists
@@ -458,21 +568,22 @@ func handleArgs() {
 
 				validCommand = true
 
-				count, err := getTotalDependencyCount(cmd)
+				ctx := newExecContext()
+				count, err := getTotalDependencyCount(ctx.status, cmd)
 				if err != nil {
 					l.Println(err)
 					return
 				}
 
-				s.Lock()
-				s.numCommands = count
-				s.Unlock()
+				ctx.status.Lock()
+				ctx.status.numCommands = count
+				ctx.status.Unlock()
 
-				err = cmd.Run(os.Args[2:], cmd.async)
+				err = cmd.Run(ctx.withArgs(os.Args[2:]), cmd.async)
 				if err != nil {
 					cLog.WithError(err).Error("failed to execute " + cmd.name)
 					cleanup()
-					os.Exit(1)
+					exit(1)
 				}
 			} else {
 				cmdMap.Unlock()
@@ -480,19 +591,31 @@ func handleArgs() {
 
 			// check if its a commandchain supplied with "" or ''
 			if strings.Contains(os.Args[1], commandChainSeparator) {
-				fields := strings.Split(os.Args[1], commandChainSeparator)
+				fields, err := expandChainAliases(os.Args[1])
+				if err != nil {
+					cLog.WithError(err).Error("invalid commandChain")
+					exit(1)
+				}
 				if cmdChain, ok := validCommandChain(fields); ok {
-					cmdChain.exec(fields)
+					cmdChain.exec(newExecContext(), fields)
 				} else {
 					l.Println("invalid commandChain")
 				}
+				if !testingMode {
+					exit(0)
+				}
 				return
 			}
 
 			// check if its an alias
 			if command, ok := projectData.fields.Aliases[os.Args[1]]; ok {
-				handleLine(command)
-				os.Exit(0)
+				line, err := expandAlias(command, os.Args[2:])
+				if err != nil {
+					cLog.WithError(err).Error("invalid arguments for alias: ", os.Args[1])
+					exit(1)
+				}
+				handleLine(line)
+				exit(0)
 			}
 
 			if !validCommand {
@@ -502,7 +625,7 @@ func handleArgs() {
 			}
 		}
 		if !testingMode {
-			os.Exit(0)
+			exit(0)
 		}
 	}
 }
@@ -19,13 +19,25 @@
 package main
 
 import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"testing"
 	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/yaml.v2"
 )
 
 var (
@@ -130,6 +142,77 @@ func TestAliases(t *testing.T) {
 		c.So(len(projectData.fields.Aliases), ShouldEqual, 0)
 		handleLine("alias")
 	})
+
+	Convey("Testing alias placeholders", t, func(c C) {
+
+		out, err := expandAlias("build env=$1 -> push tag=${2:-latest}", []string{"prod"})
+		c.So(err, ShouldBeNil)
+		c.So(out, ShouldEqual, "build env=prod -> push tag=latest")
+
+		out, err = expandAlias("build env=$1 -> push tag=${2:-latest}", []string{"prod", "v1"})
+		c.So(err, ShouldBeNil)
+		c.So(out, ShouldEqual, "build env=prod -> push tag=v1")
+
+		out, err = expandAlias("deploy host=${host:-localhost} $@", []string{"host=example.com", "a=1", "b=2"})
+		c.So(err, ShouldBeNil)
+		c.So(out, ShouldEqual, "deploy host=example.com a=1 b=2")
+
+		// arguments are appended if there are no placeholders
+		out, err = expandAlias("build", []string{"env=prod"})
+		c.So(err, ShouldBeNil)
+		c.So(out, ShouldEqual, "build env=prod")
+
+		_, err = expandAlias("build env=$1", []string{})
+		c.So(err, ShouldNotBeNil)
+		_, err = expandAlias("build env=$1", []string{"a", "b"})
+		c.So(err, ShouldNotBeNil)
+
+		// arguments are validated against the command when the alias is defined
+		c.So(validateAliasCommand("greet name=$1"), ShouldBeNil)
+		c.So(validateAliasCommand("greet nam=$1"), ShouldNotBeNil)
+		c.So(validateAliasCommand("python src=$1 dst=${2:-out}"), ShouldBeNil)
+		c.So(validateAliasCommand("python src=$1 -> greet"), ShouldNotBeNil)
+		c.So(validateAliasCommand("python src=a"), ShouldBeNil)
+		c.So(validateAliasCommand("ls -la | grep x"), ShouldBeNil)
+
+		handleLine("alias set hi greet name=${1:-nobody}")
+		c.So(projectData.fields.Aliases["hi"], ShouldEqual, "greet name=${1:-nobody}")
+		c.So(aliasCompleter(""), ShouldContain, "hi")
+
+		handleLine(`alias broken "greet nam=$1"`)
+		_, ok := projectData.fields.Aliases["broken"]
+		c.So(ok, ShouldBeFalse)
+
+		// forward arguments
+		file := filepath.Join(os.TempDir(), "zeus-alias-test.txt")
+		defer os.Remove(file)
+
+		handleLine("hi bob > " + file)
+		handleLine("hi >> " + file)
+
+		contents, err := ioutil.ReadFile(file)
+		c.So(err, ShouldBeNil)
+		c.So(string(contents), ShouldEqual, "hello bob\nhello nobody\n")
+
+		// aliases are expanded in each command of a chain
+		fields, err := expandChainAliases("hi bob -> greet name=x")
+		c.So(err, ShouldBeNil)
+		c.So(fields, ShouldHaveLength, 2)
+		c.So(strings.TrimSpace(fields[0]), ShouldEqual, "greet name=bob")
+		c.So(strings.TrimSpace(fields[1]), ShouldEqual, "greet name=x")
+
+		handleLine("alias set both pause seconds=0 -> service1")
+		c.So(projectData.fields.Aliases["both"], ShouldEqual, "pause seconds=0 -> service1")
+		fields, err = expandChainAliases("greet -> both")
+		c.So(err, ShouldBeNil)
+		c.So(fields, ShouldHaveLength, 3)
+		_, ok = validCommandChain(fields)
+		c.So(ok, ShouldBeTrue)
+		handleLine("alias remove both")
+
+		handleLine("alias remove hi")
+		c.So(projectData.fields.Aliases, ShouldBeEmpty)
+	})
 }
 
 func TestConfig(t *testing.T) {
@@ -221,6 +304,15 @@ func TestEvents(t *testing.T) {
 
 		handleLine("events asdfasd")
 
+		// only builtins that are safe in the background can be run by events
+		c.So(createEvent("WRITE", "tests", "", []string{"exit"}), ShouldNotBeNil)
+		c.So(createEvent("WRITE", "tests", "", []string{"events", "remove", "x"}), ShouldNotBeNil)
+		c.So(checkEventCommand([]string{"dashboard"}), ShouldNotBeNil)
+		c.So(checkEventCommand([]string{"report", "reports/status.html"}), ShouldBeNil)
+		c.So(checkEventCommand([]string{"greet"}), ShouldBeNil)
+		c.So(checkEventCommand([]string{"echo", "hello"}), ShouldBeNil)
+		c.So(checkEventCommand(nil), ShouldNotBeNil)
+
 		Log.Info("adding event for tests dir")
 		handleLine("events add WRITE tests .xyz error")
 
@@ -315,16 +407,94 @@ func TestMakefileMigration(t *testing.T) {
 
 	TestMain(t)
 
-	Convey("Testing makefile migration", t, func() {
+	Convey("Testing makefile migration", t, func(c C) {
+
+		var (
+			makefilePath = filepath.Join(os.TempDir(), "zeus-migration-test.mk")
+			commandsPath = filepath.Join(os.TempDir(), "zeus-migration-test.yml")
+		)
+
+		makefile := "CC ?= gcc\n" +
+			"CFLAGS := -O2 \\\n" +
+			"\t-Wall\n" +
+			"CFLAGS += -g\n" +
+			"OUT = bin/app\n" +
+			"REV != git rev-parse HEAD\n" +
+			"include config.mk\n" +
+			"\n" +
+			".PHONY: all tidy\n" +
+			"\n" +
+			"# build everything\n" +
+			"all: $(OUT) docs\n" +
+			"\n" +
+			"$(OUT): main.o util.o | bin\n" +
+			"\t@$(CC) $(CFLAGS) -o $@ $^\n" +
+			"\n" +
+			"bin:\n" +
+			"\tmkdir -p $@\n" +
+			"\n" +
+			"%.o: %.c\n" +
+			"\t$(CC) -c $< -o $@\n" +
+			"\n" +
+			"docs: ; echo $$HOME $(wildcard *.md)\n" +
+			"\n" +
+			"tidy:\n" +
+			"\t-rm -rf bin\n" +
+			"\t$(MAKE) -C sub clean\n" +
+			"\n" +
+			"greet:\n" +
+			"\techo hello\n"
+
+		c.So(ioutil.WriteFile(makefilePath, []byte(makefile), 0644), ShouldBeNil)
+		c.So(ioutil.WriteFile(commandsPath, []byte("language: bash\n\ncommands:\n    greet:\n        exec: echo hi\n"), 0644), ShouldBeNil)
+
+		report, err := migrateMakefile(makefilePath, commandsPath)
+		c.So(err, ShouldBeNil)
+		c.So(report.commands, ShouldResemble, []string{"all", "bin-app", "bin", "docs", "tidy"})
+		c.So(report.globals, ShouldResemble, []string{"CC", "CFLAGS", "OUT", "REV"})
+
+		var issues []string
+		for _, i := range report.issues {
+			issues = append(issues, strconv.Itoa(i.line)+": "+i.msg)
+		}
+		c.So(issues, ShouldContain, "7: include not converted: include config.mk")
+		c.So(issues, ShouldContain, "20: pattern rule not converted: %.o: %.c")
+		c.So(issues, ShouldContain, "14: target bin/app migrated as command bin-app")
+		c.So(issues, ShouldContain, "14: prerequisites without a rule not converted for target bin/app: main.o util.o")
+		c.So(issues, ShouldContain, "23: function or substitution reference not converted: $(wildcard *.md)")
+		c.So(issues, ShouldContain, "29: target not migrated, the name greet is already taken")
+		c.So(issues, ShouldContain, "1: conditional assignment migrated as normal assignment: CC")
+		c.So(issues, ShouldContain, "27: recursive make not converted: make -C sub clean")
+
+		// the migrated file is a valid commands file
+		contents, err := ioutil.ReadFile(commandsPath)
+		c.So(err, ShouldBeNil)
+
+		commandsFile := newCommandsFile()
+		c.So(yaml.UnmarshalStrict(contents, commandsFile), ShouldBeNil)
+		c.So(commandsFile.Globals["CFLAGS"], ShouldEqual, "-O2 -Wall -g")
+		c.So(commandsFile.Globals["REV"], ShouldEqual, "$(git rev-parse HEAD)")
+		c.So(commandsFile.Commands["greet"].Exec, ShouldEqual, "echo hi")
 
-		// remove previous generated directory
-		// os.Remove("tests/zeus/migration-test")
+		all := commandsFile.Commands["all"]
+		c.So(all.Description, ShouldEqual, "build everything")
+		c.So(all.Dependencies, ShouldResemble, []string{"bin-app", "docs"})
+		c.So(all.Outputs, ShouldBeEmpty)
 
-		// migrate test Makefile into tests/zeus
-		migrateMakefile("tests/zeus/migration-test")
+		app := commandsFile.Commands["bin-app"]
+		c.So(app.Dependencies, ShouldResemble, []string{"bin"})
+		c.So(app.Outputs, ShouldResemble, []string{"bin/app"})
+		c.So(app.Exec, ShouldEqual, "${CC} ${CFLAGS} -o bin/app main.o util.o\n")
 
-		// clean up
-		// os.Remove("tests/zeus/migration-test")
+		c.So(commandsFile.Commands["docs"].Exec, ShouldEqual, "echo $HOME $(wildcard *.md)\n")
+		c.So(commandsFile.Commands["tidy"].Exec, ShouldEqual, "rm -rf bin || true\nmake -C sub clean\n")
+
+		// nothing left to migrate
+		_, err = migrateMakefile(makefilePath, commandsPath)
+		c.So(err, ShouldEqual, ErrNothingToMigrate)
+
+		os.Remove(makefilePath)
+		os.Remove(commandsPath)
 	})
 }
 
@@ -448,6 +618,9 @@ func TestCommandsFile(t *testing.T) {
 
 		// clean up
 		removeEvent(eventID)
+
+		// restore the commands of the test project
+		c.So(parseCommandsFile(commandsFilePath), ShouldBeNil)
 	})
 }
 
@@ -472,7 +645,94 @@ func TestGenerate(t *testing.T) {
 		handleLine("generate build.sh build")
 		handleLine("generate testChain.sh async -> optional bla=asdf req=asdfd -> error")
 
-		os.Remove("tests/zeus/generated")
+		// dependencies are added before the commands that need them
+		plan, err := newGeneratePlan([]string{"dependency2", "->", "greet", "name=zeus"})
+		c.So(err, ShouldBeNil)
+		c.So(len(plan.nodes), ShouldEqual, 3)
+		c.So(plan.nodes[0].cmd.name, ShouldEqual, "dependency1")
+		c.So(plan.nodes[1].deps, ShouldResemble, []string{"dependency1"})
+		c.So(plan.nodes[2].deps, ShouldResemble, []string{"dependency2"})
+		c.So(plan.nodes[2].args, ShouldResemble, []string{"name=zeus"})
+
+		_, err = newGeneratePlan([]string{"cycle1"})
+		c.So(err, ShouldNotBeNil)
+		c.So(err.Error(), ShouldEqual, "dependency cycle: cycle1 -> cycle2 -> cycle1")
+
+		// each command is generated once, so it can only run once in the chain
+		_, err = newGeneratePlan([]string{"greet", "name=a", "->", "dependency2", "->", "greet", "name=b"})
+		c.So(err, ShouldNotBeNil)
+		c.So(err.Error(), ShouldEqual, "command runs more than once in the chain: greet")
+
+		_, err = newGeneratePlan([]string{"dependency2", "->", "dependency1"})
+		c.So(err, ShouldNotBeNil)
+
+		_, err = newGeneratePlan([]string{"arguments", "password=x", "ipAddr=y", "->", "chain"})
+		c.So(err, ShouldNotBeNil)
+		c.So(err.Error(), ShouldEqual, "command invoked with different arguments: arguments: 'password=x ipAddr=y' and 'password=test ipAddr=192.168.1.5'")
+
+		plan, err = newGeneratePlan([]string{"dependency1", "->", "dependency2"})
+		c.So(err, ShouldBeNil)
+		c.So(len(plan.nodes), ShouldEqual, 2)
+
+		// chains of a single language are generated into a single script
+		handleLine("generate chain.sh dependency2 -> greet name=zeus")
+		script, err := ioutil.ReadFile("tests/zeus/generated/chain.sh")
+		c.So(err, ShouldBeNil)
+		c.So(string(script), ShouldContainSubstring, "# dependency1\n")
+		c.So(string(script), ShouldContainSubstring, "# greet\nname=zeus\n")
+
+		handleLine("generate makefile pipeline dependency2 -> greet name=zeus")
+		makefile, err := ioutil.ReadFile("tests/zeus/generated/pipeline/Makefile")
+		c.So(err, ShouldBeNil)
+		c.So(string(makefile), ShouldContainSubstring, "all: greet\n")
+		c.So(string(makefile), ShouldContainSubstring, "\ndependency2: dependency1\n\t/bin/bash -e tests/zeus/generated/pipeline/scripts/dependency2.sh\n")
+		c.So(string(makefile), ShouldContainSubstring, "\ngreet: dependency2\n")
+
+		handleLine("generate workflow pipeline dependency2 -> greet name=zeus")
+		workflow, err := ioutil.ReadFile("tests/zeus/generated/pipeline/workflow.yml")
+		c.So(err, ShouldBeNil)
+		c.So(string(workflow), ShouldContainSubstring, "run: /bin/bash -e tests/zeus/generated/pipeline/scripts/greet.sh")
+
+		handleLine("generate dockerfile pipeline dependency2 -> greet name=zeus")
+		dockerfile, err := ioutil.ReadFile("tests/zeus/generated/pipeline/Dockerfile")
+		c.So(err, ShouldBeNil)
+		c.So(string(dockerfile), ShouldContainSubstring, "FROM dependency2 AS greet\nRUN /bin/bash -e tests/zeus/generated/pipeline/scripts/greet.sh\n")
+
+		// the generated Makefile runs the chain without ZEUS
+		if _, err := exec.LookPath("make"); err == nil {
+			out, err := exec.Command("make", "-s", "-f", "tests/zeus/generated/pipeline/Makefile").CombinedOutput()
+			c.So(err, ShouldBeNil)
+			c.So(string(out), ShouldContainSubstring, "hello zeus")
+
+			os.Remove("tests/bin/dependency1")
+			os.Remove("tests/bin/dependency2")
+		}
+
+		// standalone executable with a subcommand per command
+		if _, err := exec.LookPath("go"); err == nil {
+
+			c.So(generateBinaryTarget("tool", []string{"dependency2", "->", "greet", "name=zeus"}), ShouldBeNil)
+
+			out, err := exec.Command("tests/zeus/generated/tool/tool", "greet").CombinedOutput()
+			c.So(err, ShouldBeNil)
+			c.So(string(out), ShouldContainSubstring, "hello zeus")
+
+			out, err = exec.Command("tests/zeus/generated/tool/tool", "greet", "--name", "world and more").CombinedOutput()
+			c.So(err, ShouldBeNil)
+			c.So(string(out), ShouldContainSubstring, "hello world and more")
+
+			out, _ = exec.Command("tests/zeus/generated/tool/tool", "greet", "--help").CombinedOutput()
+			c.So(string(out), ShouldContainSubstring, "print a greeting")
+			c.So(string(out), ShouldContainSubstring, "-name string")
+
+			os.Remove("tests/bin/dependency1")
+			os.Remove("tests/bin/dependency2")
+			os.RemoveAll("tests/zeus/generated/tool")
+		}
+
+		os.Remove("tests/zeus/generated/chain.sh")
+		os.Remove("tests/zeus/generated/testChain.sh")
+		os.RemoveAll("tests/zeus/generated/pipeline")
 	})
 }
 
@@ -552,3 +812,2184 @@ func TestCustomCompleters(t *testing.T) {
 		c.So(directoryCompleter(""), ShouldNotBeEmpty)
 	})
 }
+
+func TestServices(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing service groups", t, func(c C) {
+
+		// print service groups
+		handleLine("up")
+
+		// unknown group
+		c.So(startServiceGroup("asdfasdf"), ShouldNotBeNil)
+
+		// service1 exits after a second and stops service2
+		start := time.Now()
+		c.So(startServiceGroup("demo"), ShouldBeNil)
+		c.So(time.Now().Sub(start), ShouldBeLessThan, 10*time.Second)
+
+		// a crashed service stops the group with an error
+		err := startServiceGroup("broken")
+		c.So(err, ShouldNotBeNil)
+		c.So(err.Error(), ShouldStartWith, ErrServiceCrashed.Error()+": fail")
+		c.So(handleUpCommand([]string{"up", "broken"}), ShouldNotBeNil)
+
+		// all services must have been removed from the process map
+		processMapMutex.Lock()
+		for _, p := range processMap {
+			c.So(p.Name, ShouldNotStartWith, "service")
+		}
+		processMapMutex.Unlock()
+	})
+}
+
+func TestJobs(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing job control", t, func(c C) {
+
+		// no jobs yet
+		handleLine("jobs")
+		handleLine("fg")
+
+		// invalid background job
+		handleLine("asdfasdf &")
+		c.So(jobs.list(), ShouldBeEmpty)
+
+		// other commands are run in the background by the shell
+		c.So(isCommandChain("sleep 1"), ShouldBeFalse)
+		c.So(isCommandChain("service1 -> sleep 1"), ShouldBeFalse)
+		c.So(isCommandChain("service1 -> greet"), ShouldBeTrue)
+
+		// aliases are expanded before they are run in the background
+		handleLine("alias set bgAlias service1")
+		handleLine("bgAlias &")
+		c.So(jobs.list(), ShouldHaveLength, 1)
+		handleLine("wait")
+		handleLine("jobs")
+		handleLine("alias remove bgAlias")
+		c.So(jobs.list(), ShouldBeEmpty)
+
+		// run a command in the background
+		handleLine("service1 &")
+		c.So(jobs.list(), ShouldHaveLength, 1)
+
+		// background jobs have their own execution context
+		j, err := jobs.get("")
+		c.So(err, ShouldBeNil)
+		c.So(j.ctx.background, ShouldBeTrue)
+
+		handleLine("jobs")
+		handleLine("bg 1")
+
+		// wait for it and remove it from the job list
+		handleLine("wait")
+		handleLine("jobs")
+		c.So(jobs.list(), ShouldBeEmpty)
+	})
+}
+
+func TestExecContext(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing execution contexts", t, func(c C) {
+
+		var (
+			wg         sync.WaitGroup
+			bufA, bufB bytes.Buffer
+			ctxA       = newOutputContext(&bufA)
+			ctxB       = newOutputContext(&bufB)
+		)
+
+		service1, err := cmdMap.getCommand("service1")
+		c.So(err, ShouldBeNil)
+
+		// run the same chain concurrently in two contexts
+		for _, ctx := range []*execContext{ctxA, ctxB} {
+			wg.Add(1)
+			go func(ctx *execContext) {
+				defer wg.Done()
+				c.So(commandChain{service1}.exec(ctx, []string{"service1"}), ShouldBeNil)
+			}(ctx)
+		}
+		wg.Wait()
+
+		// each context received its own output
+		c.So(bufA.String(), ShouldContainSubstring, "[1/1] finished")
+		c.So(bufB.String(), ShouldContainSubstring, "[1/1] finished")
+
+		// cancel a long running command
+		service2, err := cmdMap.getCommand("service2")
+		c.So(err, ShouldBeNil)
+
+		ctx := newOutputContext(&bytes.Buffer{})
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+			ctx.cancel()
+		}()
+
+		start := time.Now()
+		c.So(service2.Run(ctx, false), ShouldEqual, ErrCancelled)
+		c.So(time.Now().Sub(start), ShouldBeLessThan, 10*time.Second)
+	})
+}
+
+func TestPipelines(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing pipelines and redirections", t, func(c C) {
+
+		c.So(isPipeline("build -> test"), ShouldBeFalse)
+		c.So(isPipeline("build | grep x"), ShouldBeTrue)
+		c.So(isPipeline("build > out.txt"), ShouldBeTrue)
+		c.So(isPipeline("echo 'a | b'"), ShouldBeFalse)
+		c.So(isPipeline("alias x ls | grep x"), ShouldBeFalse)
+
+		p, err := parsePipeline("clean -> build 2>&1 | grep x >> out.txt")
+		c.So(err, ShouldBeNil)
+		c.So(p.stages, ShouldHaveLength, 2)
+		c.So(p.stages[0].line, ShouldEqual, "clean -> build")
+		c.So(p.stages[0].stderrToStdout, ShouldBeTrue)
+		c.So(p.stages[1].line, ShouldEqual, "grep x")
+		c.So(p.outFile, ShouldEqual, "out.txt")
+		c.So(p.appendOut, ShouldBeTrue)
+
+		_, err = parsePipeline("build > out.txt | grep x")
+		c.So(err, ShouldEqual, ErrRedirectNotLast)
+		_, err = parsePipeline("build | | grep x")
+		c.So(err, ShouldEqual, ErrEmptyPipelineStage)
+		_, err = parsePipeline("build >")
+		c.So(err, ShouldEqual, ErrMissingRedirectTarget)
+
+		out := filepath.Join(os.TempDir(), "zeus-pipeline-test.txt")
+		defer os.Remove(out)
+
+		// mix zeus and shell commands
+		c.So(runPipeline("greet name=x | grep hello > "+out), ShouldBeNil)
+		c.So(runPipeline("greet | tr a-z A-Z | greet name=y 2>&1 >> "+out), ShouldBeNil)
+		c.So(runPipeline("echo hello z | grep hello >> "+out), ShouldBeNil)
+
+		contents, err := ioutil.ReadFile(out)
+		c.So(err, ShouldBeNil)
+		c.So(string(contents), ShouldEqual, "hello x\nhello y\nhello z\n")
+
+		// exit status propagation
+		c.So(pipelineExitCode(runPipeline("greet | false")), ShouldEqual, 1)
+		c.So(pipelineExitCode(runPipeline("fail | cat")), ShouldEqual, 3)
+		c.So(pipelineExitCode(runPipeline("greet | sh -c 'exit 4'")), ShouldEqual, 4)
+		c.So(runPipeline("greet | cat > "+out), ShouldBeNil)
+
+		// only a single argument is a pipeline, quoted arguments are never split
+		c.So(isPipelineArgs([]string{"greet | grep hello"}), ShouldBeTrue)
+		c.So(isPipelineArgs([]string{"greet", "name=a|b"}), ShouldBeFalse)
+		c.So(isPipelineArgs([]string{"greet", "name=>x"}), ShouldBeFalse)
+		c.So(isPipelineArgs([]string{"-h"}), ShouldBeFalse)
+	})
+}
+
+func TestFormatter(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing the formatter", t, func(c C) {
+
+		// use a formatter that strips leading whitespace
+		bash, err := ls.getLang("bash")
+		c.So(err, ShouldBeNil)
+
+		ls.Lock()
+		formatter := bash.Formatter
+		bash.Formatter = "sed -e s/^[[:space:]]*//"
+		ls.Unlock()
+
+		defer func() {
+			ls.Lock()
+			bash.Formatter = formatter
+			ls.Unlock()
+		}()
+
+		out, err := f.formatCode(bash, "  echo hello\n")
+		c.So(err, ShouldBeNil)
+		c.So(out, ShouldEqual, "echo hello\n")
+
+		// exec blocks are reinserted with their indentation
+		path := filepath.Join(os.TempDir(), "zeus-formatter-test.yml")
+		defer os.Remove(path)
+
+		err = ioutil.WriteFile(path, []byte(`language: bash
+commands:
+    hello:
+        description: test
+        exec: |
+            if true; then
+                  echo "hello"
+            fi
+
+    world:
+        exec: echo world
+`), 0644)
+		c.So(err, ShouldBeNil)
+
+		r, err := f.formatCommandsFile(path)
+		c.So(err, ShouldBeNil)
+		c.So(r.changed(), ShouldBeTrue)
+		c.So(r.formatted, ShouldEqual, `language: bash
+commands:
+    hello:
+        description: test
+        exec: |
+            if true; then
+            echo "hello"
+            fi
+
+    world:
+        exec: echo world
+`)
+
+		d := diff(r.path, r.original, r.formatted)
+		c.So(d, ShouldContainSubstring, `-                  echo "hello"`)
+		c.So(d, ShouldContainSubstring, `+            echo "hello"`)
+
+		// check mode must not modify any files
+		before, err := ioutil.ReadFile(commandsFilePath)
+		c.So(err, ShouldBeNil)
+
+		err = f.handleFormatCommand([]string{"format", "--check"})
+		c.So(err == nil || err == ErrUnformattedFiles, ShouldBeTrue)
+
+		after, err := ioutil.ReadFile(commandsFilePath)
+		c.So(err, ShouldBeNil)
+		c.So(string(after), ShouldEqual, string(before))
+
+		c.So(f.handleFormatCommand([]string{"format", "--asdf"}), ShouldEqual, ErrInvalidUsage)
+
+		// files can not be checked without their formatter
+		ls.Lock()
+		bash.Formatter = "zeus-formatter-does-not-exist"
+		ls.Unlock()
+
+		r, err = f.formatCommandsFile(path)
+		c.So(err, ShouldBeNil)
+		c.So(r.changed(), ShouldBeFalse)
+		c.So(r.unchecked, ShouldBeTrue)
+		c.So(f.handleFormatCommand([]string{"format", "--check"}), ShouldBeIn, ErrUnformattedFiles, ErrFormatterNotFound)
+	})
+}
+
+func TestValidate(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Validating the zeus directory", t, func(c C) {
+
+		path := filepath.Join(os.TempDir(), "zeus-validate-test.yml")
+		defer os.Remove(path)
+
+		err := ioutil.WriteFile(path, []byte(`language: bash
+globals:
+    name: zeus
+commands:
+    hello:
+        unknown: field
+        arguments:
+            - name:String
+            - count:Foo
+        dependencies:
+            - missing
+            - world a=b
+        exec: |
+            echo "hello"
+            if true; then
+    world:
+        dependencies:
+            - hello
+        exec: echo world
+    help:
+        exec: echo help
+`), 0644)
+		c.So(err, ShouldBeNil)
+
+		v := &validator{}
+		v.validateCommandsFile(path)
+
+		var diagnostics []string
+		for _, d := range v.diagnostics {
+			diagnostics = append(diagnostics, d.String())
+		}
+
+		c.So(diagnostics, ShouldContain, path+":6: unknown field: unknown")
+		c.So(diagnostics, ShouldContain, path+":11: hello: unknown command in dependency: missing")
+		c.So(diagnostics, ShouldContain, path+":20: command help conflicts with a builtin")
+		c.So(diagnostics, ShouldContain, path+":5: dependency cycle: hello -> world -> hello")
+
+		var found bool
+		for _, d := range diagnostics {
+			if strings.HasPrefix(d, path+":7: hello: ") {
+				found = true
+			}
+		}
+		c.So(found, ShouldBeTrue)
+
+		// the test directory contains the cycle1 / cycle2 dependency cycle
+		c.So(handleValidateCommand([]string{"validate"}), ShouldEqual, ErrValidationFailed)
+		c.So(handleValidateCommand([]string{"validate", "asdf"}), ShouldEqual, ErrInvalidUsage)
+
+		// aliases can not be checked without the project data
+		data := projectData
+		projectData = nil
+		c.So((&validator{}).validateAliases(), ShouldEqual, ErrNoProjectData)
+		projectData = data
+	})
+}
+
+func TestSchema(t *testing.T) {
+
+	Convey("Generating JSON schemas from the structs", t, func(c C) {
+
+		s := schemas["commands"]
+		c.So(s.Properties["commands"].AdditionalProperties.(*jsonSchema).Properties["exec"].Type, ShouldEqual, "string")
+		c.So(s.Properties["services"].AdditionalProperties.(*jsonSchema).Items.Type, ShouldEqual, "string")
+
+		b, err := schemas["config"].JSON()
+		c.So(err, ShouldBeNil)
+		c.So(string(b), ShouldContainSubstring, `"webInterface"`)
+		c.So(string(b), ShouldNotContainSubstring, `"eebInterface"`)
+
+		var names []string
+		for _, item := range configItems() {
+			names = append(names, string(item.GetName()))
+		}
+		c.So(strings.Join(names, ""), ShouldContainSubstring, "webInterface")
+		c.So(strings.Join(names, ""), ShouldNotContainSubstring, "fixParseErrors")
+
+		errs := schemas["config"].check(`eebInterface: true
+debug: maybe
+languages:
+  - name: go
+    bang: "#!/bin/go"
+    intepreter: go
+colorProfiles:
+    custom:
+        Text: ""
+        Foo: ""
+debug: true
+`)
+		var msgs []string
+		for _, e := range errs {
+			msgs = append(msgs, e.Error())
+		}
+		c.So(msgs, ShouldResemble, []string{
+			"line 1: unknown field: eebInterface",
+			"line 2: debug: expected boolean, got: maybe",
+			"line 6: unknown field: intepreter",
+			"line 10: unknown field: Foo",
+			"line 11: duplicate field: debug, first declared in line 2",
+		})
+
+		// exec blocks are not checked
+		c.So(schemas["commands"].check(`commands:
+    build:
+        exec: |
+            foo: bar
+        dependencies:
+            - clean
+`), ShouldBeEmpty)
+
+		c.So(handleSchemaCommand([]string{"schema", "data"}), ShouldBeNil)
+		c.So(handleSchemaCommand([]string{"schema", "asdf"}), ShouldEqual, ErrUnknownSchema)
+		c.So(handleSchemaCommand([]string{"schema"}), ShouldEqual, ErrInvalidUsage)
+	})
+}
+
+func TestImport(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing import", t, func(c C) {
+
+		var (
+			dir          = filepath.Join(os.TempDir(), "zeus-import-test")
+			commandsPath = filepath.Join(dir, "commands.yml")
+		)
+		c.So(os.MkdirAll(dir, 0700), ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		load := func() *CommandsFile {
+			contents, err := ioutil.ReadFile(commandsPath)
+			c.So(err, ShouldBeNil)
+			commandsFile := newCommandsFile()
+			c.So(yaml.UnmarshalStrict(contents, commandsFile), ShouldBeNil)
+			for _, d := range commandsFile.Commands {
+				_, err := parseArgumentDeclarations(d.Arguments)
+				c.So(err, ShouldBeNil)
+			}
+			return commandsFile
+		}
+
+		issues := func(r *migrationReport) (out []string) {
+			for _, i := range r.issues {
+				out = append(out, strconv.Itoa(i.line)+": "+i.msg)
+			}
+			return
+		}
+
+		// npm scripts
+		packageJSON := filepath.Join(dir, "package.json")
+		c.So(ioutil.WriteFile(packageJSON, []byte(`{
+  "name": "app",
+  "scripts": {
+    "prebundle": "rimraf dist",
+    "bundle": "tsc -p .",
+    "postbundle": "echo done",
+    "test:unit": "jest",
+    "ci": "npm run bundle && npm test -- --coverage"
+  },
+  "dependencies": {}
+}
+`), 0644), ShouldBeNil)
+
+		report, err := importTasks(importNPM, packageJSON, commandsPath)
+		c.So(err, ShouldBeNil)
+		c.So(report.commands, ShouldResemble, []string{"prebundle", "bundle", "postbundle", "test-unit", "ci"})
+		c.So(issues(report), ShouldContain, "6: post script postbundle depends on bundle, run postbundle to run both")
+		c.So(issues(report), ShouldContain, "7: script test:unit migrated as command test-unit")
+
+		commandsFile := load()
+		c.So(commandsFile.Commands["bundle"].Dependencies, ShouldResemble, []string{"prebundle"})
+		c.So(commandsFile.Commands["postbundle"].Dependencies, ShouldResemble, []string{"bundle"})
+		c.So(commandsFile.Commands["ci"].Exec, ShouldEndWith, "\nzeus bundle && zeus test -- --coverage\n")
+
+		// justfile
+		justfile := filepath.Join(dir, "justfile")
+		c.So(ioutil.WriteFile(justfile, []byte(`set shell := ["bash", "-c"]
+
+version := "1.0"
+commit := `+"`git rev-parse HEAD`"+`
+
+# compile the project
+compile target="debug" jobs="4": fetch
+    @echo building {{target}} with {{jobs}} jobs
+    -cargo build --{{target}} -j {{jobs}}
+
+fetch:
+    cargo fetch
+
+release: (compile "release" "8")
+
+hello *names:
+    #!/usr/bin/env python3
+    print("hello")
+`), 0644), ShouldBeNil)
+
+		report, err = importTasks(importJust, justfile, commandsPath)
+		c.So(err, ShouldBeNil)
+		c.So(report.commands, ShouldResemble, []string{"compile", "fetch", "release", "hello"})
+		c.So(report.globals, ShouldResemble, []string{"version", "commit"})
+		c.So(issues(report), ShouldContain, "1: set not converted: set shell := [\"bash\", \"-c\"]")
+		c.So(issues(report), ShouldContain, "16: variadic parameter *names migrated as a single argument")
+
+		commandsFile = load()
+		c.So(commandsFile.Globals["commit"], ShouldEqual, "$(git rev-parse HEAD)")
+
+		compile := commandsFile.Commands["compile"]
+		c.So(compile.Description, ShouldEqual, "compile the project")
+		c.So(compile.Arguments, ShouldResemble, []string{"target:String? = debug", "jobs:Int? = 4"})
+		c.So(compile.Dependencies, ShouldResemble, []string{"fetch"})
+		c.So(compile.Exec, ShouldEqual, "echo building ${target} with ${jobs} jobs\ncargo build --${target} -j ${jobs} || true\n")
+
+		c.So(commandsFile.Commands["release"].Dependencies, ShouldResemble, []string{"compile target=release jobs=8"})
+		c.So(commandsFile.Commands["hello"].Language, ShouldEqual, "python")
+		c.So(commandsFile.Commands["hello"].Exec, ShouldEqual, "print(\"hello\")\n")
+
+		// Taskfile
+		taskfile := filepath.Join(dir, "Taskfile.yml")
+		c.So(ioutil.WriteFile(taskfile, []byte(`version: '3'
+
+vars:
+  BINARY: app
+  REV:
+    sh: git rev-parse HEAD
+
+includes:
+  docs: ./docs
+
+tasks:
+  go:build:
+    desc: build the binary
+    deps: ['go:generate']
+    vars:
+      GOOS: linux
+    requires:
+      vars: [VERSION]
+    cmds:
+      - go build -o {{.BINARY}} -ldflags "-X main.version={{.VERSION}}"
+      - task: go:lint
+        vars: {STRICT: true}
+    generates:
+      - '{{.BINARY}}'
+
+  go:generate: go generate ./...
+
+  go:lint:
+    cmds:
+      - cmd: golangci-lint run {{.CLI_ARGS}}
+        ignore_error: true
+`), 0644), ShouldBeNil)
+
+		report, err = importTasks(importTask, taskfile, commandsPath)
+		c.So(err, ShouldBeNil)
+		c.So(report.commands, ShouldResemble, []string{"go-build", "go-generate", "go-lint"})
+		c.So(report.globals, ShouldResemble, []string{"BINARY", "REV"})
+		c.So(issues(report), ShouldContain, "8: includes not converted")
+		c.So(issues(report), ShouldContain, "28: template not converted: {{.CLI_ARGS}}")
+
+		commandsFile = load()
+		c.So(commandsFile.Globals["REV"], ShouldEqual, "$(git rev-parse HEAD)")
+
+		build := commandsFile.Commands["go-build"]
+		c.So(build.Description, ShouldEqual, "build the binary")
+		c.So(build.Dependencies, ShouldResemble, []string{"go-generate"})
+		c.So(build.Arguments, ShouldResemble, []string{"VERSION:String", "GOOS:String? = linux"})
+		c.So(build.Outputs, ShouldResemble, []string{"${BINARY}"})
+		c.So(build.Exec, ShouldEqual, "go build -o ${BINARY} -ldflags \"-X main.version=${VERSION}\"\nzeus go-lint STRICT=true\n")
+		c.So(commandsFile.Commands["go-lint"].Exec, ShouldEqual, "golangci-lint run {{.CLI_ARGS}} || true\n")
+
+		// the names are taken now
+		report, err = importTasks(importTask, taskfile, commandsPath)
+		c.So(err, ShouldEqual, ErrNothingToMigrate)
+		c.So(issues(report), ShouldContain, "12: task not migrated, the name go-build is already taken")
+
+		_, err = importTasks("gradle", taskfile, commandsPath)
+		c.So(err, ShouldEqual, ErrUnknownImportFormat)
+		c.So(handleImportCommand([]string{"import", "gradle"}), ShouldEqual, ErrUnknownImportFormat)
+
+		// print the overview
+		c.So(handleImportCommand([]string{"import"}), ShouldBeNil)
+	})
+}
+
+func TestGraph(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing dependency graph", t, func(c C) {
+
+		os.Remove("tests/bin/dependency1")
+		os.Remove("tests/bin/dependency2")
+
+		graph, err := newDependencyGraph("dependency2")
+		c.So(err, ShouldBeNil)
+		c.So(len(graph.Nodes), ShouldEqual, 2)
+		c.So(graph.Nodes[0].Name, ShouldEqual, "dependency2")
+		c.So(graph.Nodes[1].Outputs, ShouldResemble, []string{"tests/bin/dependency1"})
+		c.So(graph.Edges, ShouldResemble, []*graphEdge{{From: "dependency1", To: "dependency2", Args: []string{}}})
+
+		// cycles are resolved once
+		graph, err = newDependencyGraph("cycle1")
+		c.So(err, ShouldBeNil)
+		c.So(len(graph.Nodes), ShouldEqual, 2)
+		c.So(len(graph.Edges), ShouldEqual, 2)
+
+		// the whole graph contains all commands
+		graph, err = newDependencyGraph("")
+		c.So(err, ShouldBeNil)
+		c.So(len(graph.Nodes), ShouldEqual, cmdMap.length())
+
+		_, err = newDependencyGraph("unknown")
+		c.So(err, ShouldNotBeNil)
+
+		// run twice, the outputs of dependency1 exist in the second run
+		handleLine("dependency2")
+		handleLine("dependency2")
+		handleLine("fail")
+		c.So(commandStates.get("dependency1"), ShouldEqual, stateSkipped)
+		c.So(commandStates.get("dependency2"), ShouldEqual, stateFinished)
+		c.So(commandStates.get("fail"), ShouldEqual, stateFailed)
+
+		graph, err = newDependencyGraph("dependency2")
+		c.So(err, ShouldBeNil)
+
+		dot, err := graph.render(graphDOT)
+		c.So(err, ShouldBeNil)
+		c.So(dot, ShouldStartWith, "digraph zeus {\n")
+		c.So(dot, ShouldContainSubstring, `"dependency1" [label="dependency1", tooltip="test dependencies", peripheries=2, xlabel="tests/bin/dependency1", fillcolor="#d3d3d3"];`)
+		c.So(dot, ShouldContainSubstring, `"dependency2" [label="dependency2", tooltip="test dependencies", fillcolor="#98fb98"];`)
+		c.So(dot, ShouldContainSubstring, `"dependency1" -> "dependency2";`)
+
+		mermaid, err := graph.render(graphMermaid)
+		c.So(err, ShouldBeNil)
+		c.So(mermaid, ShouldStartWith, "graph LR\n")
+		c.So(mermaid, ShouldContainSubstring, "    dependency1 --> dependency2\n")
+		c.So(mermaid, ShouldContainSubstring, "    class dependency1 outputs\n")
+		c.So(mermaid, ShouldContainSubstring, "    class dependency1 skipped\n")
+
+		// dependency arguments are edge labels
+		c.So(mermaidID("build-linux"), ShouldEqual, "build_linux")
+		argGraph := &dependencyGraph{
+			Nodes: []*graphNode{{Name: "greet", Async: true}, {Name: "release"}},
+			Edges: []*graphEdge{{From: "greet", To: "release", Args: []string{"name=zeus"}}},
+		}
+		c.So(argGraph.dot(), ShouldContainSubstring, `"greet" [label="greet", style="rounded,filled,dashed"];`)
+		c.So(argGraph.dot(), ShouldContainSubstring, `"greet" -> "release" [label="name=zeus"];`)
+		c.So(argGraph.mermaid(), ShouldContainSubstring, `    greet -- "name=zeus" --> release`)
+		c.So(argGraph.mermaid(), ShouldContainSubstring, "    class greet async\n")
+
+		out, err := graph.render(graphJSON)
+		c.So(err, ShouldBeNil)
+
+		var decoded dependencyGraph
+		c.So(json.Unmarshal([]byte(out), &decoded), ShouldBeNil)
+		c.So(decoded.Nodes[1].State, ShouldEqual, stateSkipped)
+
+		_, err = graph.render("svg")
+		c.So(err, ShouldEqual, ErrUnknownGraphFormat)
+
+		// states of earlier invocations are taken from the run history
+		commandStates.Lock()
+		delete(commandStates.items, "cycle1")
+		delete(commandStates.items, "cycle2")
+		commandStates.Unlock()
+
+		c.So(writeHistory([]*historyRun{{
+			ID:    "graph",
+			Chain: "cycle1",
+			Commands: []*historyEntry{
+				{Name: "cycle1", Status: runOK},
+				{Name: "cycle2", Status: runSkipped},
+			},
+		}}), ShouldBeNil)
+
+		graph, err = newDependencyGraph("cycle1")
+		c.So(err, ShouldBeNil)
+		c.So(graph.Nodes[0].State, ShouldEqual, stateFinished)
+		c.So(graph.Nodes[1].State, ShouldEqual, stateSkipped)
+
+		// states of this process take precedence
+		states := lastCommandStates()
+		c.So(states["fail"], ShouldEqual, stateFailed)
+
+		os.Remove(historyPath())
+		os.Remove(zeusDir + "/history.lock")
+
+		// web interface
+		w := httptest.NewRecorder()
+		graphHandler(w, httptest.NewRequest("GET", "/api/graph?command=dependency2", nil))
+		c.So(w.Code, ShouldEqual, 200)
+		c.So(w.Header().Get("Content-Type"), ShouldEqual, "application/json")
+		c.So(json.Unmarshal(w.Body.Bytes(), &decoded), ShouldBeNil)
+		c.So(len(decoded.Nodes), ShouldEqual, 2)
+
+		w = httptest.NewRecorder()
+		graphHandler(w, httptest.NewRequest("GET", "/api/graph?command=unknown", nil))
+		c.So(w.Code, ShouldEqual, 404)
+
+		c.So(handleGraphCommand([]string{"graph", "mermaid", "dependency2"}), ShouldBeNil)
+		c.So(handleGraphCommand([]string{"graph", "dependency2", "greet"}), ShouldEqual, ErrInvalidUsage)
+
+		os.Remove("tests/bin/dependency1")
+		os.Remove("tests/bin/dependency2")
+	})
+}
+
+func TestTrace(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing execution traces", t, func(c C) {
+
+		var (
+			chromePath = filepath.Join(os.TempDir(), "zeus-trace.json")
+			otlpPath   = filepath.Join(os.TempDir(), "zeus-trace-otlp.json")
+		)
+
+		os.Remove("tests/bin/dependency1")
+		os.Remove("tests/bin/dependency2")
+
+		startTrace(chromePath, otlpPath)
+		handleLine("dependency2")
+		handleLine("dependency2 -> greet name=trace")
+		handleLine("fail")
+		flushTrace()
+
+		// tracing is disabled after writing the files
+		c.So(getTracer(), ShouldBeNil)
+
+		b, err := ioutil.ReadFile(chromePath)
+		c.So(err, ShouldBeNil)
+
+		var chrome chromeTraceFile
+		c.So(json.Unmarshal(b, &chrome), ShouldBeNil)
+		c.So(chrome.DisplayTimeUnit, ShouldEqual, "ms")
+
+		var (
+			spans = make(map[string][]*chromeTraceEvent, 0)
+			ids   = make(map[float64]*chromeTraceEvent, 0)
+		)
+		for _, e := range chrome.TraceEvents {
+			if e.Ph == "X" {
+				spans[e.Name] = append(spans[e.Name], e)
+				ids[e.Args["id"].(float64)] = e
+			}
+		}
+
+		// first run executes the dependency
+		c.So(len(spans["dependency2"]), ShouldEqual, 2)
+		c.So(spans["dependency2"][0].Cat, ShouldEqual, spanCommand)
+		c.So(spans["dependency2"][0].Args["exitCode"], ShouldEqual, 0)
+		c.So(spans["dependency2"][0].Args["status"], ShouldEqual, spanOK)
+
+		dep := spans["dependency1"][0]
+		c.So(dep.Cat, ShouldEqual, spanDependency)
+		c.So(ids[dep.Args["parent"].(float64)], ShouldEqual, spans["dependency2"][0])
+		c.So(dep.Tid, ShouldEqual, spans["dependency2"][0].Tid)
+		c.So(dep.Ts, ShouldBeGreaterThanOrEqualTo, spans["dependency2"][0].Ts)
+
+		// second run skips it
+		skipped := spans["dependency1"][1]
+		c.So(skipped.Args["status"], ShouldEqual, spanSkipped)
+		c.So(skipped.Args["reason"], ShouldEqual, "all named outputs exist")
+
+		// chains are the parent of their commands
+		chain := spans["dependency2 -> greet"][0]
+		c.So(chain.Cat, ShouldEqual, spanChain)
+		c.So(ids[spans["greet"][0].Args["parent"].(float64)], ShouldEqual, chain)
+		c.So(spans["greet"][0].Args["args"], ShouldEqual, "name=trace")
+
+		// failures record the exit status
+		c.So(spans["fail"][0].Args["status"], ShouldEqual, spanError)
+		c.So(spans["fail"][0].Args["exitCode"], ShouldEqual, 3)
+		c.So(spans["fail"][0].Args["error"], ShouldEqual, "exit status 3")
+
+		b, err = ioutil.ReadFile(otlpPath)
+		c.So(err, ShouldBeNil)
+
+		var otlp otlpTraceFile
+		c.So(json.Unmarshal(b, &otlp), ShouldBeNil)
+		c.So(len(otlp.ResourceSpans), ShouldEqual, 1)
+		c.So(otlp.ResourceSpans[0].Resource.Attributes[0].Value["stringValue"], ShouldEqual, "zeus")
+
+		otlpSpans := otlp.ResourceSpans[0].ScopeSpans[0].Spans
+		c.So(len(otlpSpans), ShouldEqual, len(ids))
+
+		bySpanID := make(map[string]*otlpSpan, 0)
+		for _, s := range otlpSpans {
+			c.So(len(s.TraceID), ShouldEqual, 32)
+			c.So(len(s.SpanID), ShouldEqual, 16)
+			bySpanID[s.SpanID] = s
+		}
+		for _, s := range otlpSpans {
+			switch s.Name {
+			case "dependency1":
+				c.So(bySpanID[s.ParentSpanID].Name, ShouldEqual, "dependency2")
+			case "fail":
+				c.So(s.Status.Code, ShouldEqual, otlpStatusError)
+				c.So(s.Attributes, ShouldContain, &otlpAttribute{Key: "zeus.exitCode", Value: map[string]interface{}{"intValue": "3"}})
+			case "greet":
+				c.So(s.Status.Code, ShouldEqual, otlpStatusOK)
+			}
+		}
+
+		os.Remove(chromePath)
+		os.Remove(otlpPath)
+		os.Remove("tests/bin/dependency1")
+		os.Remove("tests/bin/dependency2")
+	})
+}
+
+func TestHistory(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing the run history", t, func(c C) {
+
+		conf.Lock()
+		conf.fields.RunHistory = true
+		conf.Unlock()
+
+		os.Remove(historyPath())
+		os.Remove("tests/bin/dependency1")
+		os.Remove("tests/bin/dependency2")
+
+		handleLine("dependency2")
+		handleLine("dependency2")
+		handleLine("fail")
+		handleLine("dependency2 -> fail")
+
+		runs, err := loadHistory()
+		c.So(err, ShouldBeNil)
+		c.So(len(runs), ShouldEqual, 4)
+
+		// first run executes the dependency
+		c.So(runs[0].Chain, ShouldEqual, "dependency2")
+		c.So(runs[0].Status, ShouldEqual, runOK)
+		c.So(len(runs[0].ID), ShouldEqual, 8)
+		c.So(len(runs[0].Commands), ShouldEqual, 2)
+		c.So(runs[0].Commands[1].Name, ShouldEqual, "dependency1")
+		c.So(runs[0].Commands[1].Dependency, ShouldBeTrue)
+		c.So(runs[0].Commands[1].Status, ShouldEqual, runOK)
+		c.So(runs[0].Duration, ShouldBeGreaterThanOrEqualTo, runs[0].Commands[1].Duration)
+
+		// second run skips it
+		c.So(runs[1].Commands[1].Status, ShouldEqual, runSkipped)
+
+		// failures record the exit code
+		c.So(runs[2].Status, ShouldEqual, runFailed)
+		c.So(runs[2].ExitCode, ShouldEqual, 3)
+		c.So(runs[2].Commands[0].ExitCode, ShouldEqual, 3)
+
+		// chains are a single run
+		c.So(runs[3].Chain, ShouldEqual, "dependency2 -> fail")
+		c.So(runs[3].Status, ShouldEqual, runFailed)
+		c.So(runs[3].ExitCode, ShouldEqual, 3)
+		c.So(len(runs[3].Commands), ShouldEqual, 3)
+
+		// filters
+		f, err := parseHistoryFilter([]string{"status=failed"})
+		c.So(err, ShouldBeNil)
+		c.So(len(f.apply(runs)), ShouldEqual, 2)
+
+		f, err = parseHistoryFilter([]string{"dependency1", "since=1h"})
+		c.So(err, ShouldBeNil)
+		c.So(len(f.apply(runs)), ShouldEqual, 3)
+
+		f, err = parseHistoryFilter([]string{"command=greet"})
+		c.So(err, ShouldBeNil)
+		c.So(len(f.apply(runs)), ShouldEqual, 0)
+
+		_, err = parseHistoryFilter([]string{"status=unknown"})
+		c.So(err, ShouldNotBeNil)
+		_, err = parseHistoryFilter([]string{"foo=bar"})
+		c.So(err, ShouldNotBeNil)
+
+		// statistics
+		stats := collectStats(runs)
+		c.So(stats["dependency1"].runs, ShouldEqual, 1)
+		c.So(stats["dependency1"].skipped, ShouldEqual, 2)
+		c.So(stats["fail"].runs, ShouldEqual, 2)
+		c.So(stats["fail"].failureRate(), ShouldEqual, 1)
+		c.So(stats["dependency2"].failureRate(), ShouldEqual, 0)
+
+		var durations []time.Duration
+		for i := 10; i > 0; i-- {
+			durations = append(durations, time.Duration(i)*time.Second)
+		}
+		c.So(percentile(durations, 50), ShouldEqual, 5*time.Second)
+		c.So(percentile(durations, 95), ShouldEqual, 10*time.Second)
+		c.So(percentile(nil, 95), ShouldEqual, 0)
+
+		s := &commandStats{durations: durations}
+		_, ok := s.trend()
+		c.So(ok, ShouldBeFalse)
+
+		s.durations = append([]time.Duration{2 * time.Second}, s.durations...)
+		trend, ok := s.trend()
+		c.So(ok, ShouldBeTrue)
+		c.So(trend, ShouldEqual, 1.5)
+
+		// ages
+		d, err := parseAge("30d")
+		c.So(err, ShouldBeNil)
+		c.So(d, ShouldEqual, 30*24*time.Hour)
+
+		d, err = parseAge("2w")
+		c.So(err, ShouldBeNil)
+		c.So(d, ShouldEqual, 14*24*time.Hour)
+
+		d, err = parseAge("12h")
+		c.So(err, ShouldBeNil)
+		c.So(d, ShouldEqual, 12*time.Hour)
+
+		_, err = parseAge("soon")
+		c.So(err, ShouldEqual, ErrInvalidAge)
+
+		// builtin
+		c.So(handleHistoryCommand([]string{"history"}), ShouldBeNil)
+		c.So(handleHistoryCommand([]string{"history", "status=failed", "limit=1"}), ShouldBeNil)
+		c.So(handleHistoryCommand([]string{"history", "stats", "fail"}), ShouldBeNil)
+		c.So(handleHistoryCommand([]string{"history", "show", runs[3].ID}), ShouldBeNil)
+		c.So(handleHistoryCommand([]string{"history", "show", "unknown"}), ShouldEqual, ErrUnknownRun)
+		c.So(handleHistoryCommand([]string{"history", "since=yesterday"}), ShouldEqual, ErrInvalidAge)
+
+		// pruning
+		runs[0].Start = time.Now().Add(-48 * time.Hour)
+		c.So(writeHistory(runs), ShouldBeNil)
+
+		n, err := pruneHistory(0, 24*time.Hour)
+		c.So(err, ShouldBeNil)
+		c.So(n, ShouldEqual, 1)
+
+		n, err = pruneHistory(2, 0)
+		c.So(err, ShouldBeNil)
+		c.So(n, ShouldEqual, 1)
+
+		pruned, err := loadHistory()
+		c.So(err, ShouldBeNil)
+		c.So(len(pruned), ShouldEqual, 2)
+		c.So(pruned[1].ID, ShouldEqual, runs[3].ID)
+
+		c.So(handleHistoryCommand([]string{"history", "prune", "limit=1"}), ShouldBeNil)
+		pruned, err = loadHistory()
+		c.So(err, ShouldBeNil)
+		c.So(len(pruned), ShouldEqual, 1)
+
+		// the history is only pruned on startup if a rule is violated
+		c.So(historyExceeds(1, 0), ShouldBeFalse)
+		c.So(historyExceeds(0, time.Hour), ShouldBeFalse)
+		c.So(writeHistory(runs), ShouldBeNil)
+		c.So(historyExceeds(len(runs), 0), ShouldBeFalse)
+		c.So(historyExceeds(len(runs)-1, 0), ShouldBeTrue)
+		c.So(historyExceeds(0, 24*time.Hour), ShouldBeTrue)
+
+		// runs appended while pruning are kept
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				b, _ := json.Marshal(&historyRun{ID: "append" + strconv.Itoa(i), Start: time.Now()})
+				appendHistory(b)
+			}(i)
+		}
+		for i := 0; i < 5; i++ {
+			_, err := pruneHistory(0, 24*time.Hour)
+			c.So(err, ShouldBeNil)
+		}
+		wg.Wait()
+
+		pruned, err = loadHistory()
+		c.So(err, ShouldBeNil)
+		c.So(len(pruned), ShouldEqual, len(runs)-1+20)
+
+		info, err := os.Stat(historyPath())
+		c.So(err, ShouldBeNil)
+		c.So(info.Mode().Perm(), ShouldEqual, os.FileMode(0644))
+
+		c.So(handleHistoryCommand([]string{"history", "clear"}), ShouldBeNil)
+		pruned, err = loadHistory()
+		c.So(err, ShouldBeNil)
+		c.So(len(pruned), ShouldEqual, 0)
+
+		conf.Lock()
+		conf.fields.RunHistory = false
+		conf.Unlock()
+
+		os.Remove(historyPath())
+		os.Remove(zeusDir + "/history.lock")
+		os.Remove("tests/bin/dependency1")
+		os.Remove("tests/bin/dependency2")
+	})
+}
+
+func TestAPI(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing the REST API", t, func(c C) {
+
+		router := createRouter()
+
+		request := func(method, path, body string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+			return w
+		}
+
+		// wait for a run to finish and return its status
+		await := func(id string) *apiRunStatus {
+			run, ok := apiRuns.get(id)
+			c.So(ok, ShouldBeTrue)
+			<-run.done
+
+			w := request("GET", "/api/runs/"+id, "")
+			c.So(w.Code, ShouldEqual, 200)
+
+			var status apiRunStatus
+			c.So(json.Unmarshal(w.Body.Bytes(), &status), ShouldBeNil)
+			return &status
+		}
+
+		// commands and their argument schemas
+		w := request("GET", "/api/commands", "")
+		c.So(w.Code, ShouldEqual, 200)
+
+		var commands []*apiCommand
+		c.So(json.Unmarshal(w.Body.Bytes(), &commands), ShouldBeNil)
+		c.So(len(commands), ShouldEqual, cmdMap.length())
+
+		found := make(map[string]*apiCommand, 0)
+		for _, cmd := range commands {
+			found[cmd.Name] = cmd
+		}
+		c.So(found["greet"].Description, ShouldEqual, "print a greeting")
+		c.So(found["greet"].Arguments.Properties["name"].Type, ShouldEqual, "string")
+		c.So(found["greet"].Arguments.Properties["name"].Default, ShouldEqual, "world")
+		c.So(found["greet"].Arguments.Required, ShouldBeEmpty)
+		c.So(found["pause"].Arguments.Properties["seconds"].Type, ShouldEqual, "integer")
+		c.So(found["pause"].Arguments.Properties["seconds"].Default, ShouldEqual, 30)
+		c.So(found["dependency2"].Dependencies, ShouldResemble, []string{"dependency1"})
+
+		// run a command with arguments
+		w = request("POST", "/api/run", `{"chain": "greet", "args": {"greet": {"name": "api"}}}`)
+		c.So(w.Code, ShouldEqual, 202)
+
+		var started apiRunStatus
+		c.So(json.Unmarshal(w.Body.Bytes(), &started), ShouldBeNil)
+		c.So(w.Header().Get("Location"), ShouldEqual, "/api/runs/"+started.ID)
+		c.So(started.Chain, ShouldEqual, "greet name=api")
+
+		status := await(started.ID)
+		c.So(status.State, ShouldEqual, stateFinished)
+		c.So(status.Output, ShouldContainSubstring, "hello api")
+		c.So(status.End, ShouldNotBeNil)
+
+		// failures
+		w = request("POST", "/api/run", `{"chain": "greet -> fail"}`)
+		c.So(w.Code, ShouldEqual, 202)
+		c.So(json.Unmarshal(w.Body.Bytes(), &started), ShouldBeNil)
+
+		status = await(started.ID)
+		c.So(status.State, ShouldEqual, stateFailed)
+		c.So(status.Error, ShouldEqual, "exit status 3")
+		c.So(status.Output, ShouldContainSubstring, "hello world")
+
+		// invalid requests
+		for _, body := range []string{
+			`{"chain": "unknown"}`,
+			`{"chain": ""}`,
+			`{"chain": "greet", "args": {"fail": {"name": "x"}}}`,
+			`{"chain": "greet", "args": {"greet": {"age": 1}}}`,
+			`{"chain": "greet", "args": {"greet": {"name": "two words"}}}`,
+			`{"chain": "pause seconds=soon"}`,
+			`not json`,
+		} {
+			w = request("POST", "/api/run", body)
+			c.So(w.Code, ShouldEqual, 400)
+			c.So(w.Body.String(), ShouldNotContainSubstring, "\u001b")
+		}
+
+		// cancel a run
+		w = request("POST", "/api/run", `{"chain": "pause"}`)
+		c.So(w.Code, ShouldEqual, 202)
+		c.So(json.Unmarshal(w.Body.Bytes(), &started), ShouldBeNil)
+
+		time.Sleep(200 * time.Millisecond)
+
+		w = request("GET", "/api/procs", "")
+		c.So(w.Code, ShouldEqual, 200)
+
+		var procs []*apiProcess
+		c.So(json.Unmarshal(w.Body.Bytes(), &procs), ShouldBeNil)
+
+		var paused bool
+		for _, p := range procs {
+			if p.Command == "pause" {
+				paused = true
+			}
+		}
+		c.So(paused, ShouldBeTrue)
+
+		w = request("DELETE", "/api/runs/"+started.ID, "")
+		c.So(w.Code, ShouldEqual, 200)
+		c.So(json.Unmarshal(w.Body.Bytes(), &status), ShouldBeNil)
+		c.So(status.State, ShouldEqual, runCancelled)
+
+		// finished runs can not be cancelled
+		w = request("DELETE", "/api/runs/"+started.ID, "")
+		c.So(w.Code, ShouldEqual, 409)
+
+		w = request("GET", "/api/runs/unknown", "")
+		c.So(w.Code, ShouldEqual, 404)
+
+		w = request("GET", "/api/runs", "")
+		c.So(w.Code, ShouldEqual, 200)
+
+		var runs []*apiRunStatus
+		c.So(json.Unmarshal(w.Body.Bytes(), &runs), ShouldBeNil)
+		c.So(len(runs), ShouldEqual, 3)
+		c.So(runs[2].ID, ShouldEqual, started.ID)
+		c.So(runs[2].Output, ShouldBeEmpty)
+	})
+}
+
+func TestStream(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing output streams", t, func(c C) {
+
+		// graphic rendition
+		a := &ansiConverter{}
+		c.So(a.convert([]byte("\x1b[31mred\x1b[0m plain")), ShouldEqual, `<span class="ansi-fg-1">red</span> plain`)
+		c.So(a.convert([]byte("\x1b[1;94mbright\x1b[22m normal")), ShouldEqual, `<span class="ansi-bold ansi-fg-12">bright</span><span class="ansi-fg-12"> normal</span>`)
+		c.So(a.convert([]byte("\x1b[39;4mline\x1b[m")), ShouldEqual, `<span class="ansi-underline">line</span>`)
+
+		// the style is kept across chunks and incomplete sequences are buffered
+		a = &ansiConverter{}
+		c.So(a.convert([]byte("\x1b[1")), ShouldEqual, "")
+		c.So(a.convert([]byte(";32mok")), ShouldEqual, `<span class="ansi-bold ansi-fg-2">ok</span>`)
+		c.So(a.convert([]byte("more\x1b[0m")), ShouldEqual, `<span class="ansi-bold ansi-fg-2">more</span>`)
+
+		// incomplete UTF-8
+		c.So(a.convert([]byte{0xc3}), ShouldEqual, "")
+		c.So(a.convert([]byte{0xbc}), ShouldEqual, "ü")
+
+		// 256 colors and true color
+		a = &ansiConverter{}
+		c.So(a.convert([]byte("\x1b[38;5;196mx\x1b[0m")), ShouldEqual, `<span style="color:#ff0000">x</span>`)
+		c.So(a.convert([]byte("\x1b[38;5;9mx\x1b[0m")), ShouldEqual, `<span class="ansi-fg-9">x</span>`)
+		c.So(a.convert([]byte("\x1b[48;2;1;2;3mx\x1b[0m")), ShouldEqual, `<span style="background-color:#010203">x</span>`)
+
+		// markup is escaped, other sequences and carriage returns are dropped
+		c.So(a.convert([]byte("<b>&\r\n")), ShouldEqual, "&lt;b&gt;&amp;\n")
+		c.So(a.convert([]byte("\x1b[2K\x1b]0;title\x07x")), ShouldEqual, "x")
+
+		// runs are only streamed while the web interface is running
+		socketstoreMutex.Lock()
+		store := socketstore
+		socketstore = nil
+		socketstoreMutex.Unlock()
+
+		ctx := newExecContext()
+		streamCtx, s := ctx.startStream(randomString(), "greet")
+		c.So(s, ShouldBeNil)
+		c.So(streamCtx, ShouldEqual, ctx)
+
+		socketstoreMutex.Lock()
+		socketstore = NewSocketStore()
+		socketstoreMutex.Unlock()
+
+		var buf bytes.Buffer
+		id := randomString()
+		streamCtx, s = newOutputContext(&buf).startStream(id, "greet")
+		c.So(s, ShouldNotBeNil)
+
+		// nested invocations use the stream of the run
+		nested, n := streamCtx.startStream(randomString(), "greet")
+		c.So(n, ShouldBeNil)
+		c.So(nested, ShouldEqual, streamCtx)
+
+		cmd, err := cmdMap.getCommand("greet")
+		c.So(err, ShouldBeNil)
+		err = cmd.Run(streamCtx.withArgs([]string{"name=\x1b[1mzeus\x1b[0m"}), false)
+		c.So(err, ShouldBeNil)
+		s.finish(finalRunState(streamCtx, err))
+
+		// the output is written to the context and the stream
+		c.So(buf.String(), ShouldContainSubstring, "hello \x1b[1mzeus")
+		got, ok := streams.get(id)
+		c.So(ok, ShouldBeTrue)
+		c.So(got.state, ShouldEqual, stateFinished)
+		c.So(strings.Join(got.chunks, ""), ShouldContainSubstring, `hello <span class="ansi-bold">zeus</span>`)
+
+		m := runsMessage()
+		c.So(m.Type, ShouldEqual, "runs")
+		c.So(m.Runs[len(m.Runs)-1].ID, ShouldEqual, id)
+		c.So(m.String(), ShouldContainSubstring, `"state":"finished"`)
+
+		// final states
+		c.So(finalRunState(newExecContext(), nil), ShouldEqual, stateFinished)
+		c.So(finalRunState(newExecContext(), ErrCancelled), ShouldEqual, runCancelled)
+		c.So(finalRunState(newExecContext(), ErrRunFinished), ShouldEqual, stateFailed)
+
+		socketstoreMutex.Lock()
+		socketstore = store
+		socketstoreMutex.Unlock()
+	})
+}
+
+func TestWebAuth(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing the web server authentication", t, func(c C) {
+
+		handler := secureHandler(createRouter())
+
+		request := func(req *http.Request) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			return w
+		}
+
+		// the session token is required
+		w := request(httptest.NewRequest("GET", "/api/commands", nil))
+		c.So(w.Code, ShouldEqual, http.StatusUnauthorized)
+		c.So(w.Body.String(), ShouldContainSubstring, `"error"`)
+
+		req := httptest.NewRequest("GET", "/api/commands", nil)
+		req.Header.Set("Authorization", "Bearer "+newToken())
+		c.So(request(req).Code, ShouldEqual, http.StatusUnauthorized)
+
+		// API clients pass the token as header and need no CSRF token
+		req = httptest.NewRequest("GET", "/api/commands", nil)
+		req.Header.Set("Authorization", "Bearer "+sessionToken)
+		c.So(request(req).Code, ShouldEqual, http.StatusOK)
+
+		req = httptest.NewRequest("DELETE", "/api/runs/unknown", nil)
+		req.Header.Set("Authorization", "Bearer "+sessionToken)
+		c.So(request(req).Code, ShouldEqual, http.StatusNotFound)
+
+		// the link with the token sets the cookies and removes the token from the address
+		w = request(httptest.NewRequest("GET", "/wiki?token="+sessionToken+"&page=1", nil))
+		c.So(w.Code, ShouldEqual, http.StatusFound)
+		c.So(w.Header().Get("Location"), ShouldEqual, "/wiki?page=1")
+
+		cookies := make(map[string]*http.Cookie, 0)
+		for _, ck := range w.Result().Cookies() {
+			cookies[ck.Name] = ck
+		}
+		c.So(cookies[tokenCookie].Value, ShouldEqual, sessionToken)
+		c.So(cookies[tokenCookie].HttpOnly, ShouldBeTrue)
+		c.So(cookies[tokenCookie].SameSite, ShouldEqual, http.SameSiteStrictMode)
+		c.So(cookies[csrfCookie].Value, ShouldEqual, csrfToken)
+		c.So(cookies[csrfCookie].HttpOnly, ShouldBeFalse)
+
+		browser := func(method, path, origin, csrf string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, path, nil)
+			req.AddCookie(cookies[tokenCookie])
+			if origin != "" {
+				req.Header.Set("Origin", origin)
+			}
+			if csrf != "" {
+				req.Header.Set(csrfHeader, csrf)
+			}
+			return request(req)
+		}
+
+		// the browser is authenticated by cookie
+		c.So(browser("GET", "/api/commands", "", "").Code, ShouldEqual, http.StatusOK)
+
+		// state changing requests need the CSRF token and the same origin, httptest requests are sent to example.com
+		c.So(browser("DELETE", "/api/runs/unknown", "", "").Code, ShouldEqual, http.StatusForbidden)
+		c.So(browser("DELETE", "/api/runs/unknown", "", csrfToken).Code, ShouldEqual, http.StatusForbidden)
+		c.So(browser("DELETE", "/api/runs/unknown", "http://evil.com", csrfToken).Code, ShouldEqual, http.StatusForbidden)
+		c.So(browser("DELETE", "/api/runs/unknown", "http://example.com", "").Code, ShouldEqual, http.StatusForbidden)
+		c.So(browser("DELETE", "/api/runs/unknown", "http://example.com", newToken()).Body.String(), ShouldContainSubstring, ErrInvalidCSRFToken.Error())
+		c.So(browser("DELETE", "/api/runs/unknown", "http://example.com", csrfToken).Code, ShouldEqual, http.StatusNotFound)
+		c.So(browser("POST", "/quit", "http://evil.com", csrfToken).Code, ShouldEqual, http.StatusForbidden)
+		c.So(browser("POST", glueAjaxPath, "http://evil.com", "").Code, ShouldEqual, http.StatusForbidden)
+
+		// bind address
+		c.So(listenAddress(), ShouldEqual, "127.0.0.1:8080")
+		c.So(exposedBindAddress("127.0.0.1"), ShouldBeFalse)
+		c.So(exposedBindAddress("::1"), ShouldBeFalse)
+		c.So(exposedBindAddress("localhost"), ShouldBeFalse)
+		c.So(exposedBindAddress(""), ShouldBeTrue)
+		c.So(exposedBindAddress("0.0.0.0"), ShouldBeTrue)
+		c.So(exposedBindAddress("192.168.1.10"), ShouldBeTrue)
+		c.So(webURL("/wiki"), ShouldEqual, "http://localhost:8080/wiki?token="+sessionToken)
+		c.So(webPageURL("/wiki"), ShouldEqual, "http://localhost:8080/wiki")
+
+		// self-signed certificate, reused as long as it is valid
+		dir, err := ioutil.TempDir("", "zeus-tls")
+		c.So(err, ShouldBeNil)
+
+		hosts := []string{"localhost", "127.0.0.1", "::1"}
+		cert, fp, err := loadOrCreateCertificate(dir, hosts)
+		c.So(err, ShouldBeNil)
+		c.So(len(fp), ShouldEqual, 95)
+
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		c.So(err, ShouldBeNil)
+		c.So(leaf.VerifyHostname("localhost"), ShouldBeNil)
+		c.So(leaf.VerifyHostname("127.0.0.1"), ShouldBeNil)
+		c.So(leaf.VerifyHostname("example.com"), ShouldNotBeNil)
+
+		info, err := os.Stat(filepath.Join(dir, "key.pem"))
+		c.So(err, ShouldBeNil)
+		c.So(info.Mode().Perm(), ShouldEqual, os.FileMode(0600))
+
+		_, reused, err := loadOrCreateCertificate(dir, hosts)
+		c.So(err, ShouldBeNil)
+		c.So(reused, ShouldEqual, fp)
+
+		// a new certificate is generated when a host is not covered
+		_, renewed, err := loadOrCreateCertificate(dir, append(hosts, "zeus.local"))
+		c.So(err, ShouldBeNil)
+		c.So(renewed, ShouldNotEqual, fp)
+
+		c.So(os.RemoveAll(dir), ShouldBeNil)
+	})
+}
+
+func TestSettings(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing the settings API", t, func(c C) {
+
+		router := createRouter()
+
+		request := func(method, path, body string) (*httptest.ResponseRecorder, *settings) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+			var s settings
+			if w.Code == http.StatusOK {
+				c.So(json.Unmarshal(w.Body.Bytes(), &s), ShouldBeNil)
+			}
+			return w, &s
+		}
+
+		// current settings
+		w, s := request("GET", "/api/settings", "")
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(s.Keys, ShouldContain, "Ctrl-A")
+		c.So(s.DateFormat, ShouldEqual, "02-01-2006")
+
+		fields := make(map[string]*settingsField, 0)
+		for _, f := range s.Config {
+			fields[f.Name] = f
+		}
+		c.So(fields["autoFormat"].Type, ShouldEqual, "bool")
+		c.So(fields["codeSnippetScope"].Type, ShouldEqual, "int")
+		c.So(fields["colorProfiles"], ShouldBeNil)
+
+		// config fields are validated like the config builtin
+		w, _ = request("PUT", "/api/config/unknownField", `{"value": 1}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		c.So(w.Body.String(), ShouldContainSubstring, ErrInvalidConfigField.Error())
+
+		w, _ = request("PUT", "/api/config/codeSnippetScope", `{"value": "abc"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+		w, _ = request("PUT", "/api/config/codeSnippetScope", `{"value": 20}`)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		conf.Lock()
+		c.So(conf.fields.CodeSnippetScope, ShouldEqual, 20)
+		conf.Unlock()
+		c.So(conf.set("codeSnippetScope", strconv.Itoa(int(fields["codeSnippetScope"].Value.(float64)))), ShouldBeNil)
+
+		w, _ = request("PUT", "/api/config/codeSnippetScope", `invalid`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+		// aliases
+		w, _ = request("POST", "/api/aliases", `{"name": "build", "command": "greet"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, _ = request("POST", "/api/aliases", `{"name": "webAlias", "command": "greet nam=x"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, s = request("POST", "/api/aliases", `{"name": "webAlias", "command": "greet name=web"}`)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(s.Aliases["webAlias"], ShouldEqual, "greet name=web")
+		c.So(aliasCompleter(""), ShouldContain, "webAlias")
+		w, s = request("DELETE", "/api/aliases/webAlias", "")
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(s.Aliases, ShouldNotContainKey, "webAlias")
+		w, _ = request("DELETE", "/api/aliases/webAlias", "")
+		c.So(w.Code, ShouldEqual, http.StatusNotFound)
+
+		// milestones
+		w, _ = request("POST", "/api/milestones", `{"name": "web", "date": "2012-12-12"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, s = request("POST", "/api/milestones", `{"name": "web", "date": "12-12-2012", "description": "from the web"}`)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(s.Milestones[len(s.Milestones)-1].Date, ShouldEqual, "12-12-2012")
+		c.So(s.Milestones[len(s.Milestones)-1].Description, ShouldEqual, "from the web")
+		w, _ = request("POST", "/api/milestones", `{"name": "web", "date": "12-12-2012"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, _ = request("PUT", "/api/milestones/web", `{"percent": 101}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, _ = request("PUT", "/api/milestones/unknown", `{"percent": 10}`)
+		c.So(w.Code, ShouldEqual, http.StatusNotFound)
+		w, s = request("PUT", "/api/milestones/web", `{"percent": 50}`)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(s.Milestones[len(s.Milestones)-1].PercentComplete, ShouldEqual, 50)
+		w, _ = request("DELETE", "/api/milestones/web", "")
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		w, _ = request("DELETE", "/api/milestones/web", "")
+		c.So(w.Code, ShouldEqual, http.StatusNotFound)
+
+		// deadline and author
+		w, _ = request("PUT", "/api/deadline", `{"date": "tomorrow"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, s = request("PUT", "/api/deadline", `{"date": "12-12-2012"}`)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(s.Deadline, ShouldEqual, "12-12-2012")
+		w, s = request("DELETE", "/api/deadline", "")
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(s.Deadline, ShouldEqual, "")
+
+		author := s.Author
+		w, _ = request("PUT", "/api/author", `{"name": " "}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, s = request("PUT", "/api/author", `{"name": "Web Author"}`)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(s.Author, ShouldEqual, "Web Author")
+		setAuthor(author)
+
+		// key bindings
+		w, _ = request("PUT", "/api/keys/Ctrl-Z", `{"command": "greet"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, s = request("PUT", "/api/keys/Ctrl-O", `{"command": "greet name=key"}`)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(s.KeyBindings["Ctrl-O"], ShouldEqual, "greet name=key")
+		w, s = request("DELETE", "/api/keys/Ctrl-O", "")
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(s.KeyBindings, ShouldNotContainKey, "Ctrl-O")
+
+		// events
+		w, _ = request("POST", "/api/events", `{"op": "OPEN", "path": "tests", "command": "greet"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, _ = request("POST", "/api/events", `{"op": "WRITE", "path": "does/not/exist", "command": "greet"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, _ = request("POST", "/api/events", `{"op": "WRITE", "path": "tests", "fileExtension": "go", "command": "greet"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w, s = request("POST", "/api/events", `{"op": "WRITE", "path": "tests", "fileExtension": ".go", "command": "greet name=event"}`)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+
+		var event *settingsEvent
+		for _, e := range s.Events {
+			if e.Command == "greet name=event" {
+				event = e
+			}
+		}
+		c.So(event, ShouldNotBeNil)
+		c.So(event.Op, ShouldEqual, "WRITE")
+
+		// wait for the watcher before removing the event
+		time.Sleep(50 * time.Millisecond)
+		w, _ = request("DELETE", "/api/events/"+event.ID, "")
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		w, _ = request("DELETE", "/api/events/"+event.ID, "")
+		c.So(w.Code, ShouldEqual, http.StatusNotFound)
+	})
+}
+
+func TestWiki(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing the wiki", t, func(c C) {
+
+		dir, err := ioutil.TempDir("", "zeus-wiki")
+		c.So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		// the secret is next to the wiki
+		wiki := filepath.Join(dir, "wiki")
+		c.So(os.MkdirAll(filepath.Join(wiki, "docs"), 0755), ShouldBeNil)
+		c.So(ioutil.WriteFile(filepath.Join(wiki, "INDEX.md"), []byte("# Index\n\n[Guide](/wiki/docs/GUIDE.md)\n"), 0644), ShouldBeNil)
+		c.So(ioutil.WriteFile(filepath.Join(wiki, "docs", "GUIDE.md"), []byte("# Guide\n\nlightning fast builds with zeus\n"), 0644), ShouldBeNil)
+		c.So(ioutil.WriteFile(filepath.Join(dir, "secret.md"), []byte("secret"), 0644), ShouldBeNil)
+		c.So(os.Symlink(filepath.Join(dir, "secret.md"), filepath.Join(wiki, "docs", "link.md")), ShouldBeNil)
+
+		original := wikiDir
+		wikiDir = wiki
+		defer func() {
+			wikiDir = original
+		}()
+
+		router := createRouter()
+
+		request := func(method, path, body string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+			return w
+		}
+
+		// pages outside of the wiki are rejected
+		for _, page := range []string{"docs/../../secret.md", "docs/", "docs/..", "../secret.md", "secret.md", "docs/link.md"} {
+			_, err := wikiFile(page)
+			c.So(err, ShouldNotBeNil)
+		}
+		path, err := wikiFile("docs/GUIDE.md")
+		c.So(err, ShouldBeNil)
+		c.So(path, ShouldEqual, filepath.Join(wiki, "docs", "GUIDE.md"))
+
+		w := request("GET", "/wiki/docs/..%2F..%2Fsecret.md", "")
+		c.So(w.Code, ShouldEqual, http.StatusNotFound)
+		w = request("GET", "/api/wiki/page?page=docs/link.md", "")
+		c.So(w.Code, ShouldEqual, http.StatusNotFound)
+
+		// pages are rendered with the editor
+		w = request("GET", "/wiki/docs/GUIDE.md", "")
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(w.Body.String(), ShouldContainSubstring, "<h1>Guide</h1>")
+		c.So(w.Body.String(), ShouldContainSubstring, `id="edit"`)
+
+		// raw HTML and unsafe links are not rendered
+		html := string(renderMarkdown([]byte("# Page\n\n<script>alert(1)</script>\n\n<img src=x onerror=alert(1)> [link](javascript:alert(1))\n")))
+		c.So(html, ShouldContainSubstring, "<h1>Page</h1>")
+		c.So(html, ShouldNotContainSubstring, "<script>")
+		c.So(html, ShouldNotContainSubstring, "onerror")
+		c.So(html, ShouldNotContainSubstring, `href="javascript:`)
+
+		// the command reference is generated from the commands
+		w = request("GET", "/wiki/commands", "")
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(w.Body.String(), ShouldNotContainSubstring, `id="edit"`)
+
+		commands := commandsMarkdown()
+		c.So(commands, ShouldContainSubstring, "## arguments\n\ntest optional command arguments")
+		c.So(commands, ShouldContainSubstring, "| user | String | yes | bob |")
+		c.So(commands, ShouldContainSubstring, "| password | String | no |  |")
+		c.So(commands, ShouldContainSubstring, "**Dependencies:** cycle1")
+		c.So(commands, ShouldContainSubstring, "increments the build number")
+
+		// search
+		search := func(q string) []*wikiSearchResult {
+			w := request("GET", "/api/wiki/search?q="+q, "")
+			c.So(w.Code, ShouldEqual, http.StatusOK)
+
+			var res []*wikiSearchResult
+			c.So(json.Unmarshal(w.Body.Bytes(), &res), ShouldBeNil)
+			return res
+		}
+
+		res := search("lightn+zeus")
+		c.So(len(res), ShouldEqual, 1)
+		c.So(res[0].Page, ShouldEqual, "docs/GUIDE.md")
+		c.So(res[0].Title, ShouldEqual, "Guide")
+		c.So(res[0].URL, ShouldEqual, "/wiki/docs/GUIDE.md")
+		c.So(res[0].Snippet, ShouldContainSubstring, "lightning fast builds")
+
+		c.So(len(search("lightning+nothing")), ShouldEqual, 0)
+		c.So(len(search("")), ShouldEqual, 0)
+
+		res = search("optional+arguments")
+		c.So(len(res), ShouldEqual, 1)
+		c.So(res[0].Page, ShouldEqual, wikiCommandsPage)
+
+		// editing
+		w = request("GET", "/api/wiki/page?page=docs/GUIDE.md", "")
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+
+		var source wikiPageSource
+		c.So(json.Unmarshal(w.Body.Bytes(), &source), ShouldBeNil)
+		c.So(source.Editable, ShouldBeTrue)
+		c.So(source.Markdown, ShouldStartWith, "# Guide")
+
+		w = request("PUT", "/api/wiki/page?page=docs/GUIDE.md", `{"markdown": "# Guide\n\nthunderous builds\n"}`)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+
+		b, err := ioutil.ReadFile(filepath.Join(wiki, "docs", "GUIDE.md"))
+		c.So(err, ShouldBeNil)
+		c.So(string(b), ShouldContainSubstring, "thunderous")
+
+		c.So(len(search("lightning")), ShouldEqual, 0)
+		c.So(len(search("thunder")), ShouldEqual, 1)
+
+		// new pages can be created
+		w = request("PUT", "/api/wiki/page?page=docs/NEW.md", `{"markdown": "# New\n\nthunder again\n"}`)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(len(search("thunder")), ShouldEqual, 2)
+
+		// generated pages, other files and paths outside the wiki can not be edited
+		w = request("PUT", "/api/wiki/page?page=commands", `{"markdown": "test"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		c.So(w.Body.String(), ShouldContainSubstring, ErrReadOnlyWikiPage.Error())
+		w = request("PUT", "/api/wiki/page?page=docs/page.html", `{"markdown": "test"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		w = request("PUT", "/api/wiki/page?page=docs/../../secret.md", `{"markdown": "test"}`)
+		c.So(w.Code, ShouldEqual, http.StatusBadRequest)
+		c.So(w.Body.String(), ShouldContainSubstring, ErrInvalidWikiPage.Error())
+
+		b, err = ioutil.ReadFile(filepath.Join(dir, "secret.md"))
+		c.So(err, ShouldBeNil)
+		c.So(string(b), ShouldEqual, "secret")
+	})
+}
+
+func TestReport(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing the report", t, func(c C) {
+
+		now := time.Date(2017, 12, 10, 15, 0, 0, 0, time.UTC)
+		deadline := time.Date(2017, 12, 12, 0, 0, 0, 0, time.UTC)
+		c.So(deadlineCountdown(deadline, now), ShouldEqual, "2 days left")
+		c.So(deadlineCountdown(deadline, now.AddDate(0, 0, 1)), ShouldEqual, "1 day left")
+		c.So(deadlineCountdown(deadline, now.AddDate(0, 0, 2)), ShouldEqual, "due today")
+		c.So(deadlineCountdown(deadline, now.AddDate(0, 0, 5)), ShouldEqual, "overdue by 3 days")
+
+		c.So(progressBar(50), ShouldEqual, "`"+strings.Repeat("█", 10)+strings.Repeat("░", 10)+"` 50%")
+		c.So(progressBar(150), ShouldEqual, "`"+strings.Repeat("█", 20)+"` 100%")
+		c.So(progressBar(-1), ShouldEqual, "`"+strings.Repeat("░", 20)+"` 0%")
+
+		c.So(markdownCell("a | b\nc"), ShouldEqual, "a \\| b c")
+
+		c.So(setDeadline("12-12-2017"), ShouldBeNil)
+		c.So(createMilestone("reportMilestone", "24-12-2017", "ship | it"), ShouldBeNil)
+		c.So(setMilestone("reportMilestone", "40"), ShouldBeNil)
+		defer func() {
+			removeDeadline()
+			removeMilestone("reportMilestone")
+		}()
+
+		md := reportMarkdownSource(now)
+		c.So(md, ShouldContainSubstring, "| Build Number | ")
+		c.So(md, ShouldContainSubstring, "| Deadline | 12-12-2017 (2 days left) |")
+		c.So(md, ShouldContainSubstring, "| reportMilestone | 24-12-2017 | "+progressBar(40)+" | ship \\| it |")
+		c.So(md, ShouldContainSubstring, "## Commands")
+		c.So(md, ShouldContainSubstring, "| arguments | ipAddr:String, password:String, port:Int? = 80, user:String? = bob | test optional command arguments |")
+
+		// reports are written to files, the format is detected by the extension
+		dir, err := ioutil.TempDir("", "zeus-report")
+		c.So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		c.So(handleReportCommand([]string{"report", filepath.Join(dir, "reports", "status.html")}), ShouldBeNil)
+		b, err := ioutil.ReadFile(filepath.Join(dir, "reports", "status.html"))
+		c.So(err, ShouldBeNil)
+		c.So(string(b), ShouldStartWith, "<!DOCTYPE html>")
+		c.So(string(b), ShouldContainSubstring, "<table>")
+		c.So(string(b), ShouldContainSubstring, "<h2>Milestones</h2>")
+
+		c.So(handleReportCommand([]string{"report", "markdown", filepath.Join(dir, "status.txt")}), ShouldBeNil)
+		b, err = ioutil.ReadFile(filepath.Join(dir, "status.txt"))
+		c.So(err, ShouldBeNil)
+		c.So(string(b), ShouldContainSubstring, "## Milestones")
+
+		c.So(handleReportCommand([]string{"report", "html", "markdown"}), ShouldEqual, ErrInvalidUsage)
+		c.So(handleReportCommand([]string{"report", "a", "b"}), ShouldEqual, ErrInvalidUsage)
+
+		_, err = generateReport("pdf")
+		c.So(err, ShouldNotBeNil)
+	})
+}
+
+func TestDaemon(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing the daemon", t, func(c C) {
+
+		dir, err := ioutil.TempDir("", "zeus-daemon")
+		c.So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "daemon.sock")
+
+		_, err = requestDaemonStatus(path)
+		c.So(err, ShouldEqual, ErrDaemonNotRunning)
+
+		d, err := newDaemon(path)
+		c.So(err, ShouldBeNil)
+
+		// only the owner can connect to the socket
+		info, err := os.Stat(path)
+		c.So(err, ShouldBeNil)
+		c.So(info.Mode().Perm(), ShouldEqual, os.FileMode(0600))
+
+		served := make(chan struct{})
+		go func() {
+			d.serve()
+			close(served)
+		}()
+
+		_, err = newDaemon(path)
+		c.So(err, ShouldEqual, ErrDaemonRunning)
+
+		s, err := requestDaemonStatus(path)
+		c.So(err, ShouldBeNil)
+		c.So(s.PID, ShouldEqual, os.Getpid())
+		c.So(s.Commands, ShouldBeGreaterThan, 0)
+		c.So(s.Runs, ShouldEqual, 0)
+
+		var (
+			out     bytes.Buffer
+			errOut  bytes.Buffer
+			client  = &daemonClient{stdout: &out, stderr: &errOut}
+			discard = &daemonClient{stdout: ioutil.Discard, stderr: ioutil.Discard}
+		)
+
+		// output is streamed back to the client
+		code, err := requestDaemonRun(path, []string{"greet", "name=daemon"}, client, nil)
+		c.So(err, ShouldBeNil)
+		c.So(code, ShouldEqual, 0)
+		c.So(out.String(), ShouldContainSubstring, "hello daemon")
+
+		out.Reset()
+		code, err = requestDaemonRun(path, []string{"pause seconds=0 -> greet name=chain"}, client, nil)
+		c.So(err, ShouldBeNil)
+		c.So(code, ShouldEqual, 0)
+		c.So(out.String(), ShouldContainSubstring, "hello chain")
+
+		// quoted arguments with pipe symbols are passed to the command
+		out.Reset()
+		code, err = requestDaemonRun(path, []string{"greet", "name='a|b'"}, client, nil)
+		c.So(err, ShouldBeNil)
+		c.So(code, ShouldEqual, 0)
+		c.So(out.String(), ShouldContainSubstring, "hello a|b")
+
+		// the commands get the environment, the input and the output streams of the client
+		out.Reset()
+		errOut.Reset()
+		code, err = requestDaemonRun(path, []string{"daemonClient"}, &daemonClient{
+			env:    append(os.Environ(), "ZEUS_DAEMON_TEST=forwarded"),
+			stdin:  strings.NewReader("from stdin\n"),
+			stdout: &out,
+			stderr: &errOut,
+		}, nil)
+		c.So(err, ShouldBeNil)
+		c.So(code, ShouldEqual, 0)
+		c.So(out.String(), ShouldContainSubstring, "env forwarded")
+		c.So(out.String(), ShouldContainSubstring, "input from stdin")
+		c.So(out.String(), ShouldNotContainSubstring, "error output")
+		c.So(errOut.String(), ShouldContainSubstring, "error output")
+
+		// without input, reading stdin returns EOF
+		out.Reset()
+		code, err = requestDaemonRun(path, []string{"daemonClient"}, client, nil)
+		c.So(err, ShouldBeNil)
+		c.So(code, ShouldEqual, 0)
+		c.So(out.String(), ShouldContainSubstring, "input \n")
+
+		// the exit code of the command is passed to the client
+		code, err = requestDaemonRun(path, []string{"fail"}, discard, nil)
+		c.So(err, ShouldNotBeNil)
+		c.So(code, ShouldEqual, 3)
+
+		code, err = requestDaemonRun(path, []string{"doesNotExist"}, discard, nil)
+		c.So(err.Error(), ShouldContainSubstring, ErrUnknownCommand.Error())
+		c.So(code, ShouldEqual, 1)
+
+		// interrupting the client cancels the run on the daemon
+		interrupt := make(chan os.Signal, 1)
+		go func() {
+			time.Sleep(300 * time.Millisecond)
+			interrupt <- os.Interrupt
+		}()
+
+		start := time.Now()
+		code, err = requestDaemonRun(path, []string{"pause", "seconds=10"}, discard, interrupt)
+		c.So(err, ShouldBeNil)
+		c.So(code, ShouldEqual, daemonInterruptedCode)
+		c.So(time.Since(start), ShouldBeLessThan, 5*time.Second)
+
+		for i := 0; i < 50; i++ {
+			if s, err = requestDaemonStatus(path); err == nil && s.Runs == 0 {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		c.So(s.Runs, ShouldEqual, 0)
+
+		// builtins, flags and pipelines are never forwarded
+		c.So(forwardToDaemon([]string{"greet"}), ShouldBeTrue)
+		c.So(forwardToDaemon([]string{"history", "stats"}), ShouldBeFalse)
+		c.So(forwardToDaemon([]string{"daemon", "stop"}), ShouldBeFalse)
+		c.So(forwardToDaemon([]string{"-h"}), ShouldBeFalse)
+		c.So(forwardToDaemon([]string{"greet | grep hello"}), ShouldBeFalse)
+		c.So(forwardToDaemon([]string{"greet", "name=a|b"}), ShouldBeTrue)
+		c.So(forwardToDaemon(nil), ShouldBeFalse)
+
+		c.So(requestDaemonStop(path), ShouldBeNil)
+		select {
+		case <-served:
+		case <-time.After(5 * time.Second):
+			t.Fatal("daemon did not stop")
+		}
+
+		_, err = requestDaemonStatus(path)
+		c.So(err, ShouldEqual, ErrDaemonNotRunning)
+	})
+}
+
+func TestDashboard(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing the dashboard", t, func(c C) {
+
+		// find the newest run of a chain
+		findRun := func(chain string) *activityNode {
+			activity.Lock()
+			defer activity.Unlock()
+			for i := len(activity.runs) - 1; i >= 0; i-- {
+				if activity.runs[i].name == chain {
+					return activity.runs[i]
+				}
+			}
+			return nil
+		}
+
+		// wait until the run is in the state
+		waitForRun := func(chain, state string) *activityNode {
+			for i := 0; i < 100; i++ {
+				if run := findRun(chain); run != nil {
+					activity.Lock()
+					s := run.state
+					activity.Unlock()
+					if s == state {
+						return run
+					}
+				}
+				time.Sleep(50 * time.Millisecond)
+			}
+			return nil
+		}
+
+		// chains are tracked with their commands and output
+		var buf bytes.Buffer
+		fields := []string{"pause seconds=0 ", " greet name=dashboard"}
+		cmdChain, ok := validCommandChain(fields)
+		c.So(ok, ShouldBeTrue)
+		c.So(cmdChain.exec(newOutputContext(&buf), fields), ShouldBeNil)
+
+		run := findRun("pause seconds=0 -> greet name=dashboard")
+		c.So(run, ShouldNotBeNil)
+		activity.Lock()
+		c.So(run.state, ShouldEqual, stateFinished)
+		c.So(len(run.children), ShouldEqual, 2)
+		c.So(run.children[0].name, ShouldEqual, "pause")
+		c.So(run.children[0].args, ShouldEqual, "seconds=0")
+		c.So(run.children[1].state, ShouldEqual, stateFinished)
+		c.So(strings.Join(run.children[1].output(), "\n"), ShouldContainSubstring, "hello dashboard")
+		c.So(strings.Join(run.children[0].output(), "\n"), ShouldNotContainSubstring, "hello dashboard")
+		c.So(strings.Join(run.output(), "\n"), ShouldContainSubstring, "hello dashboard")
+		activity.Unlock()
+
+		// single commands start their own run
+		cmd, err := cmdMap.getCommand("fail")
+		c.So(err, ShouldBeNil)
+		c.So(cmd.Run(newOutputContext(ioutil.Discard), false), ShouldNotBeNil)
+		run = findRun("fail")
+		c.So(run, ShouldNotBeNil)
+		activity.Lock()
+		c.So(run.state, ShouldEqual, stateFailed)
+		c.So(run.children[0].state, ShouldEqual, stateFailed)
+		activity.Unlock()
+
+		// output is cleaned up for the terminal
+		n := &activityNode{}
+		n.Write([]byte("\x1b[31mred\x1b[0m\r\n\tprogress 10%\r\tprogress 100%\nincomplete"))
+		activity.Lock()
+		c.So(n.output(), ShouldResemble, []string{"red", "    progress 100%", "incomplete"})
+		activity.Unlock()
+
+		// keys
+		c.So(dashboardKeys([]byte("\x1b[Aj\x1b[6~\x1bOB\x1b[C\t\r\x1b")), ShouldResemble, []string{"up", "j", "pgdn", "down", "tab", "enter", "esc"})
+
+		// the runs, output, processes and events are rendered
+		activity.addEvent("WRITE", "dashboard.go", "greet")
+		d := &dashboard{width: 120, height: 40}
+		d.selected = findRun("pause seconds=0 -> greet name=dashboard").children[1]
+		screen := d.render()
+		c.So(screen, ShouldContainSubstring, "Runs")
+		c.So(screen, ShouldContainSubstring, "Processes")
+		c.So(screen, ShouldContainSubstring, "Output: greet name=dashboard")
+		c.So(screen, ShouldContainSubstring, "hello dashboard")
+		c.So(screen, ShouldContainSubstring, "dashboard.go → greet")
+		c.So(strings.Count(screen, "\r\n"), ShouldEqual, 39)
+
+		// attaching shows the output on the whole screen
+		c.So(d.handleKey("a"), ShouldBeFalse)
+		c.So(d.attached, ShouldBeTrue)
+		c.So(d.render(), ShouldNotContainSubstring, "Processes")
+		c.So(d.handleKey("esc"), ShouldBeFalse)
+		c.So(d.attached, ShouldBeFalse)
+
+		// running chains can be cancelled
+		done := make(chan error)
+		go func() {
+			cmd, _ := cmdMap.getCommand("pause")
+			done <- cmd.Run(newOutputContext(ioutil.Discard).withArgs([]string{"seconds=10"}), false)
+		}()
+		run = waitForRun("pause seconds=10", stateRunning)
+		c.So(run, ShouldNotBeNil)
+
+		d.selected = run
+		c.So(d.handleKey("c"), ShouldBeFalse)
+		c.So(d.message, ShouldStartWith, "cancelled")
+		select {
+		case err = <-done:
+			c.So(err, ShouldEqual, ErrCancelled)
+		case <-time.After(5 * time.Second):
+			t.Fatal("run was not cancelled")
+		}
+		c.So(waitForRun("pause seconds=10", runCancelled), ShouldNotBeNil)
+
+		// restarting runs the chain again
+		old := findRun("greet name=dashboard")
+		greet, err := cmdMap.getCommand("greet")
+		c.So(err, ShouldBeNil)
+		c.So(greet.Run(newOutputContext(ioutil.Discard).withArgs([]string{"name=dashboard"}), false), ShouldBeNil)
+		old = findRun("greet name=dashboard")
+		d.selected = old.children[0]
+		c.So(d.handleKey("r"), ShouldBeFalse)
+		c.So(d.message, ShouldStartWith, "restarted")
+		for i := 0; i < 100 && findRun("greet name=dashboard") == old; i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+		c.So(findRun("greet name=dashboard"), ShouldNotEqual, old)
+		c.So(waitForRun("greet name=dashboard", stateFinished), ShouldNotBeNil)
+
+		// selection
+		d.selected = nil
+		d.render()
+		first := d.selected
+		c.So(d.handleKey("down"), ShouldBeFalse)
+		c.So(d.selected, ShouldNotEqual, first)
+		c.So(d.handleKey("k"), ShouldBeFalse)
+		c.So(d.selected, ShouldEqual, first)
+
+		c.So(d.handleKey("q"), ShouldBeTrue)
+	})
+}
+
+func TestMetrics(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing the metrics endpoint", t, func(c C) {
+
+		// counters and histograms
+		m := newMetricsRegistry()
+		m.commandFinished("build", nil, 1500*time.Millisecond, false)
+		m.commandFinished("build", errors.New("exit status 1"), time.Second, false)
+		m.commandFinished("build", ErrCancelled, time.Second, false)
+		m.commandFinished("serve", nil, time.Second, true)
+		m.commandSkipped("build")
+		m.eventFired("src", "WRITE")
+		m.eventFired("src", "WRITE")
+
+		var buf bytes.Buffer
+		c.So(m.write(&buf), ShouldBeNil)
+		out := buf.String()
+
+		c.So(out, ShouldContainSubstring, "# TYPE zeus_command_runs_total counter\n")
+		c.So(out, ShouldContainSubstring, `zeus_command_runs_total{command="build",result="ok"} 1`+"\n")
+		c.So(out, ShouldContainSubstring, `zeus_command_runs_total{command="build",result="failed"} 1`+"\n")
+		c.So(out, ShouldContainSubstring, `zeus_command_runs_total{command="build",result="cancelled"} 1`+"\n")
+		c.So(out, ShouldContainSubstring, `zeus_command_runs_total{command="build",result="skipped"} 1`+"\n")
+		c.So(out, ShouldContainSubstring, "# TYPE zeus_command_duration_seconds histogram\n")
+		c.So(out, ShouldContainSubstring, `zeus_command_duration_seconds_bucket{command="build",le="1"} 0`+"\n")
+		c.So(out, ShouldContainSubstring, `zeus_command_duration_seconds_bucket{command="build",le="2.5"} 1`+"\n")
+		c.So(out, ShouldContainSubstring, `zeus_command_duration_seconds_bucket{command="build",le="+Inf"} 1`+"\n")
+		c.So(out, ShouldContainSubstring, `zeus_command_duration_seconds_sum{command="build"} 1.5`+"\n")
+		c.So(out, ShouldContainSubstring, `zeus_command_duration_seconds_count{command="build"} 1`+"\n")
+		c.So(out, ShouldContainSubstring, `zeus_event_triggers_total{path="src",op="WRITE"} 2`+"\n")
+		c.So(out, ShouldContainSubstring, `zeus_watchers{type="internal"}`)
+		c.So(out, ShouldContainSubstring, `zeus_build_info{version="`+version+`"} 1`)
+
+		// detached commands are counted without their duration
+		c.So(out, ShouldContainSubstring, `zeus_command_runs_total{command="serve",result="ok"} 1`+"\n")
+		c.So(out, ShouldNotContainSubstring, `zeus_command_duration_seconds_count{command="serve"}`)
+
+		c.So(metricLabels("path", "a\"b\\c\nd"), ShouldEqual, `{path="a\"b\\c\nd"}`)
+
+		// commands are counted when they run
+		metrics.Lock()
+		before := metrics.command("greet").results[runOK]
+		metrics.Unlock()
+
+		cmd, err := cmdMap.getCommand("greet")
+		c.So(err, ShouldBeNil)
+		c.So(cmd.Run(newOutputContext(ioutil.Discard), false), ShouldBeNil)
+
+		metrics.Lock()
+		c.So(metrics.command("greet").results[runOK], ShouldEqual, before+1)
+		c.So(metrics.command("greet").count, ShouldBeGreaterThan, 0)
+		metrics.Unlock()
+
+		// the endpoint requires the session token or the metrics token
+		handler := secureHandler(createRouter())
+		request := func(path, token string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("GET", path, nil)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			return w
+		}
+
+		c.So(request(metricsPath, "").Code, ShouldEqual, http.StatusUnauthorized)
+
+		w := request(metricsPath, sessionToken)
+		c.So(w.Code, ShouldEqual, http.StatusOK)
+		c.So(w.Header().Get("Content-Type"), ShouldEqual, metricsContentType)
+		c.So(w.Body.String(), ShouldContainSubstring, `zeus_command_runs_total{command="greet",result="ok"}`)
+
+		conf.Lock()
+		conf.fields.MetricsToken = "scrape"
+		conf.Unlock()
+
+		c.So(request(metricsPath, "scrape").Code, ShouldEqual, http.StatusOK)
+		c.So(request(metricsPath, "invalid").Code, ShouldEqual, http.StatusUnauthorized)
+
+		// the metrics token is only valid for the metrics
+		c.So(request("/api/commands", "scrape").Code, ShouldEqual, http.StatusUnauthorized)
+
+		conf.Lock()
+		conf.fields.MetricsToken = ""
+		conf.Unlock()
+
+		c.So(request(metricsPath, "scrape").Code, ShouldEqual, http.StatusUnauthorized)
+	})
+}
+
+func TestNotifications(t *testing.T) {
+
+	TestMain(t)
+
+	Convey("Testing notification sinks", t, func(c C) {
+
+		// configuration errors
+		c.So((&notificationSink{Type: "pager"}).check(), ShouldNotBeNil)
+		c.So((&notificationSink{Type: notifyWebhook}).check(), ShouldNotBeNil)
+		c.So((&notificationSink{Type: notifyWebhook, URL: "ftp://example.com"}).check(), ShouldNotBeNil)
+		c.So((&notificationSink{Type: notifyWebhook, URL: "https://example.com", Body: "{{ .Command"}).check(), ShouldNotBeNil)
+		c.So((&notificationSink{Type: notifyBell, Results: []string{"skipped"}}).check(), ShouldNotBeNil)
+		c.So((&notificationSink{Type: notifyBell, MinDuration: "soon"}).check(), ShouldNotBeNil)
+		c.So((&notificationSink{Type: notifyWebhook, URL: "https://example.com/hook", Results: []string{runFailed}, MinDuration: "1m"}).check(), ShouldBeNil)
+		c.So((&notificationSink{Type: notifyDesktop}).check(), ShouldBeNil)
+
+		// filters
+		exitErr := exec.Command("sh", "-c", "exit 2").Run()
+		c.So(exitErr, ShouldNotBeNil)
+
+		n := newNotification("build", nil, exitErr, 2*time.Minute, "warning\nerror: missing file\n")
+		c.So(n.Result, ShouldEqual, runFailed)
+		c.So(n.ExitCode, ShouldEqual, 2)
+		c.So(n.Args, ShouldResemble, []string{})
+		c.So(n.Stderr, ShouldEqual, "warning\nerror: missing file")
+
+		c.So((&notificationSink{Type: notifyBell}).match(n), ShouldBeTrue)
+		c.So((&notificationSink{Type: notifyBell, Commands: []string{"build"}}).match(n), ShouldBeTrue)
+		c.So((&notificationSink{Type: notifyBell, Commands: []string{"test"}}).match(n), ShouldBeFalse)
+		c.So((&notificationSink{Type: notifyBell, Results: []string{runFailed, runCancelled}}).match(n), ShouldBeTrue)
+		c.So((&notificationSink{Type: notifyBell, Results: []string{runOK}}).match(n), ShouldBeFalse)
+		c.So((&notificationSink{Type: notifyBell, MinDuration: "1m"}).match(n), ShouldBeTrue)
+		c.So((&notificationSink{Type: notifyBell, MinDuration: "5m"}).match(n), ShouldBeFalse)
+
+		// only the tail of stderr is sent
+		var lines []string
+		for i := 0; i < 30; i++ {
+			lines = append(lines, "line "+strconv.Itoa(i))
+		}
+		tail := stderrTail(strings.Join(lines, "\n"))
+		c.So(strings.Count(tail, "\n"), ShouldEqual, stderrTailLines-1)
+		c.So(strings.HasPrefix(tail, "line 10\n"), ShouldBeTrue)
+		c.So(strings.HasSuffix(tail, "line 29"), ShouldBeTrue)
+		c.So(len(stderrTail(strings.Repeat("ä", stderrTailSize))), ShouldBeLessThanOrEqualTo, stderrTailSize)
+
+		// bodies
+		body, err := (&notificationSink{Type: notifyWebhook, URL: "https://example.com"}).body(n)
+		c.So(err, ShouldBeNil)
+		var payload map[string]interface{}
+		c.So(json.Unmarshal(body, &payload), ShouldBeNil)
+		c.So(payload["command"], ShouldEqual, "build")
+		c.So(payload["exitCode"], ShouldEqual, 2)
+		c.So(payload["seconds"], ShouldEqual, 120)
+
+		body, err = (&notificationSink{Type: notifyWebhook, URL: "https://example.com", Body: `{"text": {{ json .Stderr }}, "code": {{ .ExitCode }}}`}).body(n)
+		c.So(err, ShouldBeNil)
+		c.So(string(body), ShouldEqual, `{"text": "warning\nerror: missing file", "code": 2}`)
+
+		// desktop notifications
+		title, text := n.desktopText()
+		c.So(title, ShouldEqual, "build failed")
+		c.So(text, ShouldStartWith, "exit code 2 after ")
+		c.So(text, ShouldEndWith, "error: missing file")
+
+		title, _ = newNotification("build", nil, nil, time.Second, "noise").desktopText()
+		c.So(title, ShouldEqual, "build finished")
+
+		// webhooks
+		var (
+			mutex    sync.Mutex
+			received []map[string]interface{}
+			status   = http.StatusOK
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			var p map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&p)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if r.Header.Get("X-Token") == "secret" && r.Header.Get("Content-Type") == "application/json" {
+				received = append(received, p)
+			}
+			w.WriteHeader(status)
+		}))
+		defer server.Close()
+
+		hook := &notificationSink{Type: notifyWebhook, URL: server.URL, Headers: map[string]string{"X-Token": "secret"}}
+		c.So(hook.post(n), ShouldBeNil)
+
+		mutex.Lock()
+		c.So(len(received), ShouldEqual, 1)
+		status = http.StatusInternalServerError
+		mutex.Unlock()
+
+		c.So(hook.post(n), ShouldNotBeNil)
+
+		mutex.Lock()
+		received = nil
+		status = http.StatusOK
+		mutex.Unlock()
+
+		// the bell is written to the output
+		var buf bytes.Buffer
+		conf.Lock()
+		conf.fields.Notifications = []*notificationSink{{Type: notifyBell, Results: []string{runFailed}}}
+		conf.Unlock()
+
+		notifySinks(n, &buf)
+		c.So(buf.String(), ShouldEqual, "\a")
+
+		buf.Reset()
+		notifySinks(newNotification("build", nil, nil, time.Second, ""), &buf)
+		c.So(buf.String(), ShouldBeEmpty)
+
+		// finished commands are sent to the sinks
+		conf.Lock()
+		conf.fields.Notifications = []*notificationSink{hook}
+		conf.Unlock()
+
+		for _, name := range []string{"greet", "fail"} {
+			cmd, err := cmdMap.getCommand(name)
+			c.So(err, ShouldBeNil)
+			cmd.Run(newOutputContext(ioutil.Discard), false)
+		}
+		waitNotifications()
+
+		mutex.Lock()
+		c.So(len(received), ShouldEqual, 2)
+		c.So(received[0]["command"], ShouldEqual, "greet")
+		c.So(received[0]["result"], ShouldEqual, runOK)
+		c.So(received[1]["command"], ShouldEqual, "fail")
+		c.So(received[1]["result"], ShouldEqual, runFailed)
+		c.So(received[1]["exitCode"], ShouldEqual, 3)
+		received = nil
+		mutex.Unlock()
+
+		// a slow webhook does not block the command
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(time.Second)
+		}))
+		defer slow.Close()
+
+		conf.Lock()
+		conf.fields.Notifications = []*notificationSink{{Type: notifyWebhook, URL: slow.URL}, hook}
+		conf.Unlock()
+
+		cmd, err := cmdMap.getCommand("greet")
+		c.So(err, ShouldBeNil)
+
+		start := time.Now()
+		c.So(cmd.Run(newOutputContext(ioutil.Discard), false), ShouldBeNil)
+		c.So(time.Since(start), ShouldBeLessThan, 900*time.Millisecond)
+
+		waitNotifications()
+		c.So(time.Since(start), ShouldBeGreaterThanOrEqualTo, time.Second)
+
+		conf.Lock()
+		conf.fields.Notifications = nil
+		conf.Unlock()
+
+		mutex.Lock()
+		c.So(len(received), ShouldEqual, 1)
+		mutex.Unlock()
+	})
+}